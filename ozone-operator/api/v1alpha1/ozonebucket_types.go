@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OzoneBucketSpec defines an Ozone bucket managed in a cluster.
+type OzoneBucketSpec struct {
+	// Cluster names the OzoneCluster (same namespace) the bucket lives in.
+	Cluster string `json:"cluster"`
+
+	// Volume is the Ozone volume containing the bucket.
+	Volume string `json:"volume"`
+
+	// BucketName overrides the Ozone bucket name; defaults to the object name.
+	// +optional
+	BucketName string `json:"bucketName,omitempty"`
+
+	// Quota limits the space and key count of the bucket.
+	// +optional
+	Quota *QuotaSpec `json:"quota,omitempty"`
+
+	// EncryptionKey names the KMS key encrypting the bucket. Defaults to the cluster-wide
+	// spec.security.encryption.bucketKey; empty when neither is set creates an unencrypted
+	// bucket. Only applied at creation, since Ozone cannot encrypt an existing bucket.
+	// +optional
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+}
+
+// OzoneBucketStatus defines the observed state of a managed bucket.
+type OzoneBucketStatus struct {
+	// Conditions are the latest available observations of the bucket state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Usage reports the current quota usage as read from OM.
+	// +optional
+	Usage *QuotaUsageStatus `json:"usage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneBucket manages one Ozone bucket, including its quotas, in an OzoneCluster.
+type OzoneBucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneBucketSpec   `json:"spec,omitempty"`
+	Status OzoneBucketStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneBucketList contains a list of OzoneBucket.
+type OzoneBucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneBucket `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneBucket{}, &OzoneBucketList{})
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ACLIdentityType is the kind of identity an ACL entry grants rights to.
+type ACLIdentityType string
+
+const (
+	// ACLIdentityUser grants rights to a single user.
+	ACLIdentityUser ACLIdentityType = "user"
+
+	// ACLIdentityGroup grants rights to a group.
+	ACLIdentityGroup ACLIdentityType = "group"
+
+	// ACLIdentityWorld grants rights to everyone.
+	ACLIdentityWorld ACLIdentityType = "world"
+)
+
+// OzoneACLSpec is one ACL entry of a bucket, matching the type:name:rights form of
+// `ozone sh bucket setacl`.
+type OzoneACLSpec struct {
+	// Type of the identity the entry applies to.
+	// +kubebuilder:validation:Enum=user;group;world
+	Type ACLIdentityType `json:"type"`
+
+	// Name of the user or group; empty for world entries.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Rights is the Ozone rights string, e.g. "rw" or "a" for all.
+	Rights string `json:"rights"`
+}
+
+// TenantAssignmentSpec assigns users to an S3 multi-tenancy tenant.
+type TenantAssignmentSpec struct {
+	// Name of the tenant. The tenant must already exist.
+	Name string `json:"name"`
+
+	// Users assigned to the tenant; users already assigned are left untouched.
+	Users []string `json:"users"`
+}
+
+// OzoneBucketPolicySpec declares the access control of one bucket.
+type OzoneBucketPolicySpec struct {
+	// Cluster names the OzoneCluster (same namespace) holding the bucket.
+	Cluster string `json:"cluster"`
+
+	// Volume is the Ozone volume containing the bucket.
+	Volume string `json:"volume"`
+
+	// Bucket the policy applies to.
+	Bucket string `json:"bucket"`
+
+	// ACLs is the complete ACL list of the bucket. It is re-applied with setacl on every
+	// reconciliation, so entries added through direct API calls are removed again.
+	// +optional
+	ACLs []OzoneACLSpec `json:"acls,omitempty"`
+
+	// Tenant assigns users to an S3 tenant alongside the bucket ACLs.
+	// +optional
+	Tenant *TenantAssignmentSpec `json:"tenant,omitempty"`
+}
+
+// OzoneBucketPolicyStatus defines the observed state of a bucket policy.
+type OzoneBucketPolicyStatus struct {
+	// Conditions are the latest available observations of the policy state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneBucketPolicy manages the ACLs of one bucket and optional tenant user assignments in an
+// OzoneCluster, correcting drift introduced by direct API calls.
+type OzoneBucketPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneBucketPolicySpec   `json:"spec,omitempty"`
+	Status OzoneBucketPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneBucketPolicyList contains a list of OzoneBucketPolicy.
+type OzoneBucketPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneBucketPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneBucketPolicy{}, &OzoneBucketPolicyList{})
+}
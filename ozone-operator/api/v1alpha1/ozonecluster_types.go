@@ -0,0 +1,1723 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AntiAffinityMode selects how strongly pods of one component are spread across nodes.
+type AntiAffinityMode string
+
+const (
+	// AntiAffinityPreferred spreads pods across nodes on a best-effort basis.
+	AntiAffinityPreferred AntiAffinityMode = "Preferred"
+
+	// AntiAffinityRequired refuses to schedule two pods of the component onto the same node.
+	AntiAffinityRequired AntiAffinityMode = "Required"
+)
+
+// ComponentSpec holds pod-level settings shared by every Ozone component the operator manages.
+type ComponentSpec struct {
+	// Replicas is the number of pods to run for this component.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Image overrides spec.image for this component only, e.g. a custom S3 Gateway build. An
+	// explicit override also wins over the FIPS image selection.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the compute resources of the main container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env is appended to the environment of the main container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// JVMOptions are extra JVM flags of the component process, exported as OZONE_OPTS. When
+	// unset and a memory limit is configured, the defaulting webhook derives an -Xmx sized to
+	// the limit so the heap follows the cgroup.
+	// +optional
+	JVMOptions string `json:"jvmOptions,omitempty"`
+
+	// StartupTimeout is how long the startup probe lets the component boot before liveness takes
+	// over. Metadata services (SCM, OM, Recon) default to 10 minutes because opening a large
+	// RocksDB instance can take a while; gateways default to 2 minutes.
+	// +optional
+	StartupTimeout *metav1.Duration `json:"startupTimeout,omitempty"`
+
+	// TerminationGracePeriodSeconds of component pods. Datanodes default to 120 seconds so they
+	// can close their pipelines and containers cleanly; other components use the pod default.
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// UpdateStrategy of the component StatefulSet; ignored for components deployed as
+	// Deployments (S3 Gateway, HttpFS, Recon with an external database).
+	// +optional
+	UpdateStrategy *StatefulUpdateStrategySpec `json:"updateStrategy,omitempty"`
+
+	// NodeSelector constrains component pods to matching nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of component pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// AntiAffinity spreads component pods across nodes: Preferred generates a soft constraint,
+	// Required refuses to co-schedule two pods on one node. When empty the component default
+	// applies: Preferred for datanodes, none for the other components.
+	// +optional
+	AntiAffinity AntiAffinityMode `json:"antiAffinity,omitempty"`
+
+	// HostNetwork runs component pods in the host network namespace. The fixed Ozone ports must be
+	// free on the node, so this only makes sense together with anti-affinity that spreads the
+	// component one-per-node. Mostly used by bare-metal datanode and SCM deployments.
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// DNSPolicy of component pods. When HostNetwork is enabled and no policy is set,
+	// ClusterFirstWithHostNet is used so pods still resolve cluster-internal service names.
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+}
+
+// StorageSpec describes the persistent volume claimed for a component.
+type StorageSpec struct {
+	// Size of the persistent volume. For ephemeral storage this becomes the emptyDir size limit.
+	Size resource.Quantity `json:"size"`
+
+	// StorageClass of the persistent volume. Empty means the cluster default.
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// Ephemeral backs the component with an emptyDir instead of a persistent claim, so CI and
+	// demo clusters can run without PVCs. Data does not survive pod restarts; clusters with any
+	// ephemeral storage report the Durable condition as False.
+	// +optional
+	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// AutoExpand grows the claim before the metadata volume runs full, so RocksDB never hits
+	// "no space left". Requires a StorageClass with allowVolumeExpansion. Ignored for ephemeral
+	// storage.
+	// +optional
+	AutoExpand *StorageAutoExpandSpec `json:"autoExpand,omitempty"`
+}
+
+// StorageAutoExpandSpec configures usage-based expansion of a metadata claim.
+type StorageAutoExpandSpec struct {
+	// UsagePercent is the volume usage that triggers an expansion. Defaults to 80.
+	// +kubebuilder:validation:Minimum=50
+	// +kubebuilder:validation:Maximum=95
+	// +optional
+	UsagePercent *int32 `json:"usagePercent,omitempty"`
+
+	// StepPercent is how much the claim grows per expansion, relative to its current size.
+	// Defaults to 20.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepPercent *int32 `json:"stepPercent,omitempty"`
+
+	// MaxSize caps the claim size; expansion stops at the cap.
+	MaxSize resource.Quantity `json:"maxSize"`
+}
+
+// DataVolumeStorageType is the storage tier hint of a datanode volume.
+type DataVolumeStorageType string
+
+// Storage tiers understood by Ozone container placement.
+const (
+	StorageTypeSSD     DataVolumeStorageType = "SSD"
+	StorageTypeHDD     DataVolumeStorageType = "HDD"
+	StorageTypeArchive DataVolumeStorageType = "ARCHIVE"
+)
+
+// DataVolume describes one datanode storage volume; each entry becomes a volumeClaimTemplate and an
+// entry in hdds.datanode.dir.
+type DataVolume struct {
+	// Name of the volume, used as the volumeClaimTemplate name.
+	Name string `json:"name"`
+
+	// Size of the volume.
+	Size resource.Quantity `json:"size"`
+
+	// StorageClass of the volume. Empty means the cluster default.
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// Ephemeral backs the volume with an emptyDir instead of a persistent claim.
+	// +optional
+	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// StorageType hints the tier of this volume so container placement can distinguish tiers.
+	// Rendered as a storage-type tag on the hdds.datanode.dir entry; HDD maps to the DISK tag.
+	// +kubebuilder:validation:Enum=SSD;HDD;ARCHIVE
+	// +optional
+	StorageType DataVolumeStorageType `json:"storageType,omitempty"`
+
+	// Reserved is the space on this volume Ozone leaves unused
+	// (rendered into hdds.datanode.dir.du.reserved).
+	// +optional
+	Reserved *resource.Quantity `json:"reserved,omitempty"`
+}
+
+// SafemodeSpec controls how the operator reacts to SCM staying in safemode, typically after a
+// full-cluster cold start where SCM waits for datanodes to re-register.
+type SafemodeSpec struct {
+	// ForceExit lets the operator run `ozone admin safemode exit` once safemode lasts longer
+	// than After and enough datanodes have re-registered.
+	// +optional
+	ForceExit bool `json:"forceExit,omitempty"`
+
+	// After is how long SCM may stay in safemode before it is reported as prolonged and a forced
+	// exit is considered. Defaults to 10 minutes.
+	// +optional
+	After *metav1.Duration `json:"after,omitempty"`
+
+	// MinDatanodePercentage is the percentage of requested datanodes that must be ready before a
+	// forced exit. Defaults to 90.
+	// +optional
+	MinDatanodePercentage *int32 `json:"minDatanodePercentage,omitempty"`
+}
+
+// SafemodeStatus tracks the observed SCM safemode state.
+type SafemodeStatus struct {
+	// InSafemode is true while SCM reports safemode.
+	// +optional
+	InSafemode bool `json:"inSafemode,omitempty"`
+
+	// Since is when the operator first observed the current safemode period.
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// LastForcedExit is when the operator last forced a safemode exit.
+	// +optional
+	LastForcedExit *metav1.Time `json:"lastForcedExit,omitempty"`
+}
+
+// SCMSpec configures the Storage Container Manager.
+type SCMSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Storage holds SCM metadata (RocksDB and Ratis logs).
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+
+	// Safemode enables safemode monitoring and, optionally, forced exit after cold starts.
+	// +optional
+	Safemode *SafemodeSpec `json:"safemode,omitempty"`
+}
+
+// OMSpec configures the Ozone Manager.
+type OMSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Storage holds OM metadata (RocksDB and Ratis logs).
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+
+	// Rebootstrap enables automatic recovery of an OM follower whose metadata is corrupt or that
+	// fell irrecoverably behind the Ratis log. Disabled when unset.
+	// +optional
+	Rebootstrap *OMRebootstrapSpec `json:"rebootstrap,omitempty"`
+
+	// Compaction schedules full RocksDB compactions of the OM metadata store in a daily off-peak
+	// window, since unmanaged compaction debt causes latency spikes during peak hours.
+	// +optional
+	Compaction *CompactionSpec `json:"compaction,omitempty"`
+}
+
+// CompactionSpec is the daily off-peak window in which the operator triggers an OM metadata
+// compaction through `ozone admin om compact` on every OM replica. One compaction runs per window
+// occurrence; the runs are recorded in status.compactions.
+type CompactionSpec struct {
+	// Start of the window, as HH:MM in the operator local time.
+	Start string `json:"start"`
+
+	// End of the window. The window may wrap midnight.
+	End string `json:"end"`
+
+	// ColumnFamilies limits the compaction to the given OM column families, e.g. keyTable or
+	// fileTable. Empty compacts the whole metadata store.
+	// +optional
+	ColumnFamilies []string `json:"columnFamilies,omitempty"`
+}
+
+// OMRebootstrapSpec controls automatic re-bootstrap of a broken OM follower. A follower is
+// considered broken once it keeps crashing and its logs show metadata corruption or Ratis
+// snapshot-installation failures; the replacement pod then downloads a fresh checkpoint from the
+// leader.
+type OMRebootstrapSpec struct {
+	// AllowDataWipe permits deleting the broken follower's PVC so it re-bootstraps from the
+	// leader. Without it the operator only reports the follower through an Event and leaves its
+	// data in place for manual inspection.
+	// +optional
+	AllowDataWipe bool `json:"allowDataWipe,omitempty"`
+
+	// MinRestarts is how often the OM container must have crashed before the replica is treated
+	// as irrecoverable. Defaults to 5.
+	// +optional
+	MinRestarts *int32 `json:"minRestarts,omitempty"`
+}
+
+// DatanodeSpec configures the datanodes.
+type DatanodeSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Volumes are the storage volumes of each datanode.
+	// Defaults to a single 20Gi volume named "data".
+	// +optional
+	Volumes []DataVolume `json:"volumes,omitempty"`
+
+	// ParallelPodManagement starts and replaces datanode pods in parallel instead of strictly one
+	// ordinal at a time, so a large fleet comes back quickly after a cluster-wide restart.
+	// Datanodes have no startup ordering requirement among themselves; SCM and OM keep the
+	// OrderedReady default.
+	// +optional
+	ParallelPodManagement bool `json:"parallelPodManagement,omitempty"`
+
+	// SpotTolerant lets datanodes run on spot/preemptible nodes: the pods tolerate the well-known
+	// spot taints, a datanode whose node carries a termination notice is put into Ozone
+	// maintenance mode before the node disappears, and a Container Balancer run is triggered once
+	// replacement capacity registers. Only sensible for datanodes — replicated container data
+	// survives losing a node; SCM and OM should stay on regular nodes.
+	// +optional
+	SpotTolerant bool `json:"spotTolerant,omitempty"`
+
+	// FailedVolumePolicy reacts to storage volumes the datanode volume scanner has failed. The
+	// operator samples the failed volume count of every datanode pod from its metrics endpoint,
+	// surfaces the counts in status, and acts on datanodes exceeding the tolerated count.
+	// +optional
+	FailedVolumePolicy *FailedVolumePolicySpec `json:"failedVolumePolicy,omitempty"`
+
+	// Scanner tunes the datanode container and data scanners, the background bit-rot detection.
+	// The operator additionally samples the scan progress of every datanode pod into
+	// status.dataScans while this section is present.
+	// +optional
+	Scanner *ScannerSpec `json:"scanner,omitempty"`
+}
+
+// ScannerSpec maps the container and data scanner knobs onto their hdds.container.scrub
+// properties, so scan aggressiveness can be tuned per environment from the CR.
+type ScannerSpec struct {
+	// Enabled toggles the background scanners (hdds.container.scrub.enabled).
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MetadataScanInterval is the minimum gap between two metadata scans of the same container
+	// (hdds.container.scrub.metadata.scan.interval).
+	// +optional
+	MetadataScanInterval *metav1.Duration `json:"metadataScanInterval,omitempty"`
+
+	// DataScanInterval is the minimum gap between two full data scans of the same container
+	// (hdds.container.scrub.data.scan.interval).
+	// +optional
+	DataScanInterval *metav1.Duration `json:"dataScanInterval,omitempty"`
+
+	// Bandwidth caps the data scanner read throughput per volume
+	// (hdds.container.scrub.volume.bytes.per.second).
+	// +optional
+	Bandwidth *resource.Quantity `json:"bandwidth,omitempty"`
+}
+
+// FailedVolumeAction is what happens to a datanode with more failed volumes than tolerated.
+type FailedVolumeAction string
+
+const (
+	// FailedVolumeTolerate only surfaces the failure through the VolumeFailure condition.
+	FailedVolumeTolerate FailedVolumeAction = "Tolerate"
+
+	// FailedVolumeDecommission additionally decommissions the datanode through SCM, so its
+	// container replicas move to healthy nodes before the remaining volumes degrade further.
+	FailedVolumeDecommission FailedVolumeAction = "Decommission"
+)
+
+// FailedVolumePolicySpec governs the reaction to failed datanode volumes.
+type FailedVolumePolicySpec struct {
+	// Action taken on a datanode whose failed volume count exceeds toleratedFailures.
+	// Defaults to Tolerate.
+	// +kubebuilder:validation:Enum=Tolerate;Decommission
+	// +optional
+	Action FailedVolumeAction `json:"action,omitempty"`
+
+	// ToleratedFailures is how many failed volumes a datanode may carry before the action
+	// applies. Defaults to 0, acting on the first failed volume.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ToleratedFailures int32 `json:"toleratedFailures,omitempty"`
+}
+
+// ReconDatabaseSpec points Recon at an external SQL database instead of the embedded Derby
+// database on the data PVC.
+type ReconDatabaseSpec struct {
+	// URL is the JDBC connection string, e.g. jdbc:postgresql://db:5432/recon.
+	URL string `json:"url"`
+
+	// Driver is the JDBC driver class. Defaults to org.postgresql.Driver.
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// CredentialsSecret names a Secret with username and password keys for the connection.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// ReconSpec configures Recon. Recon is deployed only when this section is present.
+type ReconSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Storage holds the Recon database. Ignored when an external database is configured.
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+
+	// Database backs Recon with an external SQL database. With an external database Recon runs
+	// as a disposable Deployment instead of a StatefulSet with a PVC.
+	// +optional
+	Database *ReconDatabaseSpec `json:"database,omitempty"`
+
+	// Suspended scales Recon to zero replicas while keeping its Service and PVC, so a
+	// misbehaving Recon can be shut down temporarily without losing its database, unlike
+	// removing this section.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// StatefulUpdateStrategySpec configures how a component StatefulSet rolls out spec changes.
+type StatefulUpdateStrategySpec struct {
+	// Type of the update strategy: RollingUpdate or OnDelete. OnDelete leaves the rollout
+	// entirely to an administrator (or the upgrade tooling) deleting pods. Defaults to
+	// RollingUpdate.
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Partition holds back pods with an ordinal below this value during a RollingUpdate, so a
+	// large datanode fleet can be rolled in controlled batches by lowering it step by step.
+	// +optional
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// RollingUpdateSpec tunes a surge rolling update of a stateless component Deployment.
+type RollingUpdateSpec struct {
+	// MaxSurge is the number or percentage of extra pods allowed during an update.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that may be unavailable during an update.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// S3GatewayServiceSpec tunes how S3 Gateway traffic is routed.
+type S3GatewayServiceSpec struct {
+	// Type of the gateway Service, e.g. LoadBalancer or NodePort. The gateway keeps its default
+	// headless ClusterIP Service when empty.
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// SessionAffinity of the gateway Service (None or ClientIP). ClientIP keeps the requests of
+	// one client, such as a multipart upload, on one gateway pod.
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// ExternalTrafficPolicy of the gateway Service; only applied to NodePort and LoadBalancer
+	// services.
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// TopologyAwareRouting enables topology-aware routing hints so in-zone clients are preferably
+	// served by in-zone gateway pods.
+	// +optional
+	TopologyAwareRouting bool `json:"topologyAwareRouting,omitempty"`
+
+	// PerZoneServices lists availability zones; for each zone the operator runs a gateway
+	// Deployment pinned to that zone and a Service routing only to it, replacing the single
+	// zone-less Deployment.
+	// +optional
+	PerZoneServices []string `json:"perZoneServices,omitempty"`
+
+	// Annotations added to the gateway Service, e.g. cloud provider load balancer settings.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Hostname is published through the external-dns hostname annotation, so an external-dns
+	// deployment creates the DNS record for the gateway Service.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// LoadBalancerIP requests a specific load balancer IP; support depends on the cloud provider.
+	// Only applied to LoadBalancer services.
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// LoadBalancerClass selects the load balancer implementation of a LoadBalancer service.
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client CIDRs allowed through the load balancer.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// S3GatewaySpec configures the S3 Gateway. The gateway is deployed only when this section is present.
+type S3GatewaySpec struct {
+	ComponentSpec `json:",inline"`
+
+	// RollingUpdate tunes the surge rolling update of the gateway Deployment, so upgrades do not
+	// serially remove gateway capacity.
+	// +optional
+	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
+
+	// Service tunes session affinity and routing of gateway traffic.
+	// +optional
+	Service *S3GatewayServiceSpec `json:"service,omitempty"`
+
+	// PublishEndpointSecret writes a <cluster>-s3-connection Secret with the S3 endpoint and
+	// generated bootstrap credentials, so application operators and service bindings can discover
+	// the connection without parsing Services. Credentials are generated once and never rotated
+	// by the operator.
+	// +optional
+	PublishEndpointSecret bool `json:"publishEndpointSecret,omitempty"`
+
+	// ScalingWindows overrides the gateway replica count during recurring daily windows, for
+	// predictable diurnal workloads where HPA reaction is too slow or metrics are unavailable.
+	// The first matching window wins; outside every window the plain replica count applies.
+	// +optional
+	ScalingWindows []ScalingWindow `json:"scalingWindows,omitempty"`
+
+	// AccessLog writes per-request access records of the gateway into a dedicated log file and
+	// ships them to stdout of a tailing sidecar container, for audits and usage analytics.
+	// +optional
+	AccessLog *S3AccessLogSpec `json:"accessLog,omitempty"`
+
+	// DomainName enables virtual-host-style bucket addressing: the gateway resolves bucket names
+	// from Host headers of the form <bucket>.<domainName> (ozone.s3g.domain.name), and the
+	// Gateway API route additionally matches the wildcard *.<domainName> so per-bucket DNS names
+	// reach the gateway. Without it only path-style requests work.
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+
+	// Limits tunes the connection and request handling capacity of the gateway HTTP server and
+	// optionally fronts the gateway with a rate-limiting sidecar, to protect it from abusive
+	// clients.
+	// +optional
+	Limits *S3GatewayLimitsSpec `json:"limits,omitempty"`
+
+	// Suspended scales the gateway to zero replicas while keeping its Services and endpoint
+	// Secret, so S3 traffic can be stopped temporarily without dismantling the gateway setup.
+	// It overrides scalingWindows.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// S3AccessLogSpec enables the S3 Gateway request log. The records land in a rolling file on a
+// shared emptyDir; a sidecar container tails the file to its own stdout so log collectors pick up
+// access records separately from the gateway service log.
+type S3AccessLogSpec struct {
+	// Format is the log4j conversion pattern of each access record. Defaults to "%m%n", the
+	// plain NCSA line emitted by the Hadoop request log.
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// SidecarImage runs the tailing sidecar. Defaults to the gateway image.
+	// +optional
+	SidecarImage string `json:"sidecarImage,omitempty"`
+}
+
+// S3GatewayLimitsSpec tunes the gateway HTTP server capacity. The thread and connection knobs map
+// onto the embedded Hadoop HTTP server properties of the gateway container only; the other
+// components keep their defaults.
+type S3GatewayLimitsSpec struct {
+	// HandlerThreads caps the request handler threads of the gateway HTTP server
+	// (hadoop.http.max.threads).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HandlerThreads *int32 `json:"handlerThreads,omitempty"`
+
+	// MaxConnections caps the TCP connections the gateway accepts before new connections queue in
+	// the socket backlog (hadoop.http.socket.backlog.size).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// IdleTimeout closes keep-alive connections that stay idle this long
+	// (hadoop.http.idle_timeout.ms).
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// RateLimit fronts the gateway with an nginx sidecar enforcing per-client request limits.
+	// +optional
+	RateLimit *S3RateLimitSpec `json:"rateLimit,omitempty"`
+}
+
+// S3RateLimitSpec enforces per-client request rates through an nginx sidecar. The gateway Service
+// sends S3 traffic through the sidecar, which rejects requests above the limit with 429 before
+// they reach the gateway.
+type S3RateLimitSpec struct {
+	// RequestsPerSecond is the sustained request rate allowed per client.
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Burst allows short bursts above the sustained rate before requests are rejected.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// PerAccessKey keys the limit on the S3 access key from the Authorization header instead of
+	// the client IP. Unauthenticated requests still fall back to the client IP.
+	// +optional
+	PerAccessKey bool `json:"perAccessKey,omitempty"`
+
+	// Image runs the rate-limiting sidecar. Defaults to a pinned nginx image.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ScalingWindow is one recurring daily scaling window given as HH:MM pairs in the operator local
+// time. The window may wrap midnight (start 21:00, end 09:00).
+type ScalingWindow struct {
+	// Start of the window.
+	Start string `json:"start"`
+
+	// End of the window.
+	End string `json:"end"`
+
+	// Replicas the gateway runs during the window.
+	Replicas int32 `json:"replicas"`
+}
+
+// BalancerSchedule restricts balancing to a daily time window given as HH:MM pairs in the operator
+// local time. The window may wrap midnight (start 22:00, end 06:00).
+type BalancerSchedule struct {
+	// Start of the window.
+	Start string `json:"start"`
+
+	// End of the window.
+	End string `json:"end"`
+}
+
+// BalancerSpec configures the SCM Container Balancer. The operator drives the balancer run state
+// through `ozone admin containerbalancer start/stop` against the SCM.
+type BalancerSpec struct {
+	// Enabled keeps the Container Balancer running (within Schedule, when one is set).
+	// Disabling stops a running balancer.
+	Enabled bool `json:"enabled"`
+
+	// Threshold is the datanode utilization deviation, in percent, above which datanodes take
+	// part in balancing.
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+
+	// MaxDatanodesPercentage is the maximum percentage of datanodes involved in one iteration.
+	// +optional
+	MaxDatanodesPercentage *int32 `json:"maxDatanodesPercentage,omitempty"`
+
+	// MaxSizeToMovePerIteration bounds the data moved, and so the bandwidth consumed, in one
+	// balancing iteration.
+	// +optional
+	MaxSizeToMovePerIteration *resource.Quantity `json:"maxSizeToMovePerIteration,omitempty"`
+
+	// Schedule restricts balancing to a daily window; outside it a running balancer is stopped.
+	// +optional
+	Schedule *BalancerSchedule `json:"schedule,omitempty"`
+}
+
+// BalancerStatus reports the Container Balancer run state.
+type BalancerStatus struct {
+	// Running is true while the operator keeps the balancer started on the SCM.
+	// +optional
+	Running bool `json:"running,omitempty"`
+
+	// LastTransitionTime is when the operator last started or stopped the balancer.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// HttpFSSpec configures the HttpFS gateway offering WebHDFS-style REST access to Ozone. The
+// gateway is deployed only when this section is present.
+type HttpFSSpec struct {
+	ComponentSpec `json:",inline"`
+}
+
+// KMSSpec configures the embedded Hadoop KMS. The KMS is deployed only when this section is
+// present; the generated TDE configuration then points every component at it, unless
+// spec.security.encryption names an external KMS instead.
+type KMSSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Storage holds the keystore with the key material.
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+
+	// KeystorePasswordSecret names a Secret in the cluster namespace with a password entry
+	// protecting the keystore. The Hadoop default keystore password is used when empty.
+	// +optional
+	KeystorePasswordSecret string `json:"keystorePasswordSecret,omitempty"`
+}
+
+// OzoneClusterSpec defines the desired state of an Ozone cluster.
+type OzoneClusterSpec struct {
+	// Image is the Ozone image run by every component.
+	Image string `json:"image"`
+
+	// Version is the Ozone release the image carries, e.g. "1.4.0". Some property names and
+	// defaults changed across releases, and the generated configuration is adjusted to the
+	// deployed version. Defaults to the image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ImagePullPolicy of all containers.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImageArchitectures lists the node architectures (kubernetes.io/arch values such as amd64
+	// or arm64) the configured images are built for. When set, every pod gets a node affinity
+	// restricting scheduling to those architectures, so a single-arch image never lands on an
+	// incompatible node.
+	// +optional
+	ImageArchitectures []string `json:"imageArchitectures,omitempty"`
+
+	// SCM configures the Storage Container Manager.
+	// +optional
+	SCM SCMSpec `json:"scm,omitempty"`
+
+	// OM configures the Ozone Manager.
+	// +optional
+	OM OMSpec `json:"om,omitempty"`
+
+	// Datanode configures the datanodes.
+	// +optional
+	Datanode DatanodeSpec `json:"datanode,omitempty"`
+
+	// Recon deploys Recon when present.
+	// +optional
+	Recon *ReconSpec `json:"recon,omitempty"`
+
+	// S3Gateway deploys the S3 Gateway when present.
+	// +optional
+	S3Gateway *S3GatewaySpec `json:"s3g,omitempty"`
+
+	// HttpFS deploys the HttpFS gateway when present.
+	// +optional
+	HttpFS *HttpFSSpec `json:"httpfs,omitempty"`
+
+	// KMS deploys an embedded Hadoop KMS when present, for clusters without an external KMS.
+	// +optional
+	KMS *KMSSpec `json:"kms,omitempty"`
+
+	// Balancer manages the SCM Container Balancer when present.
+	// +optional
+	Balancer *BalancerSpec `json:"balancer,omitempty"`
+
+	// Replication sets the cluster-wide default replication scheme.
+	// +optional
+	Replication *ReplicationSpec `json:"replication,omitempty"`
+
+	// PodRecovery enables force-deletion of pods stuck on lost nodes when present.
+	// +optional
+	PodRecovery *PodRecoverySpec `json:"podRecovery,omitempty"`
+
+	// Diagnostics enables automatic diagnostics capture when a component container crashes.
+	// +optional
+	Diagnostics *DiagnosticsSpec `json:"diagnostics,omitempty"`
+
+	// Monitoring enables JVM telemetry evaluation: the operator watches heap occupancy through
+	// the /prom endpoints and generates Prometheus alerting rules for GC pauses and heap
+	// pressure, flagging memory-starved components before they are OOM-killed.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Profiling marks component pods for continuous profiling, so performance regressions in
+	// OM or SCM after an upgrade can be diagnosed without manual flame-graph collection.
+	// +optional
+	Profiling *ProfilingSpec `json:"profiling,omitempty"`
+
+	// Security protects cluster endpoints; currently the /prom metrics endpoints, which are
+	// otherwise readable by anything that can reach the pod network.
+	// +optional
+	Security *SecuritySpec `json:"security,omitempty"`
+
+	// StartupGates customizes the init containers that sequence component startup.
+	// +optional
+	StartupGates *StartupGateSpec `json:"startupGates,omitempty"`
+
+	// TopologyAware spreads SCM and OM pods across zones and feeds the datanode zone layout into
+	// the Ozone network topology, making replica placement zone-aware end to end.
+	// +optional
+	TopologyAware bool `json:"topologyAware,omitempty"`
+
+	// PerPodServices creates one ClusterIP Service per SCM and OM pod, named like the pod, so
+	// clients in environments that cannot resolve StatefulSet pod DNS still reach individual
+	// replicas through stable addresses.
+	// +optional
+	PerPodServices bool `json:"perPodServices,omitempty"`
+
+	// Hibernate scales every component to zero while keeping PVCs and configuration intact, so a
+	// dev or staging cluster stops costing compute without losing data. Components stop in safe
+	// order (gateways, datanodes, OM, SCM) and restart in reverse when unset.
+	// +optional
+	Hibernate bool `json:"hibernate,omitempty"`
+
+	// AllowSCMResize acknowledges a change of spec.scm.replicas on a live cluster. Growing the SCM
+	// Ratis ring bootstraps new SCMs and rolls the configuration of every component, so the
+	// webhook rejects replica changes unless this is set. Shrinking is never allowed.
+	// +optional
+	AllowSCMResize bool `json:"allowScmResize,omitempty"`
+
+	// AdoptExisting lets the operator take ownership of same-named StatefulSets, Services, and
+	// ConfigMaps it did not create, e.g. from a community Helm chart release named like this
+	// cluster, attaching owner references and reconciling them towards this spec. Without it,
+	// clashing foreign resources fail the reconcile instead of being silently overwritten.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// DisasterRecovery makes the cluster take part in a primary/standby pair when present.
+	// +optional
+	DisasterRecovery *DisasterRecoverySpec `json:"disasterRecovery,omitempty"`
+
+	// Backup configures the off-cluster backup destination when present. The operator validates
+	// the destination at admission and probes its reachability, reported through the
+	// BackupDestinationReachable condition.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+
+	// Restore seeds the namespace of this cluster from a backup destination once the OM is up.
+	// The restore Job copies key by key and skips keys already present with the right size, so an
+	// interrupted restore of a multi-hundred-GB backup resumes where it stopped instead of
+	// restarting from scratch.
+	// +optional
+	Restore *RestoreSpec `json:"restore,omitempty"`
+
+	// DeletionPolicy controls what happens to the persisted data when this OzoneCluster is
+	// deleted. Retain, the default, leaves the PVCs behind. Delete removes them. BackupThenDelete
+	// first runs one full backup to spec.backup.destination and removes the PVCs only after it
+	// finished or timed out.
+	// +kubebuilder:validation:Enum=Retain;Delete;BackupThenDelete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DisabledComponentPolicy controls what happens to the workload of a previously enabled
+	// optional component (Recon, S3 Gateway, HttpFS, KMS) when its spec section is removed.
+	// Suspend, the default, scales the workload to zero but keeps it together with its Services
+	// and PVCs, so re-adding the section brings the component back with its data. Purge deletes
+	// the workload, its Services, and its PVCs.
+	// +kubebuilder:validation:Enum=Suspend;Purge
+	// +optional
+	DisabledComponentPolicy DisabledComponentPolicy `json:"disabledComponentPolicy,omitempty"`
+
+	// Notifications pushes operator-level events — hibernation and failover transitions, backup
+	// failures, Degraded flips — to Slack, a generic webhook, or email, for teams that do not run
+	// Alertmanager for operator events.
+	// +optional
+	Notifications *NotificationsSpec `json:"notifications,omitempty"`
+
+	// DeletionProtection makes the validating webhook reject deletion of this OzoneCluster until
+	// the field is explicitly set to false. When unset, deletion is rejected while the cluster
+	// reports Available, so a running cluster holding user data cannot be removed by accident.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// ExternalAccess exposes SCM and OM outside the Kubernetes cluster when present, so datanodes
+	// running on external hosts can register with the in-cluster control plane.
+	// +optional
+	ExternalAccess *ExternalAccessSpec `json:"externalAccess,omitempty"`
+
+	// GatewayAPI attaches the S3 Gateway and Recon UI to a referenced Gateway API Gateway through
+	// HTTPRoutes (or TLSRoutes), for clusters standardizing on Gateway API instead of Ingress.
+	// The Gateway API CRDs must be installed.
+	// +optional
+	GatewayAPI *GatewayAPISpec `json:"gatewayAPI,omitempty"`
+
+	// APIProxy puts a kube-rbac-proxy sidecar in front of selected read-only Recon and OM HTTP
+	// APIs when present. Callers authenticate with a Kubernetes bearer token that the sidecar
+	// verifies through TokenReview and authorizes through SubjectAccessReview, so dashboards
+	// consume cluster insight without reaching the unauthenticated pod ports.
+	// +optional
+	APIProxy *APIProxySpec `json:"apiProxy,omitempty"`
+
+	// ExternalControlPlane attaches the datanodes to SCM and OM instances running outside
+	// Kubernetes when present; SCM and OM are then not reconciled by this operator.
+	// +optional
+	ExternalControlPlane *ExternalControlPlaneSpec `json:"externalControlPlane,omitempty"`
+
+	// TemplateRef names a cluster-scoped OzoneConfigTemplate whose config, tuning, and security
+	// defaults the defaulting webhook merges into this spec at admission. Values set on this
+	// cluster win over the template.
+	// +optional
+	TemplateRef string `json:"templateRef,omitempty"`
+
+	// Tuning sets the most impactful Ratis and RocksDB performance knobs through typed,
+	// validated fields; the operator maps them onto their ozone-site properties. Entries in
+	// spec.config still win on conflict.
+	// +optional
+	Tuning *TuningSpec `json:"tuning,omitempty"`
+
+	// Trash enables the Hadoop trash for o3fs and ofs deletions: deleted files move to a
+	// .Trash directory and are permanently removed only after the configured interval, giving
+	// users a window to recover accidental deletes.
+	// +optional
+	Trash *TrashSpec `json:"trash,omitempty"`
+
+	// ExtraConfigFiles maps file names to contents shipped to every component under
+	// /opt/hadoop/etc/extra, e.g. log4j2 configurations or Prometheus JMX rules. Contents and
+	// spec.config values may use Go template expressions over cluster metadata, such as
+	// {{ .Name }}, {{ .Namespace }}, or {{ .OMAddress }}.
+	// +optional
+	ExtraConfigFiles map[string]string `json:"extraConfigFiles,omitempty"`
+
+	// Config holds extra ozone-site.xml properties, merged over the generated defaults.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ExternalAccessSpec exposes the SCM and OM RPC endpoints through dedicated Services so external
+// (bare-metal) datanodes can reach the in-cluster control plane.
+type ExternalAccessSpec struct {
+	// ServiceType of the exposure Services: LoadBalancer or NodePort. Defaults to LoadBalancer.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Address is the stable, externally resolvable address advertised to external datanodes,
+	// typically a DNS name pointing at the LoadBalancer or the node pool.
+	Address string `json:"address"`
+
+	// Annotations added to the exposure Services, e.g. cloud provider load balancer settings.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayAPISpec exposes the S3 endpoint and the Recon UI through Gateway API routes attached to
+// an existing Gateway, instead of an Ingress.
+type GatewayAPISpec struct {
+	// GatewayName names the Gateway the routes attach to.
+	GatewayName string `json:"gatewayName"`
+
+	// GatewayNamespace is the namespace of the Gateway. Defaults to the cluster namespace; a
+	// Gateway in another namespace must allow routes from the cluster namespace.
+	// +optional
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+
+	// SectionName restricts the routes to one listener of the Gateway; they attach to every
+	// compatible listener when empty.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+
+	// S3Hostname is the hostname the S3 Gateway route matches, e.g. s3.example.com. The route
+	// matches every hostname of the listener when empty.
+	// +optional
+	S3Hostname string `json:"s3Hostname,omitempty"`
+
+	// ReconHostname is the hostname the Recon UI route matches.
+	// +optional
+	ReconHostname string `json:"reconHostname,omitempty"`
+
+	// TLSPassthrough creates TLSRoutes instead of HTTPRoutes: the Gateway forwards on SNI and the
+	// components terminate TLS themselves. At least one hostname must be set, since TLSRoutes
+	// can only match on SNI.
+	// +optional
+	TLSPassthrough bool `json:"tlsPassthrough,omitempty"`
+}
+
+// APIProxySpec configures the authenticated read-only API proxy sidecar. The component pods'
+// service account must be allowed to create TokenReviews and SubjectAccessReviews, e.g. through
+// a binding to the system:auth-delegator ClusterRole.
+type APIProxySpec struct {
+	// Image of the kube-rbac-proxy sidecar.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Components fronted by the proxy: recon, om. Both when empty.
+	// +optional
+	Components []string `json:"components,omitempty"`
+
+	// AllowPaths restricts the proxied path prefixes. Defaults to the read-only insight
+	// endpoints /api/v1/*, /prom, and /jmx.
+	// +optional
+	AllowPaths []string `json:"allowPaths,omitempty"`
+}
+
+// PodRecoverySpec configures force-deletion of StatefulSet pods stuck on unreachable or deleted
+// nodes, so the cluster heals after a node loss instead of waiting indefinitely.
+type PodRecoverySpec struct {
+	// GraceTimeout is how long a pod may stay stuck on a lost node before it is force-deleted.
+	// Defaults to 5 minutes.
+	// +optional
+	GraceTimeout *metav1.Duration `json:"graceTimeout,omitempty"`
+
+	// IncludeS3Gateway also recovers stuck S3 Gateway pods, not only datanodes.
+	// +optional
+	IncludeS3Gateway bool `json:"includeS3Gateway,omitempty"`
+}
+
+// StartupGateSpec customizes the wait init containers that hold a component back until the
+// services it depends on accept connections (datanodes and OM wait for SCM, gateways for OM).
+type StartupGateSpec struct {
+	// Disabled removes the wait init containers; startup order is then left to crash-loop retries.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Image of a dedicated gate image containing the standalone /wait binary (built from
+	// cmd/wait), so the gates do not pull the full Ozone image. When empty the gates fall back to
+	// a shell TCP probe in the Ozone image.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Timeout after which a gate gives up and fails the pod start instead of waiting forever.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DiagnosticsSpec configures what is captured into a diagnostics ConfigMap when a component
+// container exits abnormally or is OOM-killed, so crashes leave more behind than a restart count.
+type DiagnosticsSpec struct {
+	// LogTailLines is how many lines of the crashed container log are captured. Defaults to 200.
+	// +optional
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+
+	// CaptureThreadDump also captures a jstack thread dump of the restarted JVM, giving a view of
+	// the process state shortly after the crash.
+	// +optional
+	CaptureThreadDump bool `json:"captureThreadDump,omitempty"`
+}
+
+// MonitoringSpec configures JVM telemetry thresholds. The same thresholds drive the
+// MemoryStarved condition and the generated Prometheus alerting rules.
+type MonitoringSpec struct {
+	// HeapWarningPercent is the heap occupancy percentage above which a component is considered
+	// memory-starved. Defaults to 85.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HeapWarningPercent *int32 `json:"heapWarningPercent,omitempty"`
+
+	// GCPauseWarning is the p99 GC pause beyond which the generated alerting rule fires.
+	// Defaults to 1s.
+	// +optional
+	GCPauseWarning *metav1.Duration `json:"gcPauseWarning,omitempty"`
+}
+
+// ProfilingSpec wires the component pods into a Pyroscope-compatible continuous profiler: the
+// pods get the discovery annotations and the JVMs get the agent configuration env entries the
+// image's profiling hook consumes.
+type ProfilingSpec struct {
+	// ServerAddress is the profile ingestion endpoint, e.g. http://pyroscope.monitoring:4040.
+	ServerAddress string `json:"serverAddress"`
+
+	// Components limits profiling to the listed components (scm, om, datanode, recon, s3g,
+	// httpfs). Every component is profiled when empty.
+	// +optional
+	Components []string `json:"components,omitempty"`
+}
+
+// SecuritySpec groups endpoint protection settings of the cluster.
+type SecuritySpec struct {
+	// Metrics protects the /prom endpoint every component serves.
+	// +optional
+	Metrics *MetricsSecuritySpec `json:"metrics,omitempty"`
+
+	// FIPS runs every component in restricted-crypto mode: the FIPS-ready image replaces
+	// spec.image, generated configs pin TLS 1.2+ with a FIPS keystore type, and incompatible
+	// crypto overrides in spec.config are rejected at admission.
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+
+	// FIPSImage is the FIPS-ready image used while fips is set. Defaults to spec.image with a
+	// "-fips" tag suffix.
+	// +optional
+	FIPSImage string `json:"fipsImage,omitempty"`
+
+	// UI puts an OAuth2 login in front of the SCM, OM, and Recon web UIs, which are otherwise
+	// served without any authentication.
+	// +optional
+	UI *UIAuthSpec `json:"ui,omitempty"`
+
+	// ReadOnlyRootFilesystem hardens every component pod: containers run with a read-only root
+	// filesystem and the directories Ozone needs to write outside its data volume (/tmp, logs,
+	// pid files) become emptyDir mounts, so the pods pass common admission policies.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// Encryption enables Transparent Data Encryption: the generated ozone-site/core-site
+	// properties point every component at the Hadoop KMS, and buckets created through
+	// OzoneBucket objects default to the configured encryption key.
+	// +optional
+	Encryption *EncryptionSpec `json:"encryption,omitempty"`
+}
+
+// EncryptionSpec configures Transparent Data Encryption through an external Hadoop KMS.
+type EncryptionSpec struct {
+	// KMSURI is the Hadoop KMS key provider URI, e.g. kms://http@kms.kms.svc:9600/kms.
+	KMSURI string `json:"kmsURI"`
+
+	// BucketKey is the encryption key name applied to buckets created through OzoneBucket
+	// objects that do not name their own key. The key must already exist in the KMS.
+	// +optional
+	BucketKey string `json:"bucketKey,omitempty"`
+}
+
+// MetricsAuthType selects the authentication scheme of the metrics endpoints.
+type MetricsAuthType string
+
+const (
+	// MetricsAuthBasic requires HTTP basic authentication on /prom.
+	MetricsAuthBasic MetricsAuthType = "Basic"
+
+	// MetricsAuthBearer requires a bearer token on /prom.
+	MetricsAuthBearer MetricsAuthType = "Bearer"
+)
+
+// MetricsSecuritySpec enables TLS and authentication on the component /prom endpoints. The
+// operator wires the referenced Secrets into the components and presents the same credentials
+// when it samples JVM telemetry itself.
+type MetricsSecuritySpec struct {
+	// TLSSecret names a kubernetes.io/tls Secret in the cluster namespace whose certificate
+	// serves the component web endpoints over HTTPS. The operator's HTTP client must trust the
+	// certificate for telemetry sampling to keep working.
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+
+	// Auth selects the authentication scheme required by /prom.
+	// +kubebuilder:validation:Enum=Basic;Bearer
+	// +optional
+	Auth MetricsAuthType `json:"auth,omitempty"`
+
+	// CredentialsSecret names the Secret carrying the credentials: the keys username and
+	// password for Basic, the key token for Bearer. Required when auth is set.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// UIAuthSpec puts an oauth2-proxy sidecar in front of the component web UIs: the sidecar handles
+// the OIDC login and forwards authenticated requests to the UI port on localhost, and the
+// component Services send UI traffic to the sidecar instead of the unauthenticated port.
+type UIAuthSpec struct {
+	// IssuerURL is the OIDC issuer the sidecar authenticates against,
+	// e.g. https://accounts.example.com/realms/ozone.
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientCredentialsSecret names a Secret in the cluster namespace with the entries
+	// client-id, client-secret, and cookie-secret consumed by the sidecar.
+	ClientCredentialsSecret string `json:"clientCredentialsSecret"`
+
+	// Image is the oauth2-proxy image run as the sidecar. A pinned upstream release is used when
+	// empty.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Components limits UI protection to the listed components (scm, om, recon). All three are
+	// protected when empty.
+	// +optional
+	Components []string `json:"components,omitempty"`
+}
+
+// BackupS3Spec holds the S3 connection settings of the backup destination bucket.
+type BackupS3Spec struct {
+	// Endpoint is the S3 endpoint URL, e.g. https://minio.backup.svc:9000. When empty the AWS
+	// endpoint of Region is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region of the destination bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecret names a Secret in the cluster namespace with accessKey and secretKey
+	// entries used to write backups.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// BackupSpec describes where cluster backups are written.
+type BackupSpec struct {
+	// Destination is the backup target as an s3:// URI, e.g. s3://ozone-backups/prod.
+	Destination string `json:"destination"`
+
+	// S3 holds the connection settings and credentials of the destination bucket.
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+
+	// IncludeRecon also copies the Recon database from its PVC under a recon/ prefix of the
+	// destination, so a restored Recon skips the multi-hour full resync from OM and SCM.
+	// Ignored when Recon is not deployed or runs with an external SQL database.
+	// +optional
+	IncludeRecon bool `json:"includeRecon,omitempty"`
+
+	// Suspended pauses the destination probe and all backup activity while keeping the backup
+	// configuration and status, so backups can be stopped temporarily (e.g. during destination
+	// maintenance) without removing this section.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// RestoreSpec describes where a fresh cluster is seeded from.
+type RestoreSpec struct {
+	// Source is the backup to restore as an s3:// URI, e.g. s3://ozone-backups/prod.
+	Source string `json:"source"`
+
+	// S3 holds the connection settings and credentials of the source bucket.
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+
+	// BandwidthLimitMBps caps the average copy rate of the restore, so restoring over a WAN link
+	// does not saturate it. Unlimited when unset.
+	// +optional
+	BandwidthLimitMBps *int32 `json:"bandwidthLimitMBps,omitempty"`
+}
+
+// NotificationsSpec configures the channels operator-level events are pushed to. Every configured
+// channel receives every event; delivery is best-effort and never blocks reconciliation.
+type NotificationsSpec struct {
+	// SlackWebhookSecret names a Secret in the cluster namespace with a url entry holding a
+	// Slack incoming webhook URL.
+	// +optional
+	SlackWebhookSecret string `json:"slackWebhookSecret,omitempty"`
+
+	// WebhookURL receives a JSON payload with cluster, namespace, reason and message per event.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+
+	// SMTP sends each event by mail when set.
+	// +optional
+	SMTP *SMTPNotificationSpec `json:"smtp,omitempty"`
+}
+
+// SMTPNotificationSpec describes the mail channel of NotificationsSpec.
+type SMTPNotificationSpec struct {
+	// CredentialsSecret names a Secret in the cluster namespace with host, port, username,
+	// password and from entries. Empty username skips authentication.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// To are the recipient addresses.
+	To []string `json:"to"`
+}
+
+// DisabledComponentPolicy selects what happens to the workload of an optional component whose
+// spec section was removed.
+type DisabledComponentPolicy string
+
+const (
+	// DisabledComponentSuspend scales the disabled workload to zero, keeping its Services and PVCs.
+	DisabledComponentSuspend DisabledComponentPolicy = "Suspend"
+
+	// DisabledComponentPurge deletes the disabled workload together with its Services and PVCs.
+	DisabledComponentPurge DisabledComponentPolicy = "Purge"
+)
+
+// DeletionPolicy selects what happens to cluster data when the OzoneCluster is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain leaves the PVCs behind when the cluster is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+
+	// DeletionPolicyDelete removes the PVCs together with the cluster.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyBackupThenDelete runs a final full backup before removing the PVCs.
+	DeletionPolicyBackupThenDelete DeletionPolicy = "BackupThenDelete"
+)
+
+// ReplicationType selects the replication mechanism of the cluster default.
+type ReplicationType string
+
+const (
+	// ReplicationRatis replicates full copies through Ratis pipelines.
+	ReplicationRatis ReplicationType = "RATIS"
+
+	// ReplicationEC stores data erasure coded.
+	ReplicationEC ReplicationType = "EC"
+)
+
+// ReplicationSpec sets the cluster-wide default replication written to ozone-site.xml.
+type ReplicationSpec struct {
+	// Type of the default replication. Defaults to RATIS.
+	// +optional
+	Type ReplicationType `json:"type,omitempty"`
+
+	// Factor of RATIS replication, ONE or THREE. Defaults to THREE.
+	// +optional
+	Factor string `json:"factor,omitempty"`
+
+	// ECPolicy is the default erasure coding policy when type is EC, e.g. rs-6-3-1024k.
+	// +optional
+	ECPolicy string `json:"ecPolicy,omitempty"`
+
+	// AllowedECPolicies restricts the erasure coding policies clients may request; unrestricted
+	// when empty.
+	// +optional
+	AllowedECPolicies []string `json:"allowedECPolicies,omitempty"`
+}
+
+// ExternalControlPlaneSpec points the datanodes of this cluster at an existing Ozone control
+// plane outside Kubernetes, turning the cluster into a datanode-only capacity contributor.
+type ExternalControlPlaneSpec struct {
+	// SCMAddresses lists the external SCM host names, written to ozone.scm.names.
+	SCMAddresses []string `json:"scmAddresses"`
+
+	// OMAddress is the host name of the external Ozone Manager.
+	OMAddress string `json:"omAddress"`
+
+	// Config holds extra ozone-site.xml properties needed to join the external deployment, for
+	// example security settings. Merged over the generated defaults, below spec.config.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// TuningSpec sets the most impactful Ratis and RocksDB knobs through typed fields instead of
+// free-form ozone-site overrides. Sizes are rendered into the properties as byte counts.
+type TuningSpec struct {
+	// RatisSegmentSize is the raft log segment size of the OM and SCM Ratis rings and the
+	// datanode pipelines.
+	// +optional
+	RatisSegmentSize *resource.Quantity `json:"ratisSegmentSize,omitempty"`
+
+	// RatisSnapshotThreshold is the number of raft log transactions between state-machine
+	// snapshots on OM and SCM.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RatisSnapshotThreshold *int64 `json:"ratisSnapshotThreshold,omitempty"`
+
+	// RocksDBBlockCacheSize is the block cache of the OM, SCM, and datanode metadata stores.
+	// +optional
+	RocksDBBlockCacheSize *resource.Quantity `json:"rocksDBBlockCacheSize,omitempty"`
+
+	// RocksDBWriteBufferSize is the memtable write buffer size of the metadata stores.
+	// +optional
+	RocksDBWriteBufferSize *resource.Quantity `json:"rocksDBWriteBufferSize,omitempty"`
+
+	// CompactionThreads is the number of background RocksDB compaction threads.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CompactionThreads *int32 `json:"compactionThreads,omitempty"`
+
+	// KeyDeletion tunes the OM key deleting service and SCM block deletion throttling, for
+	// clusters where the defaults cannot keep up after large bulk deletes. The operator samples
+	// the pending-deletion backlog into status.deletionBacklog while this section is present.
+	// +optional
+	KeyDeletion *KeyDeletionSpec `json:"keyDeletion,omitempty"`
+}
+
+// KeyDeletionSpec maps the deletion pipeline knobs onto their ozone-site properties.
+type KeyDeletionSpec struct {
+	// ServiceInterval is the period of the OM key deleting service
+	// (ozone.key.deleting.service.interval).
+	// +optional
+	ServiceInterval *metav1.Duration `json:"serviceInterval,omitempty"`
+
+	// KeyLimitPerTask caps how many deleted keys one service run purges
+	// (ozone.key.deleting.limit.per.task).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeyLimitPerTask *int64 `json:"keyLimitPerTask,omitempty"`
+
+	// BlockDeletionInterval is the period of the SCM block deleting service
+	// (hdds.scm.block.deletion.service.interval).
+	// +optional
+	BlockDeletionInterval *metav1.Duration `json:"blockDeletionInterval,omitempty"`
+
+	// BlockDeletionLimit caps the block deletion commands SCM sends to datanodes per interval
+	// (hdds.scm.block.deletion.per-interval.max).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BlockDeletionLimit *int32 `json:"blockDeletionLimit,omitempty"`
+}
+
+// TrashSpec tunes the Hadoop trash, rendered into the core-site entries of the shared
+// configuration so clients and the OM trash emptier agree on the retention window.
+type TrashSpec struct {
+	// Interval is how long deleted files stay in the trash before they are permanently removed
+	// (fs.trash.interval). Trashed keys still consume bucket and volume quotas until then.
+	Interval metav1.Duration `json:"interval"`
+
+	// CheckpointInterval is how often the trash checkpointer runs; it must not exceed the
+	// interval. Defaults to the interval (fs.trash.checkpoint.interval).
+	// +optional
+	CheckpointInterval *metav1.Duration `json:"checkpointInterval,omitempty"`
+}
+
+// DRRole is the role of a cluster in a disaster recovery pair.
+type DRRole string
+
+const (
+	// DRRolePrimary serves traffic and is the replication source.
+	DRRolePrimary DRRole = "Primary"
+
+	// DRRoleStandby continuously replicates from the primary and can be promoted.
+	DRRoleStandby DRRole = "Standby"
+)
+
+// DRPhase tracks the failover progress of a standby cluster.
+type DRPhase string
+
+const (
+	// DRPhaseReplicating means the standby is continuously replicating from the primary.
+	DRPhaseReplicating DRPhase = "Replicating"
+
+	// DRPhaseFencing means promotion has started and the primary S3 Gateway is being scaled down.
+	DRPhaseFencing DRPhase = "Fencing"
+
+	// DRPhaseCatchingUp means the primary is fenced and the standby is applying the final delta.
+	DRPhaseCatchingUp DRPhase = "CatchingUp"
+
+	// DRPhasePromoted means the standby has taken over as primary.
+	DRPhasePromoted DRPhase = "Promoted"
+)
+
+// DisasterRecoverySpec configures the disaster recovery role of a cluster.
+type DisasterRecoverySpec struct {
+	// Role of this cluster in the pair.
+	Role DRRole `json:"role"`
+
+	// PrimaryCluster names the primary OzoneCluster (same namespace); required on a standby.
+	// +optional
+	PrimaryCluster string `json:"primaryCluster,omitempty"`
+
+	// Buckets lists the buckets replicated from the primary to this standby.
+	// +optional
+	Buckets []ReplicatedBucket `json:"buckets,omitempty"`
+
+	// Promote triggers failover on a standby: the primary S3 Gateway is fenced, the final delta
+	// is replicated and the standby is promoted.
+	// +optional
+	Promote bool `json:"promote,omitempty"`
+}
+
+// DisasterRecoveryStatus reports the failover progress of a standby cluster.
+type DisasterRecoveryStatus struct {
+	// Phase of the disaster recovery workflow.
+	// +optional
+	Phase DRPhase `json:"phase,omitempty"`
+
+	// LastTransitionTime is when the phase last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// FencedTime is when the primary S3 Gateway was confirmed scaled down during promotion.
+	// +optional
+	FencedTime *metav1.Time `json:"fencedTime,omitempty"`
+}
+
+// OzoneClusterStatus defines the observed state of an Ozone cluster.
+type OzoneClusterStatus struct {
+	// Conditions are the latest available observations of the cluster state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ReadyDatanodes is the number of datanode pods currently ready.
+	// +optional
+	ReadyDatanodes int32 `json:"readyDatanodes,omitempty"`
+
+	// Balancer reports the Container Balancer run state when spec.balancer is set.
+	// +optional
+	Balancer *BalancerStatus `json:"balancer,omitempty"`
+
+	// Safemode reports the SCM safemode state when spec.scm.safemode is set.
+	// +optional
+	Safemode *SafemodeStatus `json:"safemode,omitempty"`
+
+	// ReconHealth summarizes container and datanode health as reported by the Recon REST API.
+	// Only populated while Recon is deployed.
+	// +optional
+	ReconHealth *ReconHealthStatus `json:"reconHealth,omitempty"`
+
+	// StorageHealth summarizes SCM pipeline and container replication statistics, periodically
+	// collected through the admin client. See the Degraded condition for the derived verdict.
+	// +optional
+	StorageHealth *StorageHealthStatus `json:"storageHealth,omitempty"`
+
+	// Endpoints lists the client-facing endpoints of the deployed components.
+	// +optional
+	Endpoints *EndpointsStatus `json:"endpoints,omitempty"`
+
+	// DisasterRecovery reports the failover progress when spec.disasterRecovery is set.
+	// +optional
+	DisasterRecovery *DisasterRecoveryStatus `json:"disasterRecovery,omitempty"`
+
+	// Maintenance lists the datanodes the operator has put into Ozone maintenance mode because
+	// their node is cordoned or marked for maintenance.
+	// +optional
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// Hibernation reports the hibernate/resume progress while spec.hibernate is set or the
+	// cluster is still resuming; absent on a normally running cluster.
+	// +optional
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+
+	// DriftedPods lists pods still running with a configuration hash that differs from the
+	// rendered ConfigMap, e.g. after a partial rollout. See the ConfigDrift condition.
+	// +optional
+	DriftedPods []string `json:"driftedPods,omitempty"`
+
+	// FailedVolumes lists datanode pods currently reporting failed storage volumes, sampled from
+	// the datanode metrics endpoints. See the VolumeFailure condition for the policy verdict.
+	// +optional
+	FailedVolumes []DatanodeVolumeFailure `json:"failedVolumes,omitempty"`
+
+	// DataScans reports the data scanner progress per datanode pod, sampled from the datanode
+	// metrics endpoints while spec.datanode.scanner is set.
+	// +optional
+	DataScans []DatanodeScanStatus `json:"dataScans,omitempty"`
+
+	// DeletionBacklog reports the pending-deletion backlog of OM and SCM, sampled from their
+	// metrics endpoints while spec.tuning.keyDeletion is set.
+	// +optional
+	DeletionBacklog *DeletionBacklogStatus `json:"deletionBacklog,omitempty"`
+
+	// Compactions is the history of the latest scheduled OM metadata compactions, oldest first.
+	// +optional
+	Compactions []CompactionRun `json:"compactions,omitempty"`
+
+	// Identity records the Ozone cluster id and SCM id read from the SCM metadata volume after
+	// initialization; later reconciles verify the on-disk identity still matches it.
+	// +optional
+	Identity *ClusterIdentityStatus `json:"identity,omitempty"`
+
+	// InitJobs tracks the one-time initialization Jobs the operator runs before creating the SCM
+	// and OM StatefulSets.
+	// +optional
+	InitJobs []InitJobStatus `json:"initJobs,omitempty"`
+
+	// Restore records the outcome of the one-time restore Job seeding this cluster from
+	// spec.restore.source.
+	// +optional
+	Restore *RestoreStatus `json:"restore,omitempty"`
+}
+
+// RestoreStatus records the outcome of the restore Job.
+type RestoreStatus struct {
+	// Succeeded is true once the restore Job completed.
+	Succeeded bool `json:"succeeded"`
+
+	// CompletionTime of the successful run.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// InitJobStatus records the outcome of one operator-managed initialization Job.
+type InitJobStatus struct {
+	// Name of the Job.
+	Name string `json:"name"`
+
+	// Succeeded is true once the Job completed.
+	Succeeded bool `json:"succeeded"`
+
+	// CompletionTime of the successful run.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ClusterIdentityStatus is the on-disk identity of the cluster, captured from the SCM VERSION
+// file after the first successful initialization.
+type ClusterIdentityStatus struct {
+	// ClusterID generated by SCM when the cluster was formatted.
+	ClusterID string `json:"clusterID"`
+
+	// SCMID of the primordial SCM.
+	// +optional
+	SCMID string `json:"scmID,omitempty"`
+
+	// RecordedTime is when the identity was first captured.
+	// +optional
+	RecordedTime *metav1.Time `json:"recordedTime,omitempty"`
+}
+
+// HibernationPhase is the coarse hibernate/resume state of the cluster.
+type HibernationPhase string
+
+const (
+	// HibernationHibernating means components are being scaled down in safe order.
+	HibernationHibernating HibernationPhase = "Hibernating"
+
+	// HibernationHibernated means every component is stopped and only PVCs and config remain.
+	HibernationHibernated HibernationPhase = "Hibernated"
+
+	// HibernationResuming means components are being restarted in dependency order.
+	HibernationResuming HibernationPhase = "Resuming"
+)
+
+// HibernationStatus tracks the hibernate/resume progress of the cluster.
+type HibernationStatus struct {
+	// Phase of the hibernate/resume sequence.
+	// +optional
+	Phase HibernationPhase `json:"phase,omitempty"`
+
+	// LastTransitionTime is when the phase last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// DatanodeVolumeFailure reports the failed storage volumes of one datanode pod.
+type DatanodeVolumeFailure struct {
+	// Pod is the datanode pod name.
+	Pod string `json:"pod"`
+
+	// FailedVolumes is the number of volumes the datanode reports as failed.
+	FailedVolumes int32 `json:"failedVolumes"`
+
+	// Decommissioned is true once the Decommission action has been issued for this datanode.
+	// +optional
+	Decommissioned bool `json:"decommissioned,omitempty"`
+}
+
+// CompactionRun is one scheduled OM metadata compaction.
+type CompactionRun struct {
+	// Time is when the compaction was triggered.
+	Time metav1.Time `json:"time"`
+
+	// Succeeded is true when every OM replica accepted the compact command.
+	Succeeded bool `json:"succeeded"`
+
+	// Message carries the failure detail of an unsuccessful run.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DeletionBacklogStatus reports the pending-deletion backlog of the cluster.
+type DeletionBacklogStatus struct {
+	// PendingKeys is the number of deleted keys OM has not yet purged.
+	PendingKeys int64 `json:"pendingKeys"`
+
+	// PendingBlocks is the number of block deletion transactions SCM has not yet completed on
+	// the datanodes.
+	PendingBlocks int64 `json:"pendingBlocks"`
+
+	// LastProbeTime is when the backlog was last sampled.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// DatanodeScanStatus reports the data scanner progress of one datanode pod.
+type DatanodeScanStatus struct {
+	// Pod is the datanode pod name.
+	Pod string `json:"pod"`
+
+	// CompletedIterations is the number of full data scanner passes the datanode has completed.
+	CompletedIterations int64 `json:"completedIterations"`
+
+	// LastCompleted is when the operator observed the latest pass complete.
+	// +optional
+	LastCompleted *metav1.Time `json:"lastCompleted,omitempty"`
+}
+
+// MaintenanceStatus tracks datanodes placed into Ozone maintenance mode by the operator.
+type MaintenanceStatus struct {
+	// Datanodes are the host names currently in maintenance mode.
+	// +optional
+	Datanodes []string `json:"datanodes,omitempty"`
+}
+
+// EndpointsStatus lists the client-facing endpoints of the cluster.
+type EndpointsStatus struct {
+	// OM is the Ozone Manager RPC endpoint.
+	// +optional
+	OM string `json:"om,omitempty"`
+
+	// S3 is the URL of the S3 Gateway, when deployed.
+	// +optional
+	S3 string `json:"s3,omitempty"`
+
+	// Recon is the URL of the Recon web UI, when deployed.
+	// +optional
+	Recon string `json:"recon,omitempty"`
+}
+
+// ReconHealthStatus summarizes cluster health as reported by the Recon REST API.
+type ReconHealthStatus struct {
+	// MissingContainers is the number of containers Recon reports as missing.
+	// +optional
+	MissingContainers int64 `json:"missingContainers,omitempty"`
+
+	// UnhealthyContainers is the total number of containers in any unhealthy replication state
+	// (missing, under-, over- or mis-replicated).
+	// +optional
+	UnhealthyContainers int64 `json:"unhealthyContainers,omitempty"`
+
+	// HealthyDatanodes is the number of datanodes Recon reports as HEALTHY.
+	// +optional
+	HealthyDatanodes int32 `json:"healthyDatanodes,omitempty"`
+
+	// TotalDatanodes is the number of datanodes known to Recon.
+	// +optional
+	TotalDatanodes int32 `json:"totalDatanodes,omitempty"`
+
+	// ExternalDatanodes is the number of registered datanodes not running as pods of this
+	// cluster, i.e. nodes that joined through spec.externalAccess.
+	// +optional
+	ExternalDatanodes int32 `json:"externalDatanodes,omitempty"`
+
+	// LastProbeTime is when Recon was last queried successfully.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// StorageHealthStatus summarizes SCM pipeline and container statistics.
+type StorageHealthStatus struct {
+	// OpenPipelines is the number of pipelines in OPEN state.
+	// +optional
+	OpenPipelines int32 `json:"openPipelines,omitempty"`
+
+	// ClosedPipelines is the number of pipelines in CLOSED state.
+	// +optional
+	ClosedPipelines int32 `json:"closedPipelines,omitempty"`
+
+	// TotalContainers is the number of containers known to SCM.
+	// +optional
+	TotalContainers int64 `json:"totalContainers,omitempty"`
+
+	// UnderReplicatedContainers is the number of containers with fewer replicas than required.
+	// +optional
+	UnderReplicatedContainers int64 `json:"underReplicatedContainers,omitempty"`
+
+	// OverReplicatedContainers is the number of containers with more replicas than required.
+	// +optional
+	OverReplicatedContainers int64 `json:"overReplicatedContainers,omitempty"`
+
+	// LastProbeTime is when SCM was last queried successfully.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Datanodes",type=integer,JSONPath=`.status.readyDatanodes`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OzoneCluster is the schema for a single operator-managed Ozone cluster.
+type OzoneCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneClusterSpec   `json:"spec,omitempty"`
+	Status OzoneClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneClusterList contains a list of OzoneCluster.
+type OzoneClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneCluster{}, &OzoneClusterList{})
+}
@@ -0,0 +1,810 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the OzoneCluster webhooks with the manager.
+func (r *OzoneCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&OzoneClusterDefaulter{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ozone-apache-org-v1alpha1-ozonecluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=ozone.apache.org,resources=ozoneclusters,verbs=create;update,versions=v1alpha1,name=mozonecluster.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OzoneCluster{}
+
+// heapFractionPercent is how much of the memory limit is given to the JVM heap, leaving headroom
+// for metaspace, direct buffers and the RocksDB block cache.
+const heapFractionPercent = 65
+
+// defaultJVMOptions fills jvmOptions with an -Xmx derived from the memory limit when the user set
+// neither. Components without a memory limit are left alone.
+func defaultJVMOptions(component *ComponentSpec) {
+	if component.JVMOptions != "" {
+		return
+	}
+	limit, ok := component.Resources.Limits[corev1.ResourceMemory]
+	if !ok {
+		return
+	}
+	heapMiB := limit.Value() * heapFractionPercent / 100 / (1 << 20)
+	if heapMiB <= 0 {
+		return
+	}
+	component.JVMOptions = fmt.Sprintf("-Xmx%dm", heapMiB)
+}
+
+// Default implements webhook.Defaulter, sizing each component's JVM heap from its memory limit.
+func (r *OzoneCluster) Default() {
+	defaultJVMOptions(&r.Spec.SCM.ComponentSpec)
+	defaultJVMOptions(&r.Spec.OM.ComponentSpec)
+	defaultJVMOptions(&r.Spec.Datanode.ComponentSpec)
+	if r.Spec.Recon != nil {
+		defaultJVMOptions(&r.Spec.Recon.ComponentSpec)
+	}
+	if r.Spec.S3Gateway != nil {
+		defaultJVMOptions(&r.Spec.S3Gateway.ComponentSpec)
+	}
+	if r.Spec.HttpFS != nil {
+		defaultJVMOptions(&r.Spec.HttpFS.ComponentSpec)
+	}
+}
+
+// OzoneClusterDefaulter is the mutating admission handler: it resolves spec.templateRef against
+// the cluster-scoped OzoneConfigTemplates before the built-in defaults run.
+type OzoneClusterDefaulter struct {
+	// Reader resolves referenced OzoneConfigTemplates. The manager's API reader is used so
+	// admission does not depend on the informer cache being warmed up.
+	Reader client.Reader
+}
+
+var _ admission.CustomDefaulter = &OzoneClusterDefaulter{}
+
+// Default implements admission.CustomDefaulter, merging the referenced template and then applying
+// the built-in defaults. A dangling templateRef fails admission instead of silently dropping the
+// fleet defaults.
+func (d *OzoneClusterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*OzoneCluster)
+	if !ok {
+		return fmt.Errorf("expected an OzoneCluster, got %T", obj)
+	}
+	if cluster.Spec.TemplateRef != "" {
+		configTemplate := &OzoneConfigTemplate{}
+		if err := d.Reader.Get(ctx, apitypes.NamespacedName{Name: cluster.Spec.TemplateRef}, configTemplate); err != nil {
+			return fmt.Errorf("resolving spec.templateRef %q: %w", cluster.Spec.TemplateRef, err)
+		}
+		mergeConfigTemplate(cluster, configTemplate)
+	}
+	cluster.Default()
+	return nil
+}
+
+// mergeConfigTemplate copies template defaults into the spec fields the cluster left unset: config
+// entries merge per key, tuning merges per field, and security is taken wholesale only when the
+// cluster configures none.
+func mergeConfigTemplate(cluster *OzoneCluster, configTemplate *OzoneConfigTemplate) {
+	if len(configTemplate.Spec.Config) > 0 && cluster.Spec.Config == nil {
+		cluster.Spec.Config = map[string]string{}
+	}
+	for key, value := range configTemplate.Spec.Config {
+		if _, ok := cluster.Spec.Config[key]; !ok {
+			cluster.Spec.Config[key] = value
+		}
+	}
+	if configTemplate.Spec.Tuning != nil {
+		if cluster.Spec.Tuning == nil {
+			cluster.Spec.Tuning = &TuningSpec{}
+		}
+		mergeTuningDefaults(cluster.Spec.Tuning, configTemplate.Spec.Tuning)
+	}
+	if configTemplate.Spec.Security != nil && cluster.Spec.Security == nil {
+		cluster.Spec.Security = configTemplate.Spec.Security.DeepCopy()
+	}
+}
+
+// mergeTuningDefaults fills the nil fields of tuning from defaults.
+func mergeTuningDefaults(tuning, defaults *TuningSpec) {
+	if tuning.RatisSegmentSize == nil && defaults.RatisSegmentSize != nil {
+		size := defaults.RatisSegmentSize.DeepCopy()
+		tuning.RatisSegmentSize = &size
+	}
+	if tuning.RatisSnapshotThreshold == nil && defaults.RatisSnapshotThreshold != nil {
+		threshold := *defaults.RatisSnapshotThreshold
+		tuning.RatisSnapshotThreshold = &threshold
+	}
+	if tuning.RocksDBBlockCacheSize == nil && defaults.RocksDBBlockCacheSize != nil {
+		size := defaults.RocksDBBlockCacheSize.DeepCopy()
+		tuning.RocksDBBlockCacheSize = &size
+	}
+	if tuning.RocksDBWriteBufferSize == nil && defaults.RocksDBWriteBufferSize != nil {
+		size := defaults.RocksDBWriteBufferSize.DeepCopy()
+		tuning.RocksDBWriteBufferSize = &size
+	}
+	if tuning.CompactionThreads == nil && defaults.CompactionThreads != nil {
+		threads := *defaults.CompactionThreads
+		tuning.CompactionThreads = &threads
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-ozone-apache-org-v1alpha1-ozonecluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=ozone.apache.org,resources=ozoneclusters,verbs=create;update;delete,versions=v1alpha1,name=vozonecluster.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OzoneCluster{}
+
+// ValidateCreate implements webhook.Validator.
+func (r *OzoneCluster) ValidateCreate() (admission.Warnings, error) {
+	if err := r.validateResources(); err != nil {
+		return nil, err
+	}
+	if err := r.validateScalingWindows(); err != nil {
+		return nil, err
+	}
+	if err := r.validateS3Gateway(); err != nil {
+		return nil, err
+	}
+	if err := r.validateBackup(); err != nil {
+		return nil, err
+	}
+	if err := r.validateNotifications(); err != nil {
+		return nil, err
+	}
+	if err := r.validateConfigTemplates(); err != nil {
+		return nil, err
+	}
+	if err := r.validateTuning(); err != nil {
+		return nil, err
+	}
+	if err := r.validateTrash(); err != nil {
+		return nil, err
+	}
+	if err := r.validateSecurity(); err != nil {
+		return nil, err
+	}
+	if err := r.validateGatewayAPI(); err != nil {
+		return nil, err
+	}
+	if err := r.validateAPIProxy(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateReplication()
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (r *OzoneCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	oldCluster, ok := old.(*OzoneCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected the old object to be an OzoneCluster, got %T", old)
+	}
+	if err := r.validateImmutableFields(oldCluster); err != nil {
+		return nil, err
+	}
+	if err := r.validateSCMResize(oldCluster); err != nil {
+		return nil, err
+	}
+	if err := r.validateResources(); err != nil {
+		return nil, err
+	}
+	if err := r.validateScalingWindows(); err != nil {
+		return nil, err
+	}
+	if err := r.validateS3Gateway(); err != nil {
+		return nil, err
+	}
+	if err := r.validateBackup(); err != nil {
+		return nil, err
+	}
+	if err := r.validateNotifications(); err != nil {
+		return nil, err
+	}
+	if err := r.validateConfigTemplates(); err != nil {
+		return nil, err
+	}
+	if err := r.validateTuning(); err != nil {
+		return nil, err
+	}
+	if err := r.validateTrash(); err != nil {
+		return nil, err
+	}
+	if err := r.validateSecurity(); err != nil {
+		return nil, err
+	}
+	if err := r.validateGatewayAPI(); err != nil {
+		return nil, err
+	}
+	if err := r.validateAPIProxy(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateReplication()
+}
+
+// ValidateDelete implements webhook.Validator, enforcing spec.deletionProtection.
+func (r *OzoneCluster) ValidateDelete() (admission.Warnings, error) {
+	if r.Spec.DeletionProtection != nil {
+		if *r.Spec.DeletionProtection {
+			return nil, fmt.Errorf("cluster %s has spec.deletionProtection enabled; "+
+				"set it to false before deleting", r.Name)
+		}
+		return nil, nil
+	}
+	// Unset protection defaults to protecting clusters that are up and likely holding user data.
+	// The Available condition is maintained by the status controller.
+	if apimeta.IsStatusConditionTrue(r.Status.Conditions, "Available") {
+		return nil, fmt.Errorf("cluster %s is Available and protected by default; "+
+			"set spec.deletionProtection to false before deleting", r.Name)
+	}
+	return nil, nil
+}
+
+// minControlPlaneMemory is the smallest memory SCM or OM can reasonably run with; RocksDB and the
+// JVM need headroom beyond this even on toy clusters.
+var minControlPlaneMemory = resource.MustParse("2Gi")
+
+// componentMemory returns the configured memory of a component, preferring the limit; nil when no
+// memory is configured at all.
+func componentMemory(component *ComponentSpec) *resource.Quantity {
+	if limit, ok := component.Resources.Limits[corev1.ResourceMemory]; ok {
+		return &limit
+	}
+	if request, ok := component.Resources.Requests[corev1.ResourceMemory]; ok {
+		return &request
+	}
+	return nil
+}
+
+// validateResources fails fast on sizings that cannot work: SCM or OM squeezed below a viable
+// memory floor, and datanode volume reservations that leave no usable capacity.
+func (r *OzoneCluster) validateResources() error {
+	for _, component := range []struct {
+		path string
+		spec *ComponentSpec
+	}{
+		{path: "spec.scm", spec: &r.Spec.SCM.ComponentSpec},
+		{path: "spec.om", spec: &r.Spec.OM.ComponentSpec},
+	} {
+		if memory := componentMemory(component.spec); memory != nil && memory.Cmp(minControlPlaneMemory) < 0 {
+			return fmt.Errorf("%s.resources gives the component %s of memory but it needs at least %s to run",
+				component.path, memory.String(), minControlPlaneMemory.String())
+		}
+	}
+	for i := range r.Spec.Datanode.Volumes {
+		volume := &r.Spec.Datanode.Volumes[i]
+		if volume.Reserved != nil && volume.Reserved.Cmp(volume.Size) >= 0 {
+			return fmt.Errorf("spec.datanode.volumes[%q] reserves %s of its %s capacity, leaving no usable space",
+				volume.Name, volume.Reserved.String(), volume.Size.String())
+		}
+	}
+	return nil
+}
+
+// scmReplicas returns the effective SCM replica count.
+func scmReplicas(cluster *OzoneCluster) int32 {
+	if cluster.Spec.SCM.Replicas != nil {
+		return *cluster.Spec.SCM.Replicas
+	}
+	return 1
+}
+
+// validateSCMResize blocks SCM replica changes on a live cluster unless explicitly acknowledged.
+// Growing the SCM Ratis ring is supported behind spec.allowScmResize; shrinking is not.
+func (r *OzoneCluster) validateSCMResize(old *OzoneCluster) error {
+	oldReplicas, newReplicas := scmReplicas(old), scmReplicas(r)
+	if newReplicas == oldReplicas {
+		return nil
+	}
+	if newReplicas < oldReplicas {
+		return fmt.Errorf("spec.scm.replicas cannot decrease (was %d, now %d): removing SCMs from the Ratis "+
+			"ring is not supported", oldReplicas, newReplicas)
+	}
+	if !r.Spec.AllowSCMResize {
+		return fmt.Errorf("changing spec.scm.replicas (was %d, now %d) is blocked: growing the SCM Ratis ring "+
+			"bootstraps new SCMs and rolls every component; set spec.allowScmResize to acknowledge",
+			oldReplicas, newReplicas)
+	}
+	return nil
+}
+
+// validateScalingWindows checks the gateway scaling windows for parseable HH:MM times and a
+// non-negative replica count.
+func (r *OzoneCluster) validateScalingWindows() error {
+	if r.Spec.S3Gateway == nil {
+		return nil
+	}
+	for i := range r.Spec.S3Gateway.ScalingWindows {
+		window := &r.Spec.S3Gateway.ScalingWindows[i]
+		for _, boundary := range []string{window.Start, window.End} {
+			if _, err := time.Parse("15:04", boundary); err != nil {
+				return fmt.Errorf("spec.s3g.scalingWindows[%d] has an invalid HH:MM time %q", i, boundary)
+			}
+		}
+		if window.Replicas < 0 {
+			return fmt.Errorf("spec.s3g.scalingWindows[%d] has a negative replica count", i)
+		}
+	}
+	return nil
+}
+
+// validateConfigTemplates parses the Go template expressions allowed in spec.config values and
+// spec.extraConfigFiles contents, so a typo fails at admission instead of being shipped to the
+// components literally.
+func (r *OzoneCluster) validateConfigTemplates() error {
+	for key, val := range r.Spec.Config {
+		if _, err := template.New(key).Parse(val); err != nil {
+			return fmt.Errorf("spec.config[%q] is not a valid template: %v", key, err)
+		}
+	}
+	for name, content := range r.Spec.ExtraConfigFiles {
+		if name == "" || strings.ContainsAny(name, "/") {
+			return fmt.Errorf("spec.extraConfigFiles key %q must be a plain file name", name)
+		}
+		if _, err := template.New(name).Parse(content); err != nil {
+			return fmt.Errorf("spec.extraConfigFiles[%q] is not a valid template: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// validateTuning checks that the tuning and scanner quantities are positive, so a malformed value
+// fails at admission instead of producing an ozone-site property the components reject on startup.
+func (r *OzoneCluster) validateTuning() error {
+	if tuning := r.Spec.Tuning; tuning != nil {
+		for _, size := range []struct {
+			path     string
+			quantity *resource.Quantity
+		}{
+			{path: "spec.tuning.ratisSegmentSize", quantity: tuning.RatisSegmentSize},
+			{path: "spec.tuning.rocksDBBlockCacheSize", quantity: tuning.RocksDBBlockCacheSize},
+			{path: "spec.tuning.rocksDBWriteBufferSize", quantity: tuning.RocksDBWriteBufferSize},
+		} {
+			if size.quantity != nil && size.quantity.Sign() <= 0 {
+				return fmt.Errorf("%s must be a positive quantity, got %s", size.path, size.quantity.String())
+			}
+		}
+		if deletion := tuning.KeyDeletion; deletion != nil {
+			for _, interval := range []struct {
+				path     string
+				duration *metav1.Duration
+			}{
+				{path: "spec.tuning.keyDeletion.serviceInterval", duration: deletion.ServiceInterval},
+				{path: "spec.tuning.keyDeletion.blockDeletionInterval", duration: deletion.BlockDeletionInterval},
+			} {
+				if interval.duration != nil && interval.duration.Duration <= 0 {
+					return fmt.Errorf("%s must be a positive duration, got %s", interval.path, interval.duration.Duration)
+				}
+			}
+		}
+	}
+	if scanner := r.Spec.Datanode.Scanner; scanner != nil {
+		for _, interval := range []struct {
+			path     string
+			duration *metav1.Duration
+		}{
+			{path: "spec.datanode.scanner.metadataScanInterval", duration: scanner.MetadataScanInterval},
+			{path: "spec.datanode.scanner.dataScanInterval", duration: scanner.DataScanInterval},
+		} {
+			if interval.duration != nil && interval.duration.Duration <= 0 {
+				return fmt.Errorf("%s must be a positive duration, got %s", interval.path, interval.duration.Duration)
+			}
+		}
+		if scanner.Bandwidth != nil && scanner.Bandwidth.Sign() <= 0 {
+			return fmt.Errorf("spec.datanode.scanner.bandwidth must be a positive quantity, got %s",
+				scanner.Bandwidth.String())
+		}
+	}
+	return nil
+}
+
+// validateTrash checks the trash retention windows. The messages spell out the quota interaction,
+// because a long trash interval is the usual reason a bucket stays over quota after deletes:
+// trashed keys keep consuming bucket and volume quotas until the interval expires.
+func (r *OzoneCluster) validateTrash() error {
+	trash := r.Spec.Trash
+	if trash == nil {
+		return nil
+	}
+	if trash.Interval.Duration < time.Minute {
+		return fmt.Errorf("spec.trash.interval must be at least one minute, got %s; "+
+			"note that trashed keys keep consuming bucket and volume quotas until the interval expires",
+			trash.Interval.Duration)
+	}
+	if trash.CheckpointInterval != nil {
+		if trash.CheckpointInterval.Duration < time.Minute {
+			return fmt.Errorf("spec.trash.checkpointInterval must be at least one minute, got %s",
+				trash.CheckpointInterval.Duration)
+		}
+		if trash.CheckpointInterval.Duration > trash.Interval.Duration {
+			return fmt.Errorf("spec.trash.checkpointInterval %s must not exceed spec.trash.interval %s, "+
+				"otherwise expired trash (which still counts against quotas) is reclaimed late",
+				trash.CheckpointInterval.Duration, trash.Interval.Duration)
+		}
+	}
+	return nil
+}
+
+// weakKeystoreTypes are keystore formats without FIPS certification.
+var weakKeystoreTypes = map[string]bool{"jks": true, "jceks": true}
+
+// weakTLSProtocols are protocol versions not allowed in restricted-crypto mode.
+var weakTLSProtocols = map[string]bool{"sslv3": true, "tlsv1": true, "tlsv1.1": true}
+
+// validateSecurity checks that metrics authentication references a credentials Secret and that
+// FIPS mode is not combined with incompatible crypto overrides, so misconfigurations fail at
+// admission instead of producing a cluster that silently violates the crypto policy.
+func (r *OzoneCluster) validateSecurity() error {
+	security := r.Spec.Security
+	if security == nil {
+		return nil
+	}
+	if metrics := security.Metrics; metrics != nil && metrics.Auth != "" && metrics.CredentialsSecret == "" {
+		return fmt.Errorf("spec.security.metrics.auth is %s but credentialsSecret is empty", metrics.Auth)
+	}
+	if security.FIPSImage != "" && !security.FIPS {
+		return fmt.Errorf("spec.security.fipsImage is set but fips is false")
+	}
+	if ui := security.UI; ui != nil {
+		if ui.IssuerURL == "" {
+			return fmt.Errorf("spec.security.ui.issuerURL must be set")
+		}
+		if ui.ClientCredentialsSecret == "" {
+			return fmt.Errorf("spec.security.ui.clientCredentialsSecret must be set")
+		}
+		for _, name := range ui.Components {
+			switch name {
+			case "scm", "om", "recon":
+			default:
+				return fmt.Errorf("spec.security.ui.components contains %q; only scm, om, and recon serve a "+
+					"protectable UI", name)
+			}
+		}
+	}
+	if encryption := security.Encryption; encryption != nil {
+		if r.Spec.KMS != nil {
+			return fmt.Errorf("spec.security.encryption points at an external KMS while spec.kms deploys an " +
+				"embedded one; configure only one of them")
+		}
+		if encryption.KMSURI == "" {
+			return fmt.Errorf("spec.security.encryption.kmsURI must be set")
+		}
+		kms, err := url.Parse(encryption.KMSURI)
+		if err != nil || kms.Scheme != "kms" || kms.Host == "" {
+			return fmt.Errorf("spec.security.encryption.kmsURI %q is not a kms:// URI", encryption.KMSURI)
+		}
+		if scheme := kms.User.Username(); scheme != "http" && scheme != "https" {
+			return fmt.Errorf("spec.security.encryption.kmsURI %q must embed the KMS transport as "+
+				"kms://http@... or kms://https@...", encryption.KMSURI)
+		}
+	}
+	if !security.FIPS {
+		return nil
+	}
+	for _, key := range []string{"ssl.server.keystore.type", "ssl.client.keystore.type"} {
+		if value, ok := r.Spec.Config[key]; ok && weakKeystoreTypes[strings.ToLower(value)] {
+			return fmt.Errorf("spec.config[%q] uses keystore type %s, which is not FIPS-certified", key, value)
+		}
+	}
+	if protocols, ok := r.Spec.Config["hadoop.ssl.enabled.protocols"]; ok {
+		for _, protocol := range strings.Split(protocols, ",") {
+			if weakTLSProtocols[strings.ToLower(strings.TrimSpace(protocol))] {
+				return fmt.Errorf("spec.config[\"hadoop.ssl.enabled.protocols\"] enables %s, which is not "+
+					"allowed in FIPS mode", strings.TrimSpace(protocol))
+			}
+		}
+	}
+	return nil
+}
+
+// validateS3Gateway rejects a virtual-host domain that is not a bare DNS suffix, since it ends up
+// both in ozone.s3g.domain.name and in route hostnames, and capacity limits that would render the
+// gateway or the rate-limiting sidecar unable to serve.
+func (r *OzoneCluster) validateS3Gateway() error {
+	s3g := r.Spec.S3Gateway
+	if s3g == nil {
+		return nil
+	}
+	if s3g.DomainName != "" &&
+		(strings.ContainsAny(s3g.DomainName, "/:*") || strings.HasPrefix(s3g.DomainName, ".")) {
+		return fmt.Errorf("spec.s3g.domainName %q must be a bare DNS suffix like s3.example.com", s3g.DomainName)
+	}
+	limits := s3g.Limits
+	if limits == nil {
+		return nil
+	}
+	if limits.HandlerThreads != nil && *limits.HandlerThreads < 1 {
+		return fmt.Errorf("spec.s3g.limits.handlerThreads must be at least 1")
+	}
+	if limits.MaxConnections != nil && *limits.MaxConnections < 1 {
+		return fmt.Errorf("spec.s3g.limits.maxConnections must be at least 1")
+	}
+	if limits.IdleTimeout != nil && limits.IdleTimeout.Duration <= 0 {
+		return fmt.Errorf("spec.s3g.limits.idleTimeout must be positive")
+	}
+	if rateLimit := limits.RateLimit; rateLimit != nil {
+		if rateLimit.RequestsPerSecond < 1 {
+			return fmt.Errorf("spec.s3g.limits.rateLimit.requestsPerSecond must be at least 1")
+		}
+		if rateLimit.Burst < 0 {
+			return fmt.Errorf("spec.s3g.limits.rateLimit.burst must not be negative")
+		}
+	}
+	return nil
+}
+
+// validateGatewayAPI rejects Gateway API route settings that would silently never carry traffic.
+func (r *OzoneCluster) validateGatewayAPI() error {
+	gw := r.Spec.GatewayAPI
+	if gw == nil {
+		return nil
+	}
+	if gw.GatewayName == "" {
+		return fmt.Errorf("spec.gatewayAPI.gatewayName must be set")
+	}
+	if gw.TLSPassthrough && gw.S3Hostname == "" && gw.ReconHostname == "" {
+		return fmt.Errorf("spec.gatewayAPI.tlsPassthrough requires s3Hostname or reconHostname, " +
+			"since TLSRoutes only match on SNI")
+	}
+	return nil
+}
+
+// validateAPIProxy checks the components and path prefixes of the read-only API proxy.
+func (r *OzoneCluster) validateAPIProxy() error {
+	proxy := r.Spec.APIProxy
+	if proxy == nil {
+		return nil
+	}
+	for _, component := range proxy.Components {
+		if component != "recon" && component != "om" {
+			return fmt.Errorf("spec.apiProxy.components: %q has no proxied API, expected recon or om", component)
+		}
+	}
+	for _, path := range proxy.AllowPaths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("spec.apiProxy.allowPaths: %q must be an absolute path prefix", path)
+		}
+	}
+	return nil
+}
+
+// validateBackup checks the backup destination at admission, so a broken URI or missing
+// credentials fail immediately instead of during the first scheduled backup.
+func (r *OzoneCluster) validateBackup() error {
+	backup := r.Spec.Backup
+	if backup == nil {
+		if r.Spec.DeletionPolicy == DeletionPolicyBackupThenDelete {
+			return fmt.Errorf("spec.deletionPolicy BackupThenDelete requires spec.backup")
+		}
+		return nil
+	}
+	destination, err := url.Parse(backup.Destination)
+	if err != nil {
+		return fmt.Errorf("spec.backup.destination %q is not a valid URI: %v", backup.Destination, err)
+	}
+	if destination.Scheme != "s3" {
+		return fmt.Errorf("spec.backup.destination must be an s3:// URI, got scheme %q", destination.Scheme)
+	}
+	if destination.Host == "" {
+		return fmt.Errorf("spec.backup.destination %q has no bucket", backup.Destination)
+	}
+	if backup.S3 == nil {
+		return fmt.Errorf("spec.backup.s3 is required for an s3:// destination")
+	}
+	if backup.S3.CredentialsSecret == "" {
+		return fmt.Errorf("spec.backup.s3.credentialsSecret is required")
+	}
+	if backup.S3.Endpoint == "" && backup.S3.Region == "" {
+		return fmt.Errorf("spec.backup.s3 needs either endpoint or region to resolve the destination")
+	}
+	if backup.S3.Endpoint != "" {
+		endpoint, err := url.Parse(backup.S3.Endpoint)
+		if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+			return fmt.Errorf("spec.backup.s3.endpoint %q is not an http(s) URL", backup.S3.Endpoint)
+		}
+	}
+	return nil
+}
+
+// validateNotifications rejects a notification configuration that could never deliver anything, so
+// a typo does not silently swallow every operator event.
+func (r *OzoneCluster) validateNotifications() error {
+	notifications := r.Spec.Notifications
+	if notifications == nil {
+		return nil
+	}
+	if notifications.SlackWebhookSecret == "" && notifications.WebhookURL == "" && notifications.SMTP == nil {
+		return fmt.Errorf("spec.notifications must configure at least one channel")
+	}
+	if notifications.WebhookURL != "" {
+		webhook, err := url.Parse(notifications.WebhookURL)
+		if err != nil || webhook.Host == "" || (webhook.Scheme != "http" && webhook.Scheme != "https") {
+			return fmt.Errorf("spec.notifications.webhookURL %q is not an http(s) URL", notifications.WebhookURL)
+		}
+	}
+	if notifications.SMTP != nil {
+		if notifications.SMTP.CredentialsSecret == "" {
+			return fmt.Errorf("spec.notifications.smtp.credentialsSecret is required")
+		}
+		if len(notifications.SMTP.To) == 0 {
+			return fmt.Errorf("spec.notifications.smtp.to needs at least one recipient")
+		}
+	}
+	return nil
+}
+
+// storageClassString renders an optional storageClass for comparison and error messages; empty
+// means the cluster default.
+func storageClassString(class *string) string {
+	if class == nil {
+		return ""
+	}
+	return *class
+}
+
+// validateStorageUpdate rejects storage changes a StatefulSet cannot apply: volumeClaimTemplates
+// are immutable, so the storageClass cannot change, the size cannot shrink, and storage cannot flip
+// between persistent and ephemeral. Setting a previously unset storageClass stays allowed so the
+// operator can pin the resolved cluster default.
+func validateStorageUpdate(path string, old, updated *StorageSpec) error {
+	if old.StorageClass != nil &&
+		storageClassString(old.StorageClass) != storageClassString(updated.StorageClass) {
+		return fmt.Errorf("%s.storageClass is immutable (was %q, now %q): volumeClaimTemplates cannot be changed "+
+			"on a running cluster", path, storageClassString(old.StorageClass), storageClassString(updated.StorageClass))
+	}
+	if updated.Size.Cmp(old.Size) < 0 {
+		return fmt.Errorf("%s.size cannot decrease (was %s, now %s): persistent volumes do not shrink",
+			path, old.Size.String(), updated.Size.String())
+	}
+	if old.Ephemeral != updated.Ephemeral {
+		return fmt.Errorf("%s.ephemeral is immutable: switching between persistent and ephemeral storage "+
+			"requires recreating the cluster", path)
+	}
+	return nil
+}
+
+// validateDataVolumesUpdate rejects layout changes of the datanode volume set. Existing volumes
+// must keep their name, storageClass and ephemeral flag and may only grow; appending new volumes is
+// allowed.
+func validateDataVolumesUpdate(old, updated []DataVolume) error {
+	byName := make(map[string]*DataVolume, len(updated))
+	for i := range updated {
+		byName[updated[i].Name] = &updated[i]
+	}
+	for i := range old {
+		oldVolume := &old[i]
+		path := fmt.Sprintf("spec.datanode.volumes[%q]", oldVolume.Name)
+		newVolume, ok := byName[oldVolume.Name]
+		if !ok {
+			return fmt.Errorf("%s cannot be removed or renamed: datanode volumeClaimTemplates are immutable "+
+				"and existing volumes still hold container data", path)
+		}
+		if oldVolume.StorageClass != nil &&
+			storageClassString(oldVolume.StorageClass) != storageClassString(newVolume.StorageClass) {
+			return fmt.Errorf("%s.storageClass is immutable (was %q, now %q)",
+				path, storageClassString(oldVolume.StorageClass), storageClassString(newVolume.StorageClass))
+		}
+		if newVolume.Size.Cmp(oldVolume.Size) < 0 {
+			return fmt.Errorf("%s.size cannot decrease (was %s, now %s)",
+				path, oldVolume.Size.String(), newVolume.Size.String())
+		}
+		if oldVolume.Ephemeral != newVolume.Ephemeral {
+			return fmt.Errorf("%s.ephemeral is immutable", path)
+		}
+	}
+	return nil
+}
+
+// validateImmutableFields rejects spec changes the reconciler cannot apply to a running cluster,
+// so they fail at admission with a clear message instead of during StatefulSet updates.
+func (r *OzoneCluster) validateImmutableFields(old *OzoneCluster) error {
+	if err := validateStorageUpdate("spec.scm.storage", &old.Spec.SCM.Storage, &r.Spec.SCM.Storage); err != nil {
+		return err
+	}
+	if err := validateStorageUpdate("spec.om.storage", &old.Spec.OM.Storage, &r.Spec.OM.Storage); err != nil {
+		return err
+	}
+	if old.Spec.Recon != nil && r.Spec.Recon != nil {
+		if err := validateStorageUpdate("spec.recon.storage", &old.Spec.Recon.Storage, &r.Spec.Recon.Storage); err != nil {
+			return err
+		}
+	}
+	for _, key := range []string{"ozone.om.service.id", "ozone.scm.service.ids"} {
+		if old.Spec.Config[key] != r.Spec.Config[key] {
+			return fmt.Errorf("spec.config[%q] is immutable: the service id names the Ratis ring persisted in the "+
+				"OM and SCM metadata, and components booted with a different id corrupt it", key)
+		}
+	}
+	return validateDataVolumesUpdate(old.Spec.Datanode.Volumes, r.Spec.Datanode.Volumes)
+}
+
+// ecPolicyWidth returns the number of datanodes an EC policy such as rs-6-3-1024k writes to,
+// i.e. data plus parity blocks.
+func ecPolicyWidth(policy string) (int32, error) {
+	parts := strings.Split(policy, "-")
+	if len(parts) != 4 {
+		return 0, fmt.Errorf("EC policy %q is not of the form <codec>-<data>-<parity>-<cellsize>", policy)
+	}
+	data, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("EC policy %q has a non-numeric data block count", policy)
+	}
+	parity, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, fmt.Errorf("EC policy %q has a non-numeric parity block count", policy)
+	}
+	return int32(data + parity), nil
+}
+
+// validateReplication checks that the configured datanode count can host the default replication
+// scheme, in particular that an EC stripe fits onto distinct datanodes.
+func (r *OzoneCluster) validateReplication() error {
+	rep := r.Spec.Replication
+	if rep == nil {
+		return nil
+	}
+	datanodes := int32(3)
+	if r.Spec.Datanode.Replicas != nil {
+		datanodes = *r.Spec.Datanode.Replicas
+	}
+	switch rep.Type {
+	case ReplicationEC:
+		policy := rep.ECPolicy
+		if policy == "" {
+			policy = "rs-6-3-1024k"
+		}
+		width, err := ecPolicyWidth(policy)
+		if err != nil {
+			return err
+		}
+		if datanodes < width {
+			return fmt.Errorf("EC policy %s needs %d datanodes but spec.datanode.replicas is %d",
+				policy, width, datanodes)
+		}
+		for _, allowed := range rep.AllowedECPolicies {
+			if _, err := ecPolicyWidth(allowed); err != nil {
+				return err
+			}
+		}
+	case ReplicationRatis, "":
+		if rep.Factor == "THREE" && datanodes < 3 {
+			return fmt.Errorf("RATIS/THREE replication needs 3 datanodes but spec.datanode.replicas is %d",
+				datanodes)
+		}
+	default:
+		return fmt.Errorf("unknown replication type %q", rep.Type)
+	}
+	return nil
+}
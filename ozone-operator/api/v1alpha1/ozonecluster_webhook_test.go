@@ -0,0 +1,813 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestECPolicyWidth(t *testing.T) {
+	tests := []struct {
+		policy  string
+		width   int32
+		wantErr bool
+	}{
+		{policy: "rs-6-3-1024k", width: 9},
+		{policy: "rs-3-2-1024k", width: 5},
+		{policy: "xor-2-1-1024k", width: 3},
+		{policy: "rs-6-3", wantErr: true},
+		{policy: "rs-a-3-1024k", wantErr: true},
+	}
+	for _, tc := range tests {
+		width, err := ecPolicyWidth(tc.policy)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ecPolicyWidth(%q): expected error, got width %d", tc.policy, width)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ecPolicyWidth(%q): unexpected error: %v", tc.policy, err)
+		} else if width != tc.width {
+			t.Errorf("ecPolicyWidth(%q) = %d, want %d", tc.policy, width, tc.width)
+		}
+	}
+}
+
+func TestDefaultJVMOptions(t *testing.T) {
+	memoryLimit := func(limit string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+		}
+	}
+	tests := []struct {
+		name      string
+		component ComponentSpec
+		want      string
+	}{
+		{
+			name:      "no memory limit leaves jvmOptions empty",
+			component: ComponentSpec{},
+		},
+		{
+			name:      "4Gi limit",
+			component: ComponentSpec{Resources: memoryLimit("4Gi")},
+			want:      "-Xmx2662m",
+		},
+		{
+			name:      "512Mi limit",
+			component: ComponentSpec{Resources: memoryLimit("512Mi")},
+			want:      "-Xmx332m",
+		},
+		{
+			name:      "explicit jvmOptions win",
+			component: ComponentSpec{JVMOptions: "-Xmx1g", Resources: memoryLimit("4Gi")},
+			want:      "-Xmx1g",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defaultJVMOptions(&tc.component)
+			if tc.component.JVMOptions != tc.want {
+				t.Fatalf("jvmOptions = %q, want %q", tc.component.JVMOptions, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	memory := func(limit string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+		}
+	}
+	reserved := func(quantity string) *resource.Quantity {
+		parsed := resource.MustParse(quantity)
+		return &parsed
+	}
+	tests := []struct {
+		name    string
+		cluster OzoneCluster
+		wantErr bool
+	}{
+		{
+			name:    "no resources configured",
+			cluster: OzoneCluster{},
+		},
+		{
+			name: "viable control plane memory",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				SCM: SCMSpec{ComponentSpec: ComponentSpec{Resources: memory("4Gi")}},
+			}},
+		},
+		{
+			name: "scm memory below the floor",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				SCM: SCMSpec{ComponentSpec: ComponentSpec{Resources: memory("512Mi")}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "om request below the floor",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				OM: OMSpec{ComponentSpec: ComponentSpec{Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "sane volume reservation",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode: DatanodeSpec{Volumes: []DataVolume{
+					{Name: "data0", Size: resource.MustParse("100Gi"), Reserved: reserved("10Gi")},
+				}},
+			}},
+		},
+		{
+			name: "reservation swallows the volume",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode: DatanodeSpec{Volumes: []DataVolume{
+					{Name: "data0", Size: resource.MustParse("100Gi"), Reserved: reserved("100Gi")},
+				}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cluster.validateResources()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSCMResize(t *testing.T) {
+	withSCMReplicas := func(n int32, acknowledge bool) *OzoneCluster {
+		return &OzoneCluster{Spec: OzoneClusterSpec{
+			SCM:            SCMSpec{ComponentSpec: ComponentSpec{Replicas: &n}},
+			AllowSCMResize: acknowledge,
+		}}
+	}
+	tests := []struct {
+		name     string
+		old, new *OzoneCluster
+		wantErr  bool
+	}{
+		{
+			name: "unchanged replicas",
+			old:  withSCMReplicas(3, false),
+			new:  withSCMReplicas(3, false),
+		},
+		{
+			name:    "grow without acknowledgment",
+			old:     withSCMReplicas(1, false),
+			new:     withSCMReplicas(3, false),
+			wantErr: true,
+		},
+		{
+			name: "grow with acknowledgment",
+			old:  withSCMReplicas(1, false),
+			new:  withSCMReplicas(3, true),
+		},
+		{
+			name:    "shrink even with acknowledgment",
+			old:     withSCMReplicas(3, false),
+			new:     withSCMReplicas(1, true),
+			wantErr: true,
+		},
+		{
+			name: "defaulted replicas stay unchanged",
+			old:  &OzoneCluster{},
+			new:  withSCMReplicas(1, false),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.new.validateSCMResize(tc.old)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		backup  *BackupSpec
+		wantErr bool
+	}{
+		{
+			name: "no backup spec",
+		},
+		{
+			name: "valid destination with endpoint",
+			backup: &BackupSpec{
+				Destination: "s3://ozone-backups/prod",
+				S3:          &BackupS3Spec{Endpoint: "https://minio.backup.svc:9000", CredentialsSecret: "backup-creds"},
+			},
+		},
+		{
+			name: "valid destination with region",
+			backup: &BackupSpec{
+				Destination: "s3://ozone-backups",
+				S3:          &BackupS3Spec{Region: "eu-central-1", CredentialsSecret: "backup-creds"},
+			},
+		},
+		{
+			name:    "non-s3 scheme",
+			backup:  &BackupSpec{Destination: "ftp://backups", S3: &BackupS3Spec{Region: "us-east-1", CredentialsSecret: "s"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing bucket",
+			backup:  &BackupSpec{Destination: "s3://", S3: &BackupS3Spec{Region: "us-east-1", CredentialsSecret: "s"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing s3 settings",
+			backup:  &BackupSpec{Destination: "s3://ozone-backups"},
+			wantErr: true,
+		},
+		{
+			name:    "missing credentials",
+			backup:  &BackupSpec{Destination: "s3://ozone-backups", S3: &BackupS3Spec{Region: "us-east-1"}},
+			wantErr: true,
+		},
+		{
+			name:    "neither endpoint nor region",
+			backup:  &BackupSpec{Destination: "s3://ozone-backups", S3: &BackupS3Spec{CredentialsSecret: "s"}},
+			wantErr: true,
+		},
+		{
+			name:    "bogus endpoint",
+			backup:  &BackupSpec{Destination: "s3://ozone-backups", S3: &BackupS3Spec{Endpoint: "minio:9000", CredentialsSecret: "s"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{Backup: tc.backup}}
+			err := cluster.validateBackup()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMergeConfigTemplate(t *testing.T) {
+	segmentSize := resource.MustParse("8Mi")
+	cacheSize := resource.MustParse("256Mi")
+	clusterCacheSize := resource.MustParse("1Gi")
+	configTemplate := &OzoneConfigTemplate{
+		Spec: OzoneConfigTemplateSpec{
+			Config: map[string]string{
+				"ozone.om.ratis.enable":   "true",
+				"ozone.scm.names":         "from-template",
+				"hdds.datanode.du.factor": "0.9",
+			},
+			Tuning: &TuningSpec{
+				RatisSegmentSize:      &segmentSize,
+				RocksDBBlockCacheSize: &cacheSize,
+			},
+			Security: &SecuritySpec{FIPS: true},
+		},
+	}
+	cluster := &OzoneCluster{
+		Spec: OzoneClusterSpec{
+			Config: map[string]string{"ozone.scm.names": "from-cluster"},
+			Tuning: &TuningSpec{RocksDBBlockCacheSize: &clusterCacheSize},
+		},
+	}
+
+	mergeConfigTemplate(cluster, configTemplate)
+
+	if cluster.Spec.Config["ozone.scm.names"] != "from-cluster" {
+		t.Errorf("cluster config entry was overwritten: %q", cluster.Spec.Config["ozone.scm.names"])
+	}
+	if cluster.Spec.Config["ozone.om.ratis.enable"] != "true" || cluster.Spec.Config["hdds.datanode.du.factor"] != "0.9" {
+		t.Errorf("template config entries were not merged: %v", cluster.Spec.Config)
+	}
+	if cluster.Spec.Tuning.RatisSegmentSize == nil || cluster.Spec.Tuning.RatisSegmentSize.String() != "8Mi" {
+		t.Errorf("unset tuning field was not filled from the template: %v", cluster.Spec.Tuning.RatisSegmentSize)
+	}
+	if cluster.Spec.Tuning.RocksDBBlockCacheSize.String() != "1Gi" {
+		t.Errorf("cluster tuning field was overwritten: %v", cluster.Spec.Tuning.RocksDBBlockCacheSize)
+	}
+	if cluster.Spec.Security == nil || !cluster.Spec.Security.FIPS {
+		t.Error("security defaults were not taken from the template")
+	}
+
+	withSecurity := &OzoneCluster{
+		Spec: OzoneClusterSpec{Security: &SecuritySpec{ReadOnlyRootFilesystem: true}},
+	}
+	mergeConfigTemplate(withSecurity, configTemplate)
+	if withSecurity.Spec.Security.FIPS {
+		t.Error("template security replaced the cluster's own security spec")
+	}
+}
+
+func TestValidateNotifications(t *testing.T) {
+	tests := []struct {
+		name          string
+		notifications *NotificationsSpec
+		wantErr       bool
+	}{
+		{
+			name: "no notifications spec",
+		},
+		{
+			name:          "slack channel",
+			notifications: &NotificationsSpec{SlackWebhookSecret: "slack-webhook"},
+		},
+		{
+			name:          "generic webhook",
+			notifications: &NotificationsSpec{WebhookURL: "https://hooks.example.com/ozone"},
+		},
+		{
+			name:          "smtp channel",
+			notifications: &NotificationsSpec{SMTP: &SMTPNotificationSpec{CredentialsSecret: "smtp-creds", To: []string{"oncall@example.com"}}},
+		},
+		{
+			name:          "no channel configured",
+			notifications: &NotificationsSpec{},
+			wantErr:       true,
+		},
+		{
+			name:          "bogus webhook URL",
+			notifications: &NotificationsSpec{WebhookURL: "hooks.example.com/ozone"},
+			wantErr:       true,
+		},
+		{
+			name:          "smtp without credentials",
+			notifications: &NotificationsSpec{SMTP: &SMTPNotificationSpec{To: []string{"oncall@example.com"}}},
+			wantErr:       true,
+		},
+		{
+			name:          "smtp without recipients",
+			notifications: &NotificationsSpec{SMTP: &SMTPNotificationSpec{CredentialsSecret: "smtp-creds"}},
+			wantErr:       true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{Notifications: tc.notifications}}
+			err := cluster.validateNotifications()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateImmutableFields(t *testing.T) {
+	class := func(name string) *string { return &name }
+	base := func() *OzoneCluster {
+		return &OzoneCluster{Spec: OzoneClusterSpec{
+			SCM: SCMSpec{Storage: StorageSpec{Size: resource.MustParse("10Gi"), StorageClass: class("fast")}},
+			OM:  OMSpec{Storage: StorageSpec{Size: resource.MustParse("10Gi")}},
+			Datanode: DatanodeSpec{Volumes: []DataVolume{
+				{Name: "data0", Size: resource.MustParse("100Gi")},
+			}},
+		}}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(cluster *OzoneCluster)
+		wantErr bool
+	}{
+		{
+			name:   "unchanged spec",
+			mutate: func(*OzoneCluster) {},
+		},
+		{
+			name:   "storage growth is allowed",
+			mutate: func(c *OzoneCluster) { c.Spec.OM.Storage.Size = resource.MustParse("20Gi") },
+		},
+		{
+			name: "appending a datanode volume is allowed",
+			mutate: func(c *OzoneCluster) {
+				c.Spec.Datanode.Volumes = append(c.Spec.Datanode.Volumes, DataVolume{Name: "data1", Size: resource.MustParse("100Gi")})
+			},
+		},
+		{
+			name:   "pinning an unset storageClass is allowed",
+			mutate: func(c *OzoneCluster) { c.Spec.OM.Storage.StorageClass = class("standard") },
+		},
+		{
+			name:    "storageClass change",
+			mutate:  func(c *OzoneCluster) { c.Spec.SCM.Storage.StorageClass = class("slow") },
+			wantErr: true,
+		},
+		{
+			name:    "storage shrink",
+			mutate:  func(c *OzoneCluster) { c.Spec.SCM.Storage.Size = resource.MustParse("5Gi") },
+			wantErr: true,
+		},
+		{
+			name:    "ephemeral toggle",
+			mutate:  func(c *OzoneCluster) { c.Spec.OM.Storage.Ephemeral = true },
+			wantErr: true,
+		},
+		{
+			name:    "renamed datanode volume",
+			mutate:  func(c *OzoneCluster) { c.Spec.Datanode.Volumes[0].Name = "renamed" },
+			wantErr: true,
+		},
+		{
+			name:    "shrunk datanode volume",
+			mutate:  func(c *OzoneCluster) { c.Spec.Datanode.Volumes[0].Size = resource.MustParse("50Gi") },
+			wantErr: true,
+		},
+		{
+			name: "unrelated config change is allowed",
+			mutate: func(c *OzoneCluster) {
+				c.Spec.Config = map[string]string{"ozone.om.ratis.segment.size": "16MB"}
+			},
+		},
+		{
+			name: "om service id override",
+			mutate: func(c *OzoneCluster) {
+				c.Spec.Config = map[string]string{"ozone.om.service.id": "renamed"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "scm service id override",
+			mutate: func(c *OzoneCluster) {
+				c.Spec.Config = map[string]string{"ozone.scm.service.ids": "renamed"}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			old := base()
+			updated := base()
+			tc.mutate(updated)
+			err := updated.validateImmutableFields(old)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateReplication(t *testing.T) {
+	replicas := func(n int32) *int32 { return &n }
+	tests := []struct {
+		name    string
+		cluster OzoneCluster
+		wantErr bool
+	}{
+		{
+			name:    "no replication spec",
+			cluster: OzoneCluster{},
+		},
+		{
+			name: "ec with enough datanodes",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode:    DatanodeSpec{ComponentSpec: ComponentSpec{Replicas: replicas(9)}},
+				Replication: &ReplicationSpec{Type: ReplicationEC, ECPolicy: "rs-6-3-1024k"},
+			}},
+		},
+		{
+			name: "ec with too few datanodes",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode:    DatanodeSpec{ComponentSpec: ComponentSpec{Replicas: replicas(5)}},
+				Replication: &ReplicationSpec{Type: ReplicationEC, ECPolicy: "rs-6-3-1024k"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "ratis three with default datanodes",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Replication: &ReplicationSpec{Type: ReplicationRatis, Factor: "THREE"},
+			}},
+		},
+		{
+			name: "ratis three with one datanode",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode:    DatanodeSpec{ComponentSpec: ComponentSpec{Replicas: replicas(1)}},
+				Replication: &ReplicationSpec{Type: ReplicationRatis, Factor: "THREE"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid allowed policy",
+			cluster: OzoneCluster{Spec: OzoneClusterSpec{
+				Datanode: DatanodeSpec{ComponentSpec: ComponentSpec{Replicas: replicas(9)}},
+				Replication: &ReplicationSpec{
+					Type: ReplicationEC, ECPolicy: "rs-6-3-1024k", AllowedECPolicies: []string{"bogus"},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cluster.validateReplication()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name: "no templates",
+		},
+		{
+			name:   "valid config template",
+			config: map[string]string{"ozone.om.service.id": "{{ .Name }}"},
+			files:  map[string]string{"jmx-rules.yaml": "cluster: {{ .Namespace }}/{{ .Name }}"},
+		},
+		{
+			name:    "broken config template",
+			config:  map[string]string{"ozone.om.service.id": "{{ .Name"},
+			wantErr: true,
+		},
+		{
+			name:    "broken file template",
+			files:   map[string]string{"log4j2.properties": "{{ end }}"},
+			wantErr: true,
+		},
+		{
+			name:    "file name with path separator",
+			files:   map[string]string{"conf/log4j2.properties": "x"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{Config: tc.config, ExtraConfigFiles: tc.files}}
+			err := cluster.validateConfigTemplates()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		security *SecuritySpec
+		config   map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "no security section",
+		},
+		{
+			name:     "auth without credentials secret",
+			security: &SecuritySpec{Metrics: &MetricsSecuritySpec{Auth: MetricsAuthBearer}},
+			wantErr:  true,
+		},
+		{
+			name: "auth with credentials secret",
+			security: &SecuritySpec{Metrics: &MetricsSecuritySpec{
+				Auth: MetricsAuthBasic, CredentialsSecret: "metrics-creds",
+			}},
+		},
+		{
+			name:     "fips image without fips",
+			security: &SecuritySpec{FIPSImage: "apache/ozone:2.0.0-fips"},
+			wantErr:  true,
+		},
+		{
+			name:     "fips with weak keystore override",
+			security: &SecuritySpec{FIPS: true},
+			config:   map[string]string{"ssl.server.keystore.type": "JKS"},
+			wantErr:  true,
+		},
+		{
+			name:     "fips with weak protocol override",
+			security: &SecuritySpec{FIPS: true},
+			config:   map[string]string{"hadoop.ssl.enabled.protocols": "TLSv1.2, TLSv1"},
+			wantErr:  true,
+		},
+		{
+			name:     "fips with approved overrides",
+			security: &SecuritySpec{FIPS: true},
+			config: map[string]string{
+				"ssl.server.keystore.type":     "BCFKS",
+				"hadoop.ssl.enabled.protocols": "TLSv1.2,TLSv1.3",
+			},
+		},
+		{
+			name:     "encryption with a valid kms uri",
+			security: &SecuritySpec{Encryption: &EncryptionSpec{KMSURI: "kms://https@kms.kms.svc:9600/kms"}},
+		},
+		{
+			name:     "encryption without a kms uri",
+			security: &SecuritySpec{Encryption: &EncryptionSpec{BucketKey: "bucket-key"}},
+			wantErr:  true,
+		},
+		{
+			name:     "encryption with a plain http kms uri",
+			security: &SecuritySpec{Encryption: &EncryptionSpec{KMSURI: "http://kms.kms.svc:9600/kms"}},
+			wantErr:  true,
+		},
+		{
+			name:     "encryption with an unknown kms transport",
+			security: &SecuritySpec{Encryption: &EncryptionSpec{KMSURI: "kms://ftp@kms.kms.svc:9600/kms"}},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{Security: tc.security, Config: tc.config}}
+			err := cluster.validateSecurity()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateS3Gateway(t *testing.T) {
+	threads := int32(64)
+	noThreads := int32(0)
+	tests := []struct {
+		name    string
+		domain  string
+		limits  *S3GatewayLimitsSpec
+		wantErr bool
+	}{
+		{name: "no domain"},
+		{name: "bare dns suffix", domain: "s3.example.com"},
+		{name: "url instead of a suffix", domain: "https://s3.example.com", wantErr: true},
+		{name: "wildcard already included", domain: "*.s3.example.com", wantErr: true},
+		{name: "leading dot", domain: ".s3.example.com", wantErr: true},
+		{name: "valid limits", limits: &S3GatewayLimitsSpec{
+			HandlerThreads: &threads,
+			IdleTimeout:    &metav1.Duration{Duration: time.Minute},
+			RateLimit:      &S3RateLimitSpec{RequestsPerSecond: 100, Burst: 50},
+		}},
+		{name: "zero handler threads", limits: &S3GatewayLimitsSpec{HandlerThreads: &noThreads}, wantErr: true},
+		{name: "negative idle timeout",
+			limits: &S3GatewayLimitsSpec{IdleTimeout: &metav1.Duration{Duration: -time.Second}}, wantErr: true},
+		{name: "rate limit without a rate", limits: &S3GatewayLimitsSpec{RateLimit: &S3RateLimitSpec{}}, wantErr: true},
+		{name: "negative burst",
+			limits: &S3GatewayLimitsSpec{RateLimit: &S3RateLimitSpec{RequestsPerSecond: 10, Burst: -1}}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{
+				S3Gateway: &S3GatewaySpec{DomainName: tc.domain, Limits: tc.limits},
+			}}
+			err := cluster.validateS3Gateway()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateTrash(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+	tests := []struct {
+		name    string
+		trash   *TrashSpec
+		wantErr bool
+	}{
+		{name: "no trash"},
+		{name: "interval only", trash: &TrashSpec{Interval: metav1.Duration{Duration: 24 * time.Hour}}},
+		{name: "checkpoint below interval", trash: &TrashSpec{
+			Interval: metav1.Duration{Duration: 24 * time.Hour}, CheckpointInterval: duration(time.Hour)}},
+		{name: "sub-minute interval", trash: &TrashSpec{Interval: metav1.Duration{Duration: time.Second}},
+			wantErr: true},
+		{name: "checkpoint above interval", trash: &TrashSpec{
+			Interval: metav1.Duration{Duration: time.Hour}, CheckpointInterval: duration(2 * time.Hour)},
+			wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{Spec: OzoneClusterSpec{Trash: tc.trash}}
+			err := cluster.validateTrash()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   *APIProxySpec
+		wantErr bool
+	}{
+		{name: "no proxy"},
+		{name: "defaults", proxy: &APIProxySpec{}},
+		{name: "valid selection", proxy: &APIProxySpec{Components: []string{"recon"}, AllowPaths: []string{"/api/v1/*"}}},
+		{name: "unknown component", proxy: &APIProxySpec{Components: []string{"scm"}}, wantErr: true},
+		{name: "relative path", proxy: &APIProxySpec{AllowPaths: []string{"api/v1"}}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &OzoneCluster{Spec: OzoneClusterSpec{APIProxy: tc.proxy}}
+			err := cluster.validateAPIProxy()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateDelete(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	available := []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, Reason: "DatanodesReady"}}
+	tests := []struct {
+		name       string
+		protection *bool
+		conditions []metav1.Condition
+		wantErr    bool
+	}{
+		{name: "explicitly protected", protection: boolPtr(true), wantErr: true},
+		{name: "explicitly unprotected running cluster", protection: boolPtr(false), conditions: available},
+		{name: "unset protection on running cluster", conditions: available, wantErr: true},
+		{name: "unset protection on cluster that never came up"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := OzoneCluster{
+				Spec:   OzoneClusterSpec{DeletionProtection: tc.protection},
+				Status: OzoneClusterStatus{Conditions: tc.conditions},
+			}
+			_, err := cluster.ValidateDelete()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected deletion to be rejected, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected deletion rejection: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OzoneConfigTemplateSpec holds reusable defaults shared by a fleet of OzoneClusters. The
+// defaulting webhook merges these into every OzoneCluster that references the template through
+// spec.templateRef; values set on the cluster itself always win.
+type OzoneConfigTemplateSpec struct {
+	// Config entries are merged under spec.config of referencing clusters; keys the cluster sets
+	// itself are left untouched.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Tuning fills the fields of spec.tuning the cluster left unset.
+	// +optional
+	Tuning *TuningSpec `json:"tuning,omitempty"`
+
+	// Security becomes spec.security of clusters that configure none themselves.
+	// +optional
+	Security *SecuritySpec `json:"security,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// OzoneConfigTemplate is a cluster-scoped set of config, tuning, and security defaults that
+// OzoneClusters reference through spec.templateRef, so a platform team manages one template
+// instead of repeating the same settings across dozens of cluster specs. The template is applied
+// at admission: editing it affects clusters on their next create or update, not retroactively.
+type OzoneConfigTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OzoneConfigTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneConfigTemplateList contains a list of OzoneConfigTemplate.
+type OzoneConfigTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneConfigTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneConfigTemplate{}, &OzoneConfigTemplateList{})
+}
@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceAction is the operation a maintenance applies to its targets.
+type MaintenanceAction string
+
+const (
+	// MaintenanceActionRestart restarts the target pods one by one.
+	MaintenanceActionRestart MaintenanceAction = "Restart"
+
+	// MaintenanceActionDecommission permanently decommissions the target datanodes, moving their
+	// containers off before removal.
+	MaintenanceActionDecommission MaintenanceAction = "Decommission"
+
+	// MaintenanceActionMaintenanceMode puts the target datanodes into Ozone maintenance mode, so
+	// SCM does not re-replicate their containers during the planned downtime.
+	MaintenanceActionMaintenanceMode MaintenanceAction = "MaintenanceMode"
+
+	// MaintenanceActionTransferLeader hands the Ratis leadership of an HA SCM or OM ring to the
+	// single named pod, or to a random peer when no pod is named.
+	MaintenanceActionTransferLeader MaintenanceAction = "TransferLeader"
+)
+
+// MaintenanceWindow bounds when a planned maintenance may run.
+type MaintenanceWindow struct {
+	// Start is the earliest time the maintenance may begin.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End is the latest time the maintenance may still begin; a maintenance not started by then
+	// fails with WindowExpired.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// OzoneMaintenanceSpec describes one planned maintenance operation.
+type OzoneMaintenanceSpec struct {
+	// Cluster names the OzoneCluster (same namespace) the maintenance applies to.
+	Cluster string `json:"cluster"`
+
+	// Component the maintenance targets: scm, om, datanode, recon, s3g or httpfs. Decommission
+	// and MaintenanceMode only apply to datanodes; TransferLeader only to scm and om.
+	Component string `json:"component"`
+
+	// Pods restricts the maintenance to the named pods; all pods of the component when empty.
+	// For TransferLeader the single named pod is the replica that receives leadership.
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+
+	// Action performed on the targets.
+	// +kubebuilder:validation:Enum=Restart;Decommission;MaintenanceMode;TransferLeader
+	Action MaintenanceAction `json:"action"`
+
+	// Window bounds when the maintenance may run; immediately when absent.
+	// +optional
+	Window *MaintenanceWindow `json:"window,omitempty"`
+}
+
+// MaintenancePhase is the coarse execution state of a maintenance.
+type MaintenancePhase string
+
+const (
+	// MaintenancePending means the maintenance waits for its window to open.
+	MaintenancePending MaintenancePhase = "Pending"
+
+	// MaintenanceInProgress means targets are being processed.
+	MaintenanceInProgress MaintenancePhase = "InProgress"
+
+	// MaintenanceCompleted means every target was processed successfully.
+	MaintenanceCompleted MaintenancePhase = "Completed"
+
+	// MaintenanceFailed means the maintenance gave up, e.g. because its window expired.
+	MaintenanceFailed MaintenancePhase = "Failed"
+)
+
+// MaintenanceLogEntry is one audit trail entry of an executed maintenance step.
+type MaintenanceLogEntry struct {
+	// Time the step was executed.
+	Time metav1.Time `json:"time"`
+
+	// Target pod or datanode host of the step.
+	Target string `json:"target"`
+
+	// Message describes what was done or what failed.
+	Message string `json:"message"`
+}
+
+// OzoneMaintenanceStatus records the execution state and audit trail of a maintenance.
+type OzoneMaintenanceStatus struct {
+	// Phase of the maintenance.
+	// +optional
+	Phase MaintenancePhase `json:"phase,omitempty"`
+
+	// StartTime is when execution began.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the maintenance completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CompletedTargets lists the targets already processed, so execution resumes where it left
+	// off after a restart of the operator.
+	// +optional
+	CompletedTargets []string `json:"completedTargets,omitempty"`
+
+	// Log is the audit trail of every executed step, including failures.
+	// +optional
+	Log []MaintenanceLogEntry `json:"log,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneMaintenance is one planned maintenance operation against an OzoneCluster, executed by the
+// operator with the required Ozone-side preparation and a complete audit trail in its status.
+type OzoneMaintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneMaintenanceSpec   `json:"spec,omitempty"`
+	Status OzoneMaintenanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneMaintenanceList contains a list of OzoneMaintenance.
+type OzoneMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneMaintenance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneMaintenance{}, &OzoneMaintenanceList{})
+}
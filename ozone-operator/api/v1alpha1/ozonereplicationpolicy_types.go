@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicationTarget points at the destination of a replication policy: either another OzoneCluster
+// in the same namespace or the OM address of a remote deployment.
+type ReplicationTarget struct {
+	// Cluster names an OzoneCluster in the same namespace.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// OMAddress is the Ozone Manager address of a remote cluster, used when the target is not
+	// managed by this operator.
+	// +optional
+	OMAddress string `json:"omAddress,omitempty"`
+}
+
+// ReplicatedBucket selects one bucket for replication.
+type ReplicatedBucket struct {
+	// Volume containing the bucket.
+	Volume string `json:"volume"`
+
+	// Bucket to replicate.
+	Bucket string `json:"bucket"`
+}
+
+// OzoneReplicationPolicySpec configures asynchronous replication of selected buckets from one
+// Ozone cluster to another.
+type OzoneReplicationPolicySpec struct {
+	// SourceCluster names the OzoneCluster (same namespace) the data is read from.
+	SourceCluster string `json:"sourceCluster"`
+
+	// Target is the destination of the replication.
+	Target ReplicationTarget `json:"target"`
+
+	// Buckets lists the buckets to replicate.
+	Buckets []ReplicatedBucket `json:"buckets"`
+
+	// Schedule is the cron schedule of the replication jobs. Defaults to hourly.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Suspend pauses replication without deleting the policy.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ReplicatedBucketStatus reports the replication progress of one bucket.
+type ReplicatedBucketStatus struct {
+	// Volume containing the bucket.
+	Volume string `json:"volume"`
+
+	// Bucket being replicated.
+	Bucket string `json:"bucket"`
+
+	// LastSyncTime is when the last replication job of this bucket succeeded.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Lag is the time elapsed since the last successful sync.
+	// +optional
+	Lag *metav1.Duration `json:"lag,omitempty"`
+}
+
+// OzoneReplicationPolicyStatus defines the observed replication state.
+type OzoneReplicationPolicyStatus struct {
+	// Conditions are the latest available observations of the policy state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Buckets reports per-bucket replication progress.
+	// +optional
+	Buckets []ReplicatedBucketStatus `json:"buckets,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneReplicationPolicy replicates selected buckets between Ozone clusters asynchronously.
+type OzoneReplicationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneReplicationPolicySpec   `json:"spec,omitempty"`
+	Status OzoneReplicationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneReplicationPolicyList contains a list of OzoneReplicationPolicy.
+type OzoneReplicationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneReplicationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneReplicationPolicy{}, &OzoneReplicationPolicyList{})
+}
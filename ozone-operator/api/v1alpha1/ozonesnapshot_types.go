@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OzoneSnapshotSpec requests Ozone-native snapshots of one bucket.
+type OzoneSnapshotSpec struct {
+	// Cluster names the OzoneCluster (same namespace) holding the bucket.
+	Cluster string `json:"cluster"`
+
+	// Volume is the Ozone volume containing the bucket.
+	Volume string `json:"volume"`
+
+	// Bucket to snapshot.
+	Bucket string `json:"bucket"`
+
+	// SnapshotName is the base name of the created snapshots; defaults to the object name.
+	// Periodic snapshots get a timestamp suffix.
+	// +optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// Interval between periodic snapshots. A single snapshot is taken when unset.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Retention prunes old snapshots; without it, snapshots accumulate until deleted manually.
+	// +optional
+	Retention *SnapshotRetentionSpec `json:"retention,omitempty"`
+
+	// Export copies the data of every new snapshot to an external S3 bucket through a Job.
+	// After the first full export, only the keys changed since the previously exported snapshot
+	// are copied, based on `ozone sh snapshot diff`.
+	// +optional
+	Export *SnapshotExportSpec `json:"export,omitempty"`
+}
+
+// SnapshotRetentionSpec bounds how many snapshots of the bucket are kept. Both limits may be
+// combined; a snapshot is pruned as soon as it violates either.
+type SnapshotRetentionSpec struct {
+	// MaxCount keeps at most this many snapshots, pruning the oldest first.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// MaxAge prunes snapshots older than this.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// SnapshotExportSpec describes where snapshot data is exported to.
+type SnapshotExportSpec struct {
+	// Destination is the export target as an s3:// URI, e.g. s3://ozone-exports/prod.
+	Destination string `json:"destination"`
+
+	// S3 holds the connection settings and credentials of the destination bucket.
+	// +optional
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+}
+
+// CreatedSnapshot records one snapshot this object created in the bucket.
+type CreatedSnapshot struct {
+	// Name of the snapshot inside the bucket.
+	Name string `json:"name"`
+
+	// CreationTime is when the snapshot was taken.
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+}
+
+// OzoneSnapshotStatus defines the observed snapshot state.
+type OzoneSnapshotStatus struct {
+	// Conditions are the latest available observations of the snapshot state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Snapshots lists the snapshots created by this object and not yet pruned, oldest first.
+	// +optional
+	Snapshots []CreatedSnapshot `json:"snapshots,omitempty"`
+
+	// LastSnapshotTime is when the most recent snapshot was taken.
+	// +optional
+	LastSnapshotTime *metav1.Time `json:"lastSnapshotTime,omitempty"`
+
+	// LastExportedSnapshot is the most recent snapshot whose export Job completed; the next
+	// export diffs against it.
+	// +optional
+	LastExportedSnapshot string `json:"lastExportedSnapshot,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneSnapshot takes Ozone-native bucket snapshots (`ozone sh snapshot create`) in an
+// OzoneCluster, once or on an interval, and prunes them per the retention rules.
+type OzoneSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneSnapshotSpec   `json:"spec,omitempty"`
+	Status OzoneSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneSnapshotList contains a list of OzoneSnapshot.
+type OzoneSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneSnapshot{}, &OzoneSnapshotList{})
+}
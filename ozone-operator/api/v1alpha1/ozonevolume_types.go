@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaSpec configures the space and namespace quotas of a volume or bucket.
+type QuotaSpec struct {
+	// Space caps the bytes stored; unlimited when unset.
+	// +optional
+	Space *resource.Quantity `json:"space,omitempty"`
+
+	// Namespace caps the number of child entries (buckets in a volume, keys in a bucket);
+	// unlimited when unset.
+	// +optional
+	Namespace *int64 `json:"namespace,omitempty"`
+}
+
+// QuotaUsageStatus reports the observed quota usage of a volume or bucket.
+type QuotaUsageStatus struct {
+	// UsedBytes is the space currently consumed.
+	// +optional
+	UsedBytes int64 `json:"usedBytes,omitempty"`
+
+	// UsedNamespace is the number of child entries currently present.
+	// +optional
+	UsedNamespace int64 `json:"usedNamespace,omitempty"`
+
+	// LastProbeTime is when the usage was last read from OM.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// OzoneVolumeSpec defines an Ozone volume managed in a cluster.
+type OzoneVolumeSpec struct {
+	// Cluster names the OzoneCluster (same namespace) the volume lives in.
+	Cluster string `json:"cluster"`
+
+	// VolumeName overrides the Ozone volume name; defaults to the object name.
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// Quota limits the space and bucket count of the volume.
+	// +optional
+	Quota *QuotaSpec `json:"quota,omitempty"`
+}
+
+// OzoneVolumeStatus defines the observed state of a managed volume.
+type OzoneVolumeStatus struct {
+	// Conditions are the latest available observations of the volume state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Usage reports the current quota usage as read from OM.
+	// +optional
+	Usage *QuotaUsageStatus `json:"usage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OzoneVolume manages one Ozone volume, including its quotas, in an OzoneCluster.
+type OzoneVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OzoneVolumeSpec   `json:"spec,omitempty"`
+	Status OzoneVolumeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OzoneVolumeList contains a list of OzoneVolume.
+type OzoneVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OzoneVolume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OzoneVolume{}, &OzoneVolumeList{})
+}
@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// The ozone-operator manages Ozone clusters on Kubernetes through the OzoneCluster custom resource.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/yaml"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+	"github.com/peterxcli/ozone/ozone-operator/internal/controller"
+	"github.com/peterxcli/ozone/ozone-operator/internal/webhookcert"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ozonev1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var metricsAddr string
+	var probeAddr string
+	var enableLeaderElection bool
+	var renderPath string
+	var manageWebhookCerts bool
+	var protectChildResources bool
+	var chaosFaults string
+	var fleetReportAddr string
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&fleetReportAddr, "fleet-report-bind-address", ":8082",
+		"The address the aggregated /fleet report of all managed OzoneClusters binds to. Empty disables it.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+		"Enable leader election for controller manager.")
+	flag.BoolVar(&manageWebhookCerts, "manage-webhook-certs", true,
+		"Generate and rotate the self-signed webhook serving certificate in-process. Disable when "+
+			"certificates are provided externally, e.g. by cert-manager.")
+	flag.BoolVar(&protectChildResources, "protect-child-resources", false,
+		"Reject manual edits and deletes of operator-owned StatefulSets and ConfigMaps at admission "+
+			"unless the object carries the ozone.apache.org/break-glass=true annotation.")
+	flag.StringVar(&renderPath, "render", "",
+		"Render the child resources of the OzoneCluster in the given YAML file to stdout and exit, "+
+			"without connecting to an API server.")
+	flag.StringVar(&chaosFaults, "chaos-faults", "",
+		"Comma-separated fault injections for exercising recovery paths in e2e tests "+
+			"(exec-error, safemode, api-error, slow-rollout). Never enable in production.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if renderPath != "" {
+		if err := render(renderPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "ozone-operator.ozone.apache.org",
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	executor, err := controller.NewPodExecutor(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create pod executor")
+		os.Exit(1)
+	}
+
+	logReader, _ := executor.(controller.PodLogReader)
+	clusterClient := client.Client(mgr.GetClient())
+	if chaosFaults != "" {
+		faults, err := controller.ParseChaosFaults(chaosFaults)
+		if err != nil {
+			setupLog.Error(err, "invalid --chaos-faults")
+			os.Exit(1)
+		}
+		setupLog.Info("chaos mode enabled, injecting faults into reconciliation", "faults", chaosFaults)
+		executor = controller.NewChaosExecutor(executor, faults)
+		clusterClient = controller.NewChaosClient(clusterClient, faults)
+	}
+	clusterReconciler := &controller.OzoneClusterReconciler{
+		Client:    clusterClient,
+		Scheme:    mgr.GetScheme(),
+		Executor:  executor,
+		LogReader: logReader,
+		Recorder:  controller.NewDedupingRecorder(mgr.GetEventRecorderFor("ozonecluster-controller")),
+	}
+	if err := clusterReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneCluster")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneClusterMonitoringReconciler{
+		OzoneClusterReconciler: clusterReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneClusterMonitoring")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneClusterBackupReconciler{
+		OzoneClusterReconciler: clusterReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneClusterBackup")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneClusterStatusReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneClusterStatus")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneReplicationPolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneReplicationPolicy")
+		os.Exit(1)
+	}
+
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if manageWebhookCerts {
+			certOptions := webhookCertOptions()
+			if err := webhookcert.Ensure(context.Background(), mgr.GetConfig(), certOptions); err != nil {
+				setupLog.Error(err, "unable to provision the webhook serving certificate")
+				os.Exit(1)
+			}
+			if err := mgr.Add(&webhookcert.Rotator{Config: mgr.GetConfig(), Options: certOptions}); err != nil {
+				setupLog.Error(err, "unable to register webhook certificate rotation")
+				os.Exit(1)
+			}
+		}
+		if err := (&ozonev1alpha1.OzoneCluster{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OzoneCluster")
+			os.Exit(1)
+		}
+		if protectChildResources {
+			if err := (&controller.ChildProtectionWebhook{
+				OperatorUser: operatorServiceAccountUser(),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "ChildProtection")
+				os.Exit(1)
+			}
+		}
+	}
+
+	if err := (&controller.OzoneVolumeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Executor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneVolume")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneBucketReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Executor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneBucket")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneSnapshotReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Executor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneSnapshot")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneBucketPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Executor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneBucketPolicy")
+		os.Exit(1)
+	}
+
+	if err := (&controller.OzoneMaintenanceReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Executor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OzoneMaintenance")
+		os.Exit(1)
+	}
+
+	if fleetReportAddr != "" {
+		if err := mgr.Add(&controller.FleetReportServer{Reader: mgr.GetClient(), Addr: fleetReportAddr}); err != nil {
+			setupLog.Error(err, "unable to register the fleet report server")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// operatorServiceAccountUser is the username the operator's own API requests carry, derived from
+// the deployment environment the same way as webhookCertOptions. The child protection webhook
+// must never reject the reconcile loop's writes.
+func operatorServiceAccountUser() string {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = "ozone-operator-system"
+	}
+	serviceAccount := os.Getenv("POD_SERVICE_ACCOUNT")
+	if serviceAccount == "" {
+		serviceAccount = "ozone-operator-controller-manager"
+	}
+	return "system:serviceaccount:" + namespace + ":" + serviceAccount
+}
+
+// webhookCertOptions derives the webhook certificate wiring from the deployment environment,
+// matching the names the kustomize install uses.
+func webhookCertOptions() webhookcert.Options {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = "ozone-operator-system"
+	}
+	return webhookcert.Options{
+		Namespace:                          namespace,
+		ServiceName:                        "ozone-operator-webhook-service",
+		SecretName:                         "ozone-operator-webhook-cert",
+		CertDir:                            filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		MutatingWebhookConfigurationName:   "ozone-operator-mutating-webhook-configuration",
+		ValidatingWebhookConfigurationName: "ozone-operator-validating-webhook-configuration",
+	}
+}
+
+// render reads an OzoneCluster manifest, applies the same defaulting as the admission webhook,
+// and prints every child resource the operator would create as multi-document YAML.
+func render(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if err := yaml.UnmarshalStrict(data, cluster); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	cluster.Default()
+	manifests, err := controller.RenderManifests(cluster)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(manifests)
+	return err
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// wait is a tiny standalone binary for startup gate init containers. It blocks until any of the
+// given TCP addresses accepts a connection, so dependent components can start as soon as one
+// replica of an HA service (any OM or SCM, not only ordinal 0) is reachable.
+//
+// Usage: wait [-interval 2s] [-timeout 0] host:port [host:port...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+func main() {
+	interval := flag.Duration("interval", 2*time.Second, "delay between connection attempts")
+	timeout := flag.Duration("timeout", 0, "give up after this long; zero waits forever")
+	flag.Parse()
+	targets := flag.Args()
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: wait [-interval 2s] [-timeout 0] host:port [host:port...]")
+		os.Exit(2)
+	}
+
+	deadline := time.Time{}
+	if *timeout > 0 {
+		deadline = time.Now().Add(*timeout)
+	}
+	for {
+		for _, target := range targets {
+			conn, err := net.DialTimeout("tcp", target, *interval)
+			if err == nil {
+				conn.Close()
+				fmt.Printf("%s is reachable\n", target)
+				return
+			}
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			fmt.Fprintf(os.Stderr, "timed out after %s waiting for %v\n", *timeout, targets)
+			os.Exit(1)
+		}
+		fmt.Printf("waiting for %v\n", targets)
+		time.Sleep(*interval)
+	}
+}
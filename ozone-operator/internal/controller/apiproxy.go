@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// apiProxyPort is where the kube-rbac-proxy sidecar listens; the component Services expose it as
+// the authenticated "api" port next to the raw component ports.
+const apiProxyPort = int32(8443)
+
+// defaultAPIProxyImage is the sidecar image used when spec.apiProxy.image is empty.
+const defaultAPIProxyImage = "quay.io/brancz/kube-rbac-proxy:v0.18.0"
+
+// defaultAPIProxyPaths are the read-only insight endpoints proxied when spec.apiProxy.allowPaths
+// is empty: the Recon REST API plus the metrics and JMX pages every component serves.
+var defaultAPIProxyPaths = []string{"/api/v1/*", "/prom", "/jmx"}
+
+// apiProxyEnabled reports whether a component's HTTP API is put behind the kube-rbac-proxy
+// sidecar.
+func apiProxyEnabled(cluster *ozonev1alpha1.OzoneCluster, component string) bool {
+	if cluster.Spec.APIProxy == nil {
+		return false
+	}
+	switch component {
+	case reconComponent, omComponent:
+	default:
+		return false
+	}
+	components := cluster.Spec.APIProxy.Components
+	if len(components) == 0 {
+		return true
+	}
+	for _, name := range components {
+		if name == component {
+			return true
+		}
+	}
+	return false
+}
+
+// applyAPIProxy appends the kube-rbac-proxy sidecar to a proxied component pod. The sidecar
+// verifies the caller's bearer token through TokenReview, authorizes the request through
+// SubjectAccessReview, and forwards only the allowed read-only paths to the HTTP port on
+// localhost.
+func applyAPIProxy(cluster *ozonev1alpha1.OzoneCluster, component string, pod *corev1.PodSpec,
+	upstreamPort int32) {
+	if !apiProxyEnabled(cluster, component) {
+		return
+	}
+	proxy := cluster.Spec.APIProxy
+	image := proxy.Image
+	if image == "" {
+		image = defaultAPIProxyImage
+	}
+	paths := proxy.AllowPaths
+	if len(paths) == 0 {
+		paths = defaultAPIProxyPaths
+	}
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:  "api-proxy",
+		Image: image,
+		Args: []string{
+			fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", apiProxyPort),
+			fmt.Sprintf("--upstream=http://127.0.0.1:%d/", upstreamPort),
+			"--allow-paths=" + strings.Join(paths, ","),
+			"--logtostderr=true",
+		},
+		Ports: []corev1.ContainerPort{{Name: "api-proxy", ContainerPort: apiProxyPort}},
+	})
+}
+
+// addAPIProxyPort appends the authenticated "api" port to the Service of a proxied component.
+func addAPIProxyPort(cluster *ozonev1alpha1.OzoneCluster, component string,
+	svc *corev1.Service) *corev1.Service {
+	if apiProxyEnabled(cluster, component) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: "api", Port: apiProxyPort})
+	}
+	return svc
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionBackupDestinationReachable is False while the backup destination bucket cannot be
+// reached, so a broken destination shows up well before the first scheduled backup runs.
+const ConditionBackupDestinationReachable = "BackupDestinationReachable"
+
+// backupProbeInterval is how often the backup destination is re-probed.
+const backupProbeInterval = 10 * time.Minute
+
+// backupBucketURL resolves the HTTP URL of the destination bucket, either under the configured
+// endpoint or under the AWS endpoint of the configured region.
+func backupBucketURL(backup *ozonev1alpha1.BackupSpec) (string, error) {
+	destination, err := url.Parse(backup.Destination)
+	if err != nil {
+		return "", fmt.Errorf("invalid backup destination %q: %v", backup.Destination, err)
+	}
+	bucket := destination.Host
+	if backup.S3 != nil && backup.S3.Endpoint != "" {
+		return strings.TrimSuffix(backup.S3.Endpoint, "/") + "/" + bucket, nil
+	}
+	if backup.S3 != nil && backup.S3.Region != "" {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, backup.S3.Region), nil
+	}
+	return "", fmt.Errorf("backup destination %q has neither an endpoint nor a region", backup.Destination)
+}
+
+// reconcileBackup probes the backup destination bucket with an unauthenticated HEAD request and
+// reports the result through the BackupDestinationReachable condition. Any HTTP response except 404
+// counts as reachable: S3 answers 403 to anonymous HeadBucket on an existing bucket, and the
+// credentials themselves are only exercised by the backup job.
+func (r *OzoneClusterReconciler) reconcileBackup(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Suspended {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionBackupDestinationReachable)
+		return ctrl.Result{}, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionBackupDestinationReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DestinationReachable",
+		ObservedGeneration: cluster.Generation,
+	}
+	bucketURL, err := backupBucketURL(cluster.Spec.Backup)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidDestination"
+		condition.Message = err.Error()
+		previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupDestinationReachable)
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+		if previous == nil || previous.Status != condition.Status {
+			r.notify(ctx, cluster, condition.Reason, condition.Message)
+		}
+		return ctrl.Result{RequeueAfter: backupProbeInterval}, nil
+	}
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, bucketURL, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	resp, err := httpClient.Do(req)
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "DestinationUnreachable"
+		condition.Message = fmt.Sprintf("HEAD %s failed: %v", bucketURL, err)
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "BucketNotFound"
+		condition.Message = fmt.Sprintf("the destination bucket at %s does not exist", bucketURL)
+	default:
+		resp.Body.Close()
+		condition.Message = fmt.Sprintf("the destination bucket at %s answered %s", bucketURL, resp.Status)
+	}
+	previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupDestinationReachable)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	// Notify on flips only; a destination that is reachable from the first probe on stays silent.
+	if (previous == nil && condition.Status == metav1.ConditionFalse) ||
+		(previous != nil && previous.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return ctrl.Result{RequeueAfter: backupProbeInterval}, nil
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// balancerScheduleRequeue is how often the balancer schedule window is re-evaluated.
+const balancerScheduleRequeue = time.Minute
+
+// inDailyWindow reports whether now falls into the daily HH:MM window. Windows may wrap midnight.
+func inDailyWindow(startSpec, endSpec string, now time.Time) (bool, error) {
+	start, err := time.Parse("15:04", startSpec)
+	if err != nil {
+		return false, fmt.Errorf("invalid window start %q: %w", startSpec, err)
+	}
+	end, err := time.Parse("15:04", endSpec)
+	if err != nil {
+		return false, fmt.Errorf("invalid window end %q: %w", endSpec, err)
+	}
+	minute := now.Hour()*60 + now.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute, nil
+	}
+	return minute >= startMinute || minute < endMinute, nil
+}
+
+// inBalancerSchedule reports whether now falls into the daily window. Windows may wrap midnight.
+func inBalancerSchedule(schedule *ozonev1alpha1.BalancerSchedule, now time.Time) (bool, error) {
+	return inDailyWindow(schedule.Start, schedule.End, now)
+}
+
+// balancerStartCommand renders the containerbalancer start invocation from the spec.
+func balancerStartCommand(balancer *ozonev1alpha1.BalancerSpec) []string {
+	command := []string{"ozone", "admin", "containerbalancer", "start"}
+	if balancer.Threshold != nil {
+		command = append(command, "-t", fmt.Sprintf("%d", *balancer.Threshold))
+	}
+	if balancer.MaxDatanodesPercentage != nil {
+		command = append(command, "-d", fmt.Sprintf("%d", *balancer.MaxDatanodesPercentage))
+	}
+	if balancer.MaxSizeToMovePerIteration != nil {
+		command = append(command, "-s",
+			fmt.Sprintf("%d", balancer.MaxSizeToMovePerIteration.ScaledValue(resource.Giga)))
+	}
+	return command
+}
+
+// reconcileBalancer starts or stops the SCM Container Balancer so its run state matches
+// spec.balancer and the optional schedule window, and mirrors the state into status.
+func (r *OzoneClusterReconciler) reconcileBalancer(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Balancer == nil || r.Executor == nil {
+		return ctrl.Result{}, nil
+	}
+
+	balancer := cluster.Spec.Balancer
+	desired := balancer.Enabled
+	var requeue time.Duration
+	if desired && balancer.Schedule != nil {
+		inWindow, err := inBalancerSchedule(balancer.Schedule, time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		desired = inWindow
+		requeue = balancerScheduleRequeue
+	}
+
+	running := cluster.Status.Balancer != nil && cluster.Status.Balancer.Running
+	if desired == running {
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+
+	command := []string{"ozone", "admin", "containerbalancer", "stop"}
+	if desired {
+		command = balancerStartCommand(balancer)
+	}
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent, command...); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	cluster.Status.Balancer = &ozonev1alpha1.BalancerStatus{Running: desired, LastTransitionTime: &now}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestInBalancerSchedule(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		name     string
+		schedule ozonev1alpha1.BalancerSchedule
+		now      time.Time
+		expected bool
+	}{
+		{"inside window", ozonev1alpha1.BalancerSchedule{Start: "08:00", End: "17:00"}, at(12, 0), true},
+		{"before window", ozonev1alpha1.BalancerSchedule{Start: "08:00", End: "17:00"}, at(7, 59), false},
+		{"after window", ozonev1alpha1.BalancerSchedule{Start: "08:00", End: "17:00"}, at(17, 0), false},
+		{"wrapping inside late", ozonev1alpha1.BalancerSchedule{Start: "22:00", End: "06:00"}, at(23, 30), true},
+		{"wrapping inside early", ozonev1alpha1.BalancerSchedule{Start: "22:00", End: "06:00"}, at(5, 59), true},
+		{"wrapping outside", ozonev1alpha1.BalancerSchedule{Start: "22:00", End: "06:00"}, at(12, 0), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inWindow, err := inBalancerSchedule(&tc.schedule, tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inWindow != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, inWindow)
+			}
+		})
+	}
+
+	if _, err := inBalancerSchedule(&ozonev1alpha1.BalancerSchedule{Start: "25:00", End: "06:00"},
+		at(12, 0)); err == nil {
+		t.Error("expected error for invalid start time")
+	}
+}
+
+func TestBalancerStartCommand(t *testing.T) {
+	threshold := int32(10)
+	maxDatanodes := int32(40)
+	maxSize := resource.MustParse("50G")
+	balancer := &ozonev1alpha1.BalancerSpec{
+		Enabled:                   true,
+		Threshold:                 &threshold,
+		MaxDatanodesPercentage:    &maxDatanodes,
+		MaxSizeToMovePerIteration: &maxSize,
+	}
+	expected := []string{"ozone", "admin", "containerbalancer", "start", "-t", "10", "-d", "40", "-s", "50"}
+	if command := balancerStartCommand(balancer); !reflect.DeepEqual(command, expected) {
+		t.Errorf("expected %v, got %v", expected, command)
+	}
+
+	minimal := balancerStartCommand(&ozonev1alpha1.BalancerSpec{Enabled: true})
+	expected = []string{"ozone", "admin", "containerbalancer", "start"}
+	if !reflect.DeepEqual(minimal, expected) {
+		t.Errorf("expected %v, got %v", expected, minimal)
+	}
+}
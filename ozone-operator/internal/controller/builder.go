@@ -0,0 +1,571 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// Component names; also used as the component label value and resource name suffix.
+const (
+	scmComponent      = "scm"
+	omComponent       = "om"
+	datanodeComponent = "datanode"
+	reconComponent    = "recon"
+	s3gComponent      = "s3g"
+	httpfsComponent   = "httpfs"
+	kmsComponent      = "kms"
+)
+
+// Fixed Ozone service ports, matching ozone-default.xml and the shipped k8s examples.
+const (
+	scmClientPort     = 9860
+	scmDatanodePort   = 9861
+	scmBlockPort      = 9863
+	scmHTTPPort       = 9876
+	omRPCPort         = 9862
+	omHTTPPort        = 9874
+	datanodeRPCPort   = 9870
+	datanodeHTTPPort  = 9882
+	datanodeRatisPort = 9858
+	reconHTTPPort     = 9888
+	reconRPCPort      = 9891
+	s3gHTTPPort       = 9878
+	httpfsHTTPPort    = 14000
+	kmsHTTPPort       = 9600
+)
+
+const (
+	// dataMountPath is where every component mounts its metadata volume,
+	// matching ozone.metadata.dirs in the generated configuration.
+	dataMountPath = "/data"
+
+	// hadoopUserGroup is the uid/gid of the hadoop user inside the Ozone image.
+	hadoopUserGroup = int64(1000)
+)
+
+// componentName returns the name of a child resource of the given cluster component.
+func componentName(cluster *ozonev1alpha1.OzoneCluster, component string) string {
+	return cluster.Name + "-" + component
+}
+
+// componentLabels returns the selector labels of one component of one cluster. The plain app and
+// component labels match the shipped k8s example manifests; the cluster label scopes selectors when
+// several OzoneClusters share a namespace.
+func componentLabels(cluster *ozonev1alpha1.OzoneCluster, component string) map[string]string {
+	return map[string]string{
+		"app":                      "ozone",
+		"component":                component,
+		"ozone.apache.org/cluster": cluster.Name,
+	}
+}
+
+// replicasOrDefault dereferences an optional replica count.
+func replicasOrDefault(replicas *int32, def int32) *int32 {
+	if replicas == nil {
+		return &def
+	}
+	return replicas
+}
+
+// suspendedReplicas overrides the replica count with zero while a component is suspended, so the
+// workload scales down without its Services or PVCs being touched.
+func suspendedReplicas(suspended bool, replicas *int32) *int32 {
+	if suspended {
+		zero := int32(0)
+		return &zero
+	}
+	return replicas
+}
+
+// podDNSPolicy resolves the effective DNS policy of a component pod. Pods on the host network
+// default to ClusterFirstWithHostNet so they can still resolve the cluster-internal service names
+// the generated ozone-site.xml points at.
+func podDNSPolicy(spec *ozonev1alpha1.ComponentSpec) corev1.DNSPolicy {
+	if spec.DNSPolicy != "" {
+		return spec.DNSPolicy
+	}
+	if spec.HostNetwork {
+		return corev1.DNSClusterFirstWithHostNet
+	}
+	return corev1.DNSClusterFirst
+}
+
+// applyComponentSpec copies the shared per-component pod settings onto a generated pod spec.
+func applyComponentSpec(pod *corev1.PodSpec, spec *ozonev1alpha1.ComponentSpec) {
+	pod.NodeSelector = spec.NodeSelector
+	pod.Tolerations = spec.Tolerations
+	pod.HostNetwork = spec.HostNetwork
+	pod.DNSPolicy = podDNSPolicy(spec)
+	if spec.TerminationGracePeriodSeconds != nil {
+		pod.TerminationGracePeriodSeconds = spec.TerminationGracePeriodSeconds
+	}
+	for i := range pod.Containers {
+		if spec.JVMOptions != "" {
+			pod.Containers[i].Env = append(pod.Containers[i].Env,
+				corev1.EnvVar{Name: "OZONE_OPTS", Value: spec.JVMOptions})
+		}
+		pod.Containers[i].Env = append(pod.Containers[i].Env, spec.Env...)
+		pod.Containers[i].Resources = spec.Resources
+	}
+}
+
+// waitForInitContainers returns the init containers that block until the given service port
+// accepts connections, used to sequence component startup (datanodes and OM wait for SCM, and so
+// on). The gate passes as soon as any of the hosts is reachable, so HA deployments start once one
+// SCM or OM replica is up. spec.startupGates can disable the gates, bound them with a timeout, or
+// swap in a dedicated gate image carrying the standalone /wait binary built from cmd/wait.
+func waitForInitContainers(cluster *ozonev1alpha1.OzoneCluster, target string, port int32,
+	hosts ...string) []corev1.Container {
+	gates := cluster.Spec.StartupGates
+	if gates != nil && gates.Disabled {
+		return nil
+	}
+	targets := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		targets = append(targets, fmt.Sprintf("%s:%d", host, port))
+	}
+	if gates != nil && gates.Image != "" {
+		args := make([]string, 0, len(targets)+2)
+		if gates.Timeout != nil {
+			args = append(args, "-timeout", gates.Timeout.Duration.String())
+		}
+		args = append(args, targets...)
+		return []corev1.Container{{
+			Name:    "wait-for-" + target,
+			Image:   gates.Image,
+			Command: []string{"/wait"},
+			Args:    args,
+		}}
+	}
+	// Fallback in the Ozone image: a bash /dev/tcp probe, so the gate works without nc.
+	var timeoutCheck string
+	if gates != nil && gates.Timeout != nil {
+		timeoutCheck = fmt.Sprintf(`if [ "$SECONDS" -ge %d ]; then echo "timed out waiting for %s"; exit 1; fi; `,
+			int(gates.Timeout.Duration.Seconds()), target)
+	}
+	script := fmt.Sprintf(`while true; do for t in %s; do `+
+		`if (exec 3<>"/dev/tcp/${t%%:*}/${t##*:}") 2>/dev/null; then exit 0; fi; done; `+
+		`%secho "waiting for %s"; sleep 2; done`,
+		strings.Join(targets, " "), timeoutCheck, target)
+	return []corev1.Container{{
+		Name:    "wait-for-" + target,
+		Image:   clusterImage(cluster),
+		Command: []string{"bash", "-c", script},
+	}}
+}
+
+// leadershipTransferPreStop returns a preStop hook handing off Ratis leadership of an HA service
+// (om or scm) to a random peer before the pod stops, so rolling restarts do not leave clients
+// waiting for a leader election timeout. Best-effort: the hook must not block pod deletion when
+// the node is a follower or the quorum is already down.
+func leadershipTransferPreStop(service string) *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sh", "-c",
+				fmt.Sprintf("ozone admin %s transfer -r || true", service)}},
+		},
+	}
+}
+
+// Default startup probe windows. Metadata services opening a large RocksDB instance can take
+// minutes to boot, during which an unguarded liveness probe would restart the pod repeatedly.
+const (
+	metadataStartupWindow = 10 * time.Minute
+	gatewayStartupWindow  = 2 * time.Minute
+	probePeriodSeconds    = 10
+)
+
+// startupWindow returns the configured startup probe window of a component, or the default.
+func startupWindow(spec *ozonev1alpha1.ComponentSpec, def time.Duration) time.Duration {
+	if spec.StartupTimeout != nil {
+		return spec.StartupTimeout.Duration
+	}
+	return def
+}
+
+// startupProbe returns a startup probe whose failure threshold covers the given boot window.
+func startupProbe(handler corev1.ProbeHandler, window time.Duration) *corev1.Probe {
+	failures := int32(window / (probePeriodSeconds * time.Second))
+	if failures < 1 {
+		failures = 1
+	}
+	return &corev1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    probePeriodSeconds,
+		FailureThreshold: failures,
+	}
+}
+
+// livenessProbe returns the tight liveness probe that takes over once the startup probe passed.
+func livenessProbe(handler corev1.ProbeHandler) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    probePeriodSeconds,
+		FailureThreshold: 3,
+	}
+}
+
+// Exec probe timings. Each admin check forks a JVM inside the container, so these probes run on a
+// slower period than the TCP and gRPC probes.
+const (
+	adminProbePeriodSeconds  = 30
+	adminProbeTimeoutSeconds = 20
+)
+
+// adminCheckProbe returns a liveness probe running a lightweight `ozone admin <service> roles`
+// round trip inside the container. Unlike a TCP probe it exercises the RPC layer, so a wedged
+// daemon whose port still accepts connections is restarted instead of reported healthy.
+func adminCheckProbe(service string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"ozone", "admin", service, "roles"}},
+		},
+		PeriodSeconds:    adminProbePeriodSeconds,
+		TimeoutSeconds:   adminProbeTimeoutSeconds,
+		FailureThreshold: 3,
+	}
+}
+
+// pvcTemplate returns the volumeClaimTemplate of one persistent component volume.
+func pvcTemplate(name string, size corev1.ResourceList, storageClass *string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: storageClass,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: size,
+			},
+		},
+	}
+}
+
+// defaultStorageSize is the claim size used when a component does not configure storage,
+// matching the shipped k8s examples.
+func defaultStorageSize() resource.Quantity {
+	return resource.MustParse("20Gi")
+}
+
+// storagePVCTemplate maps a StorageSpec onto the standard single "data" claim of SCM, OM and Recon.
+func storagePVCTemplate(storage *ozonev1alpha1.StorageSpec) corev1.PersistentVolumeClaim {
+	size := storage.Size
+	if size.IsZero() {
+		size = defaultStorageSize()
+	}
+	return pvcTemplate("data", corev1.ResourceList{corev1.ResourceStorage: size}, storage.StorageClass)
+}
+
+// emptyDirDataVolume returns the emptyDir backing a component whose storage is ephemeral.
+func emptyDirDataVolume(name string, size resource.Quantity) corev1.Volume {
+	src := &corev1.EmptyDirVolumeSource{}
+	if !size.IsZero() {
+		src.SizeLimit = &size
+	}
+	return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: src}}
+}
+
+// applyComponentStorage backs the "data" mount of a StatefulSet with either a volumeClaimTemplate
+// or, for ephemeral storage, an emptyDir limited to the configured size.
+func applyComponentStorage(sts *appsv1.StatefulSet, storage *ozonev1alpha1.StorageSpec) {
+	if storage.Ephemeral {
+		sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes,
+			emptyDirDataVolume("data", storage.Size))
+		return
+	}
+	sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{storagePVCTemplate(storage)}
+}
+
+// hasEphemeralStorage reports whether any component of the cluster runs on emptyDir storage.
+func hasEphemeralStorage(cluster *ozonev1alpha1.OzoneCluster) bool {
+	if cluster.Spec.SCM.Storage.Ephemeral || cluster.Spec.OM.Storage.Ephemeral {
+		return true
+	}
+	if cluster.Spec.Recon != nil && cluster.Spec.Recon.Storage.Ephemeral {
+		return true
+	}
+	for i := range cluster.Spec.Datanode.Volumes {
+		if cluster.Spec.Datanode.Volumes[i].Ephemeral {
+			return true
+		}
+	}
+	return false
+}
+
+// applyArchAffinity restricts pod scheduling to the node architectures the configured images
+// support, so a single-arch image never lands on an incompatible node.
+func applyArchAffinity(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	archs := cluster.Spec.ImageArchitectures
+	if len(archs) == 0 {
+		return
+	}
+	if pod.Affinity == nil {
+		pod.Affinity = &corev1.Affinity{}
+	}
+	pod.Affinity.NodeAffinity = &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "kubernetes.io/arch",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   archs,
+				}},
+			}},
+		},
+	}
+}
+
+// componentAntiAffinity builds the pod anti-affinity spreading a component across nodes for the
+// requested mode; nil when the mode is empty, leaving the component without anti-affinity.
+func componentAntiAffinity(cluster *ozonev1alpha1.OzoneCluster, component string,
+	mode ozonev1alpha1.AntiAffinityMode) *corev1.Affinity {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: componentLabels(cluster, component)},
+		TopologyKey:   "kubernetes.io/hostname",
+	}
+	switch mode {
+	case ozonev1alpha1.AntiAffinityRequired:
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		}
+	case ozonev1alpha1.AntiAffinityPreferred:
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight:          100,
+					PodAffinityTerm: term,
+				}},
+			},
+		}
+	}
+	return nil
+}
+
+// headlessService returns the per-component headless service giving pods stable DNS names.
+func headlessService(cluster *ozonev1alpha1.OzoneCluster, component string, ports []corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentName(cluster, component),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, component),
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  componentLabels(cluster, component),
+			Ports:     ports,
+		},
+	}
+}
+
+// perPodService builds a ClusterIP Service selecting exactly one StatefulSet pod, named like the
+// pod, so clients that cannot resolve pod DNS reach individual replicas through stable addresses.
+func perPodService(cluster *ozonev1alpha1.OzoneCluster, component string, ordinal int32,
+	ports []corev1.ServicePort) *corev1.Service {
+	name := fmt.Sprintf("%s-%d", componentName(cluster, component), ordinal)
+	selector := componentLabels(cluster, component)
+	selector["statefulset.kubernetes.io/pod-name"] = name
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, component),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+// perPodServices builds the per-pod Services of the SCM and OM replicas.
+func perPodServices(cluster *ozonev1alpha1.OzoneCluster) []*corev1.Service {
+	var services []*corev1.Service
+	for ordinal := int32(0); ordinal < *replicasOrDefault(cluster.Spec.SCM.Replicas, 1); ordinal++ {
+		services = append(services,
+			redirectUIPort(cluster, scmComponent, perPodService(cluster, scmComponent, ordinal, scmServicePorts())))
+	}
+	for ordinal := int32(0); ordinal < *replicasOrDefault(cluster.Spec.OM.Replicas, 1); ordinal++ {
+		services = append(services,
+			redirectUIPort(cluster, omComponent, perPodService(cluster, omComponent, ordinal, omServicePorts())))
+	}
+	return services
+}
+
+// podFQDN returns the in-cluster DNS name of one pod of a component, e.g. cluster-scm-0.cluster-scm.
+func podFQDN(cluster *ozonev1alpha1.OzoneCluster, component string, ordinal int32) string {
+	name := componentName(cluster, component)
+	return fmt.Sprintf("%s-%d.%s", name, ordinal, name)
+}
+
+// prometheusAnnotations returns the scrape annotations used by all Ozone web endpoints.
+func prometheusAnnotations(cluster *ozonev1alpha1.OzoneCluster, httpPort int32) map[string]string {
+	annotations := map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   fmt.Sprintf("%d", httpPort),
+		"prometheus.io/path":   "/prom",
+	}
+	if metricsScheme(cluster) == "https" {
+		annotations["prometheus.io/scheme"] = "https"
+	}
+	return annotations
+}
+
+// secretKeyRef returns an env value sourced from one key of a Secret.
+func secretKeyRef(secret, key string) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+			Key:                  key,
+		},
+	}
+}
+
+// withConfigHash adds the restart-requiring config hash to the pod template annotations, so pods
+// roll when such properties change and stale pods are detectable after partial rollouts.
+func withConfigHash(cluster *ozonev1alpha1.OzoneCluster, annotations map[string]string) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[configHashAnnotation] = configHash(cluster)
+	return annotations
+}
+
+// baseStatefulSet assembles the pieces shared by every component StatefulSet.
+func baseStatefulSet(cluster *ozonev1alpha1.OzoneCluster, component string, replicas *int32,
+	pod corev1.PodSpec, annotations map[string]string) *appsv1.StatefulSet {
+	labels := componentLabels(cluster, component)
+	fsGroup := hadoopUserGroup
+	pod.SecurityContext = &corev1.PodSecurityContext{FSGroup: &fsGroup}
+	applyExtraConfig(cluster, &pod)
+	applyMetricsSecurity(cluster, &pod)
+	applyHardening(cluster, &pod)
+	applyArchAffinity(cluster, &pod)
+	applyProfiling(cluster, component, &pod)
+	annotations = withConfigHash(cluster, annotations)
+	annotations = withProfilingAnnotations(cluster, component, annotations)
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentName(cluster, component),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector:    &metav1.LabelSelector{MatchLabels: labels},
+			ServiceName: componentName(cluster, component),
+			Replicas:    replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: pod,
+			},
+		},
+	}
+}
+
+// extraConfigMountPath is where spec.extraConfigFiles are mounted in every component container.
+const extraConfigMountPath = "/opt/hadoop/etc/extra"
+
+// applyExtraConfig mounts the extra configuration ConfigMap into every container of a component
+// pod when spec.extraConfigFiles is set.
+func applyExtraConfig(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	if len(cluster.Spec.ExtraConfigFiles) == 0 {
+		return
+	}
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "extra-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: extraConfigMapName(cluster)},
+			},
+		},
+	})
+	for i := range pod.Containers {
+		pod.Containers[i].VolumeMounts = append(pod.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      "extra-config",
+			MountPath: extraConfigMountPath,
+		})
+	}
+}
+
+// applyUpdateStrategy copies the configured StatefulSet update strategy onto a generated
+// StatefulSet; without configuration the StatefulSet default (RollingUpdate) applies.
+func applyUpdateStrategy(sts *appsv1.StatefulSet, spec *ozonev1alpha1.ComponentSpec) {
+	strategy := spec.UpdateStrategy
+	if strategy == nil {
+		return
+	}
+	if strategy.Type == string(appsv1.OnDeleteStatefulSetStrategyType) {
+		sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+		return
+	}
+	sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+	}
+	if strategy.Partition != nil {
+		sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: strategy.Partition,
+		}
+	}
+}
+
+// baseDeployment assembles the pieces shared by stateless component Deployments.
+func baseDeployment(cluster *ozonev1alpha1.OzoneCluster, component string, replicas *int32,
+	pod corev1.PodSpec, annotations map[string]string) *appsv1.Deployment {
+	labels := componentLabels(cluster, component)
+	fsGroup := hadoopUserGroup
+	pod.SecurityContext = &corev1.PodSecurityContext{FSGroup: &fsGroup}
+	applyExtraConfig(cluster, &pod)
+	applyMetricsSecurity(cluster, &pod)
+	applyHardening(cluster, &pod)
+	applyArchAffinity(cluster, &pod)
+	applyProfiling(cluster, component, &pod)
+	annotations = withConfigHash(cluster, annotations)
+	annotations = withProfilingAnnotations(cluster, component, annotations)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentName(cluster, component),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Replicas: replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: pod,
+			},
+		},
+	}
+}
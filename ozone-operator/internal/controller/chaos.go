@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Chaos faults injected through the --chaos-faults flag. They exercise the recovery and health
+// subsystems in e2e tests without a real broken Ozone cluster and must never be enabled in
+// production.
+const (
+	// ChaosExecError fails every `ozone admin` exec, as if the component daemons were down.
+	ChaosExecError = "exec-error"
+
+	// ChaosSafemode answers safemode status probes with "in safe mode", simulating an SCM that
+	// never leaves safemode after a cold start.
+	ChaosSafemode = "safemode"
+
+	// ChaosAPIError fails every third API write with a server timeout, simulating a flaky API
+	// server; the errors are transient, so reconciles must recover through their normal backoff.
+	ChaosAPIError = "api-error"
+
+	// ChaosSlowRollout delays every API write, stretching rollouts enough for e2e tests to
+	// observe intermediate states.
+	ChaosSlowRollout = "slow-rollout"
+)
+
+// chaosWriteDelay is how long ChaosSlowRollout stalls each API write.
+const chaosWriteDelay = 2 * time.Second
+
+// chaosAPIErrorEvery makes ChaosAPIError fail every Nth write.
+const chaosAPIErrorEvery = 3
+
+// ParseChaosFaults parses the comma-separated fault list of the --chaos-faults flag.
+func ParseChaosFaults(list string) (map[string]bool, error) {
+	faults := map[string]bool{}
+	for _, fault := range strings.Split(list, ",") {
+		switch fault = strings.TrimSpace(fault); fault {
+		case ChaosExecError, ChaosSafemode, ChaosAPIError, ChaosSlowRollout:
+			faults[fault] = true
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown chaos fault %q, known faults: %s, %s, %s, %s",
+				fault, ChaosExecError, ChaosSafemode, ChaosAPIError, ChaosSlowRollout)
+		}
+	}
+	return faults, nil
+}
+
+// chaosExecutor injects component failures into `ozone admin` execs.
+type chaosExecutor struct {
+	delegate PodExecutor
+	faults   map[string]bool
+}
+
+// NewChaosExecutor wraps a PodExecutor with the configured fault injections.
+func NewChaosExecutor(delegate PodExecutor, faults map[string]bool) PodExecutor {
+	return &chaosExecutor{delegate: delegate, faults: faults}
+}
+
+// Exec implements PodExecutor with the configured faults applied.
+func (e *chaosExecutor) Exec(ctx context.Context, namespace, pod, container string,
+	command ...string) (string, error) {
+	if e.faults[ChaosExecError] {
+		return "", fmt.Errorf("chaos: injected exec failure in %s/%s", namespace, pod)
+	}
+	joined := strings.Join(command, " ")
+	if e.faults[ChaosSafemode] && strings.Contains(joined, "safemode status") {
+		return "SCM is in safe mode.", nil
+	}
+	return e.delegate.Exec(ctx, namespace, pod, container, command...)
+}
+
+// chaosClient injects API errors and rollout delays into client writes. Reads pass through
+// untouched, so the reconcilers always observe the real cluster state.
+type chaosClient struct {
+	client.Client
+	faults map[string]bool
+
+	mu     sync.Mutex
+	writes int
+}
+
+// NewChaosClient wraps a client with the configured fault injections.
+func NewChaosClient(delegate client.Client, faults map[string]bool) client.Client {
+	return &chaosClient{Client: delegate, faults: faults}
+}
+
+// beforeWrite applies the write faults; a non-nil error replaces the write.
+func (c *chaosClient) beforeWrite() error {
+	if c.faults[ChaosSlowRollout] {
+		time.Sleep(chaosWriteDelay)
+	}
+	if !c.faults[ChaosAPIError] {
+		return nil
+	}
+	c.mu.Lock()
+	c.writes++
+	inject := c.writes%chaosAPIErrorEvery == 0
+	c.mu.Unlock()
+	if inject {
+		return errors.NewServerTimeout(schema.GroupResource{}, "chaos-injected write", 1)
+	}
+	return nil
+}
+
+// Create implements client.Client with the configured faults applied.
+func (c *chaosClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.beforeWrite(); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Update implements client.Client with the configured faults applied.
+func (c *chaosClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.beforeWrite(); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.Client with the configured faults applied.
+func (c *chaosClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.PatchOption) error {
+	if err := c.beforeWrite(); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete implements client.Client with the configured faults applied.
+func (c *chaosClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.beforeWrite(); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
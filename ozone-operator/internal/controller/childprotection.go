@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// breakGlassAnnotation marks an operator-owned child resource as open for manual edits and
+// deletes. Set it to "true" before a manual intervention and remove it afterwards; the next
+// OzoneCluster reconcile overwrites the manual change anyway, so the annotation only suppresses
+// the admission rejection, not the repair.
+const breakGlassAnnotation = "ozone.apache.org/break-glass"
+
+// ChildProtectionWebhook rejects manual updates and deletes of StatefulSets and ConfigMaps owned
+// by an OzoneCluster. The operator rebuilds those resources from the spec on every reconcile, so
+// a manual edit is silently lost at best and drifts the cluster until the next reconcile at
+// worst; rejecting it at admission points the caller at the OzoneCluster spec instead. Requests
+// from the operator itself, from kube-system controllers (garbage collection on cluster
+// deletion), and on objects carrying the break-glass annotation pass through. Registered only
+// with --protect-child-resources because it intercepts all StatefulSets and ConfigMaps in the
+// cluster, even though it ignores unowned ones.
+type ChildProtectionWebhook struct {
+	// OperatorUser is the username of the operator's own ServiceAccount, whose writes are the
+	// reconcile loop and must never be rejected.
+	OperatorUser string
+}
+
+// +kubebuilder:webhook:path=/validate-apps-v1-statefulset,mutating=false,failurePolicy=Ignore,sideEffects=None,groups=apps,resources=statefulsets,verbs=update;delete,versions=v1,name=vozonechildsts.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate--v1-configmap,mutating=false,failurePolicy=Ignore,sideEffects=None,groups="",resources=configmaps,verbs=update;delete,versions=v1,name=vozonechildcm.kb.io,admissionReviewVersions=v1
+
+var _ admission.CustomValidator = &ChildProtectionWebhook{}
+
+// SetupWithManager registers the protection webhook for both guarded child types.
+func (w *ChildProtectionWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&appsv1.StatefulSet{}).WithValidator(w).Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.ConfigMap{}).WithValidator(w).Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator. Creates are never rejected: the only
+// writer creating resources with an OzoneCluster controller reference is the operator.
+func (w *ChildProtectionWebhook) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *ChildProtectionWebhook) ValidateUpdate(ctx context.Context,
+	oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, oldObj, newObj, "edit")
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *ChildProtectionWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, obj, obj, "delete")
+}
+
+// validate rejects the request when the old object is operator-owned and neither the requester
+// nor the break-glass annotation exempts it. The annotation is honored on either side of an
+// update so an edit that sets it together with the change is not rejected for ordering reasons.
+func (w *ChildProtectionWebhook) validate(ctx context.Context,
+	oldObj, newObj runtime.Object, verb string) (admission.Warnings, error) {
+	oldChild, ok := oldObj.(client.Object)
+	if !ok || !ownedByOzoneCluster(oldChild) {
+		return nil, nil
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		if req.UserInfo.Username == w.OperatorUser ||
+			strings.HasPrefix(req.UserInfo.Username, "system:serviceaccount:kube-system:") {
+			return nil, nil
+		}
+	}
+	newChild, _ := newObj.(client.Object)
+	if breakGlassActive(oldChild) || (newChild != nil && breakGlassActive(newChild)) {
+		return admission.Warnings{fmt.Sprintf(
+			"%s is operator-managed; the manual %s is admitted via the %s annotation but the next "+
+				"reconcile rebuilds it from the OzoneCluster spec", oldChild.GetName(), verb, breakGlassAnnotation)}, nil
+	}
+	return nil, fmt.Errorf("%s %s is managed by OzoneCluster %q and a manual %s would only drift it: "+
+		"change the OzoneCluster spec instead, or annotate the object with %s=true for a break-glass intervention",
+		oldObj.GetObjectKind().GroupVersionKind().Kind, oldChild.GetName(),
+		oldChild.GetLabels()["ozone.apache.org/cluster"], verb, breakGlassAnnotation)
+}
+
+// ownedByOzoneCluster reports whether the object has an OzoneCluster controller reference.
+func ownedByOzoneCluster(obj client.Object) bool {
+	ref := metav1.GetControllerOf(obj)
+	return ref != nil && ref.Kind == "OzoneCluster" && strings.HasPrefix(ref.APIVersion, "ozone.apache.org/")
+}
+
+// breakGlassActive reports whether the break-glass annotation opens the object for manual writes.
+func breakGlassActive(obj client.Object) bool {
+	return obj.GetAnnotations()[breakGlassAnnotation] == "true"
+}
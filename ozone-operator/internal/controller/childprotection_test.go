@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// protectedConfigMap builds a ConfigMap carrying an OzoneCluster controller reference.
+func protectedConfigMap(annotations map[string]string) *corev1.ConfigMap {
+	controlled := true
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:        "cluster-config",
+		Annotations: annotations,
+		Labels:      map[string]string{"ozone.apache.org/cluster": "cluster"},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "ozone.apache.org/v1alpha1",
+			Kind:       "OzoneCluster",
+			Name:       "cluster",
+			Controller: &controlled,
+		}},
+	}}
+}
+
+func TestChildProtectionValidate(t *testing.T) {
+	webhook := &ChildProtectionWebhook{OperatorUser: "system:serviceaccount:ozone-operator-system:manager"}
+	breakGlass := map[string]string{breakGlassAnnotation: "true"}
+	tests := []struct {
+		name     string
+		old      *corev1.ConfigMap
+		new      *corev1.ConfigMap
+		wantErr  bool
+		wantWarn bool
+	}{
+		{
+			name:    "unowned configmap is ignored",
+			old:     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "user-config"}},
+			new:     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "user-config"}},
+			wantErr: false,
+		},
+		{
+			name:    "manual edit of an owned configmap is rejected",
+			old:     protectedConfigMap(nil),
+			new:     protectedConfigMap(nil),
+			wantErr: true,
+		},
+		{
+			name:     "break-glass annotation admits the edit with a warning",
+			old:      protectedConfigMap(nil),
+			new:      protectedConfigMap(breakGlass),
+			wantErr:  false,
+			wantWarn: true,
+		},
+		{
+			name:     "break-glass annotation on the existing object admits its deletion",
+			old:      protectedConfigMap(breakGlass),
+			new:      protectedConfigMap(breakGlass),
+			wantErr:  false,
+			wantWarn: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := webhook.ValidateUpdate(context.Background(), tt.old, tt.new)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (len(warnings) > 0) != tt.wantWarn {
+				t.Errorf("ValidateUpdate() warnings = %v, wantWarn %v", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// clientConfigMapName returns the name of the published client configuration bundle.
+func clientConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-client-config"
+}
+
+// externalPodFQDN returns the namespace-qualified DNS name of a component pod, usable by client
+// workloads in other namespaces.
+func externalPodFQDN(cluster *ozonev1alpha1.OzoneCluster, component string, ordinal int32) string {
+	name := componentName(cluster, component)
+	return fmt.Sprintf("%s-%d.%s.%s.svc", name, ordinal, name, cluster.Namespace)
+}
+
+// renderHadoopXML renders properties as a Hadoop configuration file with a stable key order.
+func renderHadoopXML(props map[string]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<configuration>\n")
+	for _, key := range keys {
+		var value bytes.Buffer
+		// Property values may contain XML metacharacters (e.g. log patterns).
+		_ = xml.EscapeText(&value, []byte(props[key]))
+		buf.WriteString(fmt.Sprintf("  <property>\n    <name>%s</name>\n    <value>%s</value>\n  </property>\n",
+			key, value.String()))
+	}
+	buf.WriteString("</configuration>\n")
+	return buf.String()
+}
+
+// clientSCMAddress returns the SCM address clients outside the cluster namespace should use.
+func clientSCMAddress(cluster *ozonev1alpha1.OzoneCluster) string {
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		return ecp.SCMAddresses[0]
+	}
+	return externalPodFQDN(cluster, scmComponent, 0)
+}
+
+// clientOMAddress returns the OM address clients outside the cluster namespace should use.
+func clientOMAddress(cluster *ozonev1alpha1.OzoneCluster) string {
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		return ecp.OMAddress
+	}
+	return externalPodFQDN(cluster, omComponent, 0)
+}
+
+// clientOzoneSiteProperties narrows the cluster configuration to the endpoints external clients
+// need, using namespace-qualified names so the bundle works from any namespace.
+func clientOzoneSiteProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	scmAddress := clientSCMAddress(cluster)
+	props := map[string]string{
+		"ozone.scm.names":                scmAddress,
+		"ozone.scm.client.address":       scmAddress,
+		"ozone.scm.block.client.address": scmAddress,
+		"ozone.om.address":               clientOMAddress(cluster),
+	}
+	if cluster.Spec.Recon != nil {
+		props["ozone.recon.address"] = externalPodFQDN(cluster, reconComponent, 0)
+	}
+	return props
+}
+
+// clientConfigMap publishes the configuration bundle (ozone-site.xml and core-site.xml) that
+// Spark, Hive or Flink pods in other namespaces mount to talk to this cluster. It is reconciled
+// with the cluster, so endpoint changes propagate automatically.
+func clientConfigMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	coreSite := map[string]string{
+		"fs.defaultFS": "ofs://" + clientOMAddress(cluster) + "/",
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clientConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "client-config"),
+		},
+		Data: map[string]string{
+			"ozone-site.xml": renderHadoopXML(clientOzoneSiteProperties(cluster)),
+			"core-site.xml":  renderHadoopXML(coreSite),
+		},
+	}
+}
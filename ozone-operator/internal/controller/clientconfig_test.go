@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHadoopXML(t *testing.T) {
+	rendered := renderHadoopXML(map[string]string{
+		"ozone.om.address": "om-0.om",
+		"a.pattern":        "x < y & z",
+	})
+	// Keys are sorted, so a.pattern comes first and values are XML-escaped.
+	wantOrder := strings.Index(rendered, "a.pattern") < strings.Index(rendered, "ozone.om.address")
+	if !wantOrder {
+		t.Errorf("expected sorted property order, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "x &lt; y &amp; z") {
+		t.Errorf("expected escaped value, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "<value>om-0.om</value>") {
+		t.Errorf("expected om address property, got:\n%s", rendered)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// compactionScheduleRequeue is how often the compaction window is re-evaluated.
+const compactionScheduleRequeue = time.Minute
+
+// compactionHistoryLimit caps the run history kept in status.compactions.
+const compactionHistoryLimit = 5
+
+// compactionDue reports whether a compaction should be triggered now: inside the daily window and
+// not yet run in the current window occurrence.
+func compactionDue(compaction *ozonev1alpha1.CompactionSpec, lastRun *metav1.Time,
+	now time.Time) (bool, error) {
+	inWindow, err := inDailyWindow(compaction.Start, compaction.End, now)
+	if err != nil || !inWindow {
+		return false, err
+	}
+	if lastRun == nil {
+		return true, nil
+	}
+	start, err := time.Parse("15:04", compaction.Start)
+	if err != nil {
+		return false, err
+	}
+	windowStart := time.Date(now.Year(), now.Month(), now.Day(),
+		start.Hour(), start.Minute(), 0, 0, now.Location())
+	if windowStart.After(now) {
+		// The window wrapped midnight; its start was yesterday.
+		windowStart = windowStart.AddDate(0, 0, -1)
+	}
+	return lastRun.Time.Before(windowStart), nil
+}
+
+// reconcileOMCompaction triggers `ozone admin om compact` on every OM replica once per occurrence
+// of the spec.om.compaction window and records the run in status.compactions, so compaction debt
+// is worked off during off-peak hours instead of surfacing as latency spikes.
+func (r *OzoneClusterReconciler) reconcileOMCompaction(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	compaction := cluster.Spec.OM.Compaction
+	if compaction == nil || r.Executor == nil || cluster.Spec.ExternalControlPlane != nil {
+		return ctrl.Result{}, nil
+	}
+	var lastRun *metav1.Time
+	if runs := cluster.Status.Compactions; len(runs) > 0 {
+		lastRun = &runs[len(runs)-1].Time
+	}
+	due, err := compactionDue(compaction, lastRun, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !due {
+		return ctrl.Result{RequeueAfter: compactionScheduleRequeue}, nil
+	}
+
+	commands := [][]string{{"ozone", "admin", "om", "compact"}}
+	if len(compaction.ColumnFamilies) > 0 {
+		commands = nil
+		for _, family := range compaction.ColumnFamilies {
+			commands = append(commands, []string{"ozone", "admin", "om", "compact", "--column-family", family})
+		}
+	}
+	var failures []string
+	for i := int32(0); i < *replicasOrDefault(cluster.Spec.OM.Replicas, 1); i++ {
+		pod := fmt.Sprintf("%s-%d", componentName(cluster, omComponent), i)
+		for _, command := range commands {
+			if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent, command...); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", pod, err))
+			}
+		}
+	}
+	run := ozonev1alpha1.CompactionRun{Time: metav1.Now(), Succeeded: len(failures) == 0}
+	if len(failures) > 0 {
+		run.Message = strings.Join(failures, "; ")
+	}
+	cluster.Status.Compactions = append(cluster.Status.Compactions, run)
+	if extra := len(cluster.Status.Compactions) - compactionHistoryLimit; extra > 0 {
+		cluster.Status.Compactions = cluster.Status.Compactions[extra:]
+	}
+	return ctrl.Result{RequeueAfter: compactionScheduleRequeue}, nil
+}
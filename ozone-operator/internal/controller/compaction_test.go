@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestCompactionDue(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	ranAt := func(day, hour, minute int) *metav1.Time {
+		run := metav1.NewTime(at(day, hour, minute))
+		return &run
+	}
+	window := &ozonev1alpha1.CompactionSpec{Start: "01:00", End: "05:00"}
+	wrapped := &ozonev1alpha1.CompactionSpec{Start: "22:00", End: "02:00"}
+	cases := []struct {
+		name    string
+		spec    *ozonev1alpha1.CompactionSpec
+		lastRun *metav1.Time
+		now     time.Time
+		due     bool
+	}{
+		{name: "outside window", spec: window, now: at(2, 12, 0)},
+		{name: "first run in window", spec: window, now: at(2, 2, 0), due: true},
+		{name: "already ran this window", spec: window, lastRun: ranAt(2, 1, 30), now: at(2, 2, 0)},
+		{name: "ran yesterday", spec: window, lastRun: ranAt(1, 1, 30), now: at(2, 2, 0), due: true},
+		{name: "wrapped window after midnight", spec: wrapped, lastRun: ranAt(1, 23, 0), now: at(2, 1, 0)},
+		{name: "wrapped window next night", spec: wrapped, lastRun: ranAt(1, 23, 0), now: at(2, 23, 0), due: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			due, err := compactionDue(tc.spec, tc.lastRun, tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if due != tc.due {
+				t.Errorf("expected due=%v, got %v", tc.due, due)
+			}
+		})
+	}
+
+	if _, err := compactionDue(&ozonev1alpha1.CompactionSpec{Start: "bogus", End: "05:00"}, nil,
+		at(2, 2, 0)); err == nil {
+		t.Error("expected an error for a malformed window start")
+	}
+}
@@ -0,0 +1,325 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ozoneSitePrefix is the env key prefix the Ozone image converts into ozone-site.xml entries.
+const ozoneSitePrefix = "OZONE-SITE.XML_"
+
+// configMapName returns the name of the shared configuration ConfigMap of a cluster.
+func configMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-config"
+}
+
+// scmAddresses returns the SCM host names of the cluster: the external control plane addresses
+// when configured, otherwise every in-cluster SCM pod.
+func scmAddresses(cluster *ozonev1alpha1.OzoneCluster) []string {
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		return ecp.SCMAddresses
+	}
+	replicas := *replicasOrDefault(cluster.Spec.SCM.Replicas, 1)
+	addrs := make([]string, 0, replicas)
+	for i := int32(0); i < replicas; i++ {
+		addrs = append(addrs, podFQDN(cluster, scmComponent, i))
+	}
+	return addrs
+}
+
+// omAddress returns the OM host name: the external control plane address when configured,
+// otherwise the in-cluster OM pod.
+func omAddress(cluster *ozonev1alpha1.OzoneCluster) string {
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		return ecp.OMAddress
+	}
+	return podFQDN(cluster, omComponent, 0)
+}
+
+// omAddresses returns every OM host name, used by startup gates that pass on any reachable OM.
+func omAddresses(cluster *ozonev1alpha1.OzoneCluster) []string {
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		return []string{ecp.OMAddress}
+	}
+	replicas := *replicasOrDefault(cluster.Spec.OM.Replicas, 1)
+	addrs := make([]string, 0, replicas)
+	for i := int32(0); i < replicas; i++ {
+		addrs = append(addrs, podFQDN(cluster, omComponent, i))
+	}
+	return addrs
+}
+
+// ozoneSiteProperties generates the ozone-site.xml properties of a cluster. Entries from
+// spec.config override the generated defaults.
+func ozoneSiteProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	scmAddrs := scmAddresses(cluster)
+	scmAddress := scmAddrs[0]
+	props := map[string]string{
+		"ozone.scm.names":                     strings.Join(scmAddrs, ","),
+		"ozone.scm.client.address":            scmAddress,
+		"ozone.scm.block.client.address":      scmAddress,
+		"ozone.om.address":                    omAddress(cluster),
+		"ozone.metadata.dirs":                 dataMountPath + "/metadata",
+		"ozone.scm.datanode.id.dir":           dataMountPath + "/metadata",
+		"hdds.datanode.dir":                   datanodeDirProperty(cluster),
+		"hdds.datanode.use.datanode.hostname": "true",
+		"ozone.datanode.pipeline.limit":       "1",
+		"hdds.scm.safemode.min.datanode":      fmt.Sprintf("%d", *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3)),
+		"hdds.datanode.volume.min.free.space": "1GB",
+		"ozone.om.http-address":               fmt.Sprintf("0.0.0.0:%d", omHTTPPort),
+		"hdds.scm.http-address":               fmt.Sprintf("0.0.0.0:%d", scmHTTPPort),
+	}
+	for key, val := range replicationProperties(cluster) {
+		props[key] = val
+	}
+	for key, val := range topologyProperties(cluster) {
+		props[key] = val
+	}
+	for key, val := range scmHAProperties(cluster) {
+		props[key] = val
+	}
+	for key, val := range tuningProperties(cluster) {
+		props[key] = val
+	}
+	for key, val := range scannerProperties(cluster) {
+		props[key] = val
+	}
+	for key, val := range securityProperties(cluster) {
+		props[key] = val
+	}
+	if reserved := datanodeReservedProperty(cluster); reserved != "" {
+		props["hdds.datanode.dir.du.reserved"] = reserved
+	}
+	if cluster.Spec.Recon != nil {
+		props["ozone.recon.address"] = podFQDN(cluster, reconComponent, 0)
+	}
+	if s3g := cluster.Spec.S3Gateway; s3g != nil && s3g.DomainName != "" {
+		props["ozone.s3g.domain.name"] = s3g.DomainName
+	}
+	if ecp := cluster.Spec.ExternalControlPlane; ecp != nil {
+		for key, val := range ecp.Config {
+			props[key] = val
+		}
+	}
+	applyVersionProperties(cluster, props)
+	for key, val := range cluster.Spec.Config {
+		props[key] = expandConfigTemplate(cluster, val)
+	}
+	return props
+}
+
+// configHashAnnotation propagates the hash of the restart-requiring configuration onto component
+// pods: changing those properties rolls the pods, while reload-safe tuning changes do not.
+const configHashAnnotation = "ozone.apache.org/config-hash"
+
+// reconfigurableProperties are Ozone properties supporting dynamic reconfiguration through
+// `ozone admin reconfig`; changes limited to them are applied in place instead of rolling pods.
+var reconfigurableProperties = map[string]bool{
+	"ozone.administrators":                           true,
+	"ozone.readonly.administrators":                  true,
+	"ozone.key.deleting.limit.per.task":              true,
+	"ozone.block.deleting.service.interval":          true,
+	"hdds.scm.replication.thread.interval":           true,
+	"hdds.scm.replication.under.replicated.interval": true,
+}
+
+// propertiesHash hashes the selected subset of the generated configuration in key order.
+func propertiesHash(cluster *ozonev1alpha1.OzoneCluster, reloadable bool) string {
+	props := ozoneSiteProperties(cluster)
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		if reconfigurableProperties[key] == reloadable {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, props[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// configHash covers the restart-requiring properties; propagated onto pods as configHashAnnotation.
+func configHash(cluster *ozonev1alpha1.OzoneCluster) string {
+	return propertiesHash(cluster, false)
+}
+
+// reloadableConfigHash covers the dynamically reconfigurable properties.
+func reloadableConfigHash(cluster *ozonev1alpha1.OzoneCluster) string {
+	return propertiesHash(cluster, true)
+}
+
+// configTemplateData is the cluster metadata exposed to Go templates in spec.config values and
+// spec.extraConfigFiles contents.
+type configTemplateData struct {
+	Name         string
+	Namespace    string
+	SCMAddresses []string
+	OMAddress    string
+}
+
+// expandConfigTemplate expands Go template expressions in a user-supplied configuration value
+// with cluster metadata. The admission webhook rejects invalid templates, so a value failing to
+// parse or execute here is passed through literally.
+func expandConfigTemplate(cluster *ozonev1alpha1.OzoneCluster, value string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+	tmpl, err := template.New("config").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return value
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, configTemplateData{
+		Name:         cluster.Name,
+		Namespace:    cluster.Namespace,
+		SCMAddresses: scmAddresses(cluster),
+		OMAddress:    omAddress(cluster),
+	}); err != nil {
+		return value
+	}
+	return buf.String()
+}
+
+// extraConfigMapName returns the name of the ConfigMap carrying spec.extraConfigFiles.
+func extraConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-extra-config"
+}
+
+// extraConfigMap renders spec.extraConfigFiles, expanding template expressions, into the
+// ConfigMap mounted at extraConfigMountPath in every component.
+func extraConfigMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	data := make(map[string]string, len(cluster.Spec.ExtraConfigFiles))
+	for name, content := range cluster.Spec.ExtraConfigFiles {
+		data[name] = expandConfigTemplate(cluster, content)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      extraConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "config"),
+		},
+		Data: data,
+	}
+}
+
+// scmHAProperties enables SCM Ratis replication once more than one SCM is requested. The first
+// pod is the primordial node that formats the cluster; the others bootstrap into its ring.
+func scmHAProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	if cluster.Spec.ExternalControlPlane != nil || *replicasOrDefault(cluster.Spec.SCM.Replicas, 1) <= 1 {
+		return nil
+	}
+	return map[string]string{
+		"ozone.scm.ratis.enable":       "true",
+		"ozone.scm.primordial.node.id": componentName(cluster, scmComponent) + "-0",
+	}
+}
+
+// replicationProperties translates spec.replication into the server default replication
+// properties. The cluster default stays RATIS/THREE when the spec does not override it.
+func replicationProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	props := map[string]string{
+		"ozone.server.default.replication.type": "RATIS",
+		"ozone.server.default.replication":      "3",
+	}
+	rep := cluster.Spec.Replication
+	if rep == nil {
+		return props
+	}
+	if rep.Type == ozonev1alpha1.ReplicationEC {
+		policy := rep.ECPolicy
+		if policy == "" {
+			policy = "rs-6-3-1024k"
+		}
+		props["ozone.server.default.replication.type"] = "EC"
+		props["ozone.server.default.replication"] = policy
+	} else if rep.Factor != "" {
+		props["ozone.server.default.replication"] = rep.Factor
+	}
+	if len(rep.AllowedECPolicies) > 0 {
+		props["ozone.ec.policies.allowed"] = strings.Join(rep.AllowedECPolicies, ",")
+	}
+	return props
+}
+
+// configMap generates the shared configuration ConfigMap consumed by every component through
+// envFrom. Keys use the OZONE-SITE.XML_ convention understood by the Ozone image entrypoint.
+func configMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	data := map[string]string{
+		"LOG4J.PROPERTIES_log4j.rootLogger":                               "INFO, stdout",
+		"LOG4J.PROPERTIES_log4j.appender.stdout":                          "org.apache.log4j.ConsoleAppender",
+		"LOG4J.PROPERTIES_log4j.appender.stdout.layout":                   "org.apache.log4j.PatternLayout",
+		"LOG4J.PROPERTIES_log4j.appender.stdout.layout.ConversionPattern": "%d{yyyy-MM-dd HH:mm:ss} %-5p %c{1}:%L - %m%n",
+	}
+	for key, val := range ozoneSiteProperties(cluster) {
+		data[ozoneSitePrefix+key] = val
+	}
+	if uri := keyProviderURI(cluster); uri != "" {
+		// Hadoop clients and the s3a/ofs connectors read the key provider from core-site, so the
+		// KMS must be configured there as well as in ozone-site.
+		data["CORE-SITE.XML_hadoop.security.key.provider.path"] = uri
+	}
+	if trash := cluster.Spec.Trash; trash != nil {
+		// fs.trash.interval is in minutes; the OM trash emptier and the o3fs/ofs clients both
+		// read it from core-site.
+		data["CORE-SITE.XML_fs.trash.interval"] = fmt.Sprintf("%d", int64(trash.Interval.Duration.Minutes()))
+		if trash.CheckpointInterval != nil {
+			data["CORE-SITE.XML_fs.trash.checkpoint.interval"] =
+				fmt.Sprintf("%d", int64(trash.CheckpointInterval.Duration.Minutes()))
+		}
+	}
+	if cluster.Spec.HttpFS != nil {
+		data["CORE-SITE.XML_fs.defaultFS"] = "ofs://" + omAddress(cluster) + "/"
+		data["CORE-SITE.XML_hadoop.proxyuser.hadoop.hosts"] = "*"
+		data["CORE-SITE.XML_hadoop.proxyuser.hadoop.groups"] = "*"
+		data["HTTPFS-SITE.XML_httpfs.hadoop.config.dir"] = "/opt/hadoop/etc/config"
+		data["HTTPFS-SITE.XML_httpfs.proxyuser.hadoop.hosts"] = "*"
+		data["HTTPFS-SITE.XML_httpfs.proxyuser.hadoop.groups"] = "*"
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "config"),
+		},
+		Data: data,
+	}
+}
+
+// configEnvFrom mounts the shared ConfigMap into a container environment.
+func configEnvFrom(cluster *ozonev1alpha1.OzoneCluster) []corev1.EnvFromSource {
+	return []corev1.EnvFromSource{{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: configMapName(cluster)},
+		},
+	}}
+}
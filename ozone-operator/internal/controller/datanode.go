@@ -0,0 +1,191 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// defaultDatanodeGracePeriodSeconds is the default termination grace period of datanode pods,
+// overridable via spec.datanode.terminationGracePeriodSeconds.
+const defaultDatanodeGracePeriodSeconds = int64(120)
+
+// datanodeVolumes returns the effective datanode volume list, defaulting to a single "data" volume.
+func datanodeVolumes(cluster *ozonev1alpha1.OzoneCluster) []ozonev1alpha1.DataVolume {
+	if len(cluster.Spec.Datanode.Volumes) > 0 {
+		return cluster.Spec.Datanode.Volumes
+	}
+	return []ozonev1alpha1.DataVolume{{Name: "data", Size: defaultStorageSize()}}
+}
+
+// dataVolumeMountPath returns the mount path of one datanode volume. The first volume mounts at
+// /data so it also carries datanode metadata (ozone.metadata.dirs); extra volumes mount beside it.
+func dataVolumeMountPath(index int, volume *ozonev1alpha1.DataVolume) string {
+	if index == 0 {
+		return dataMountPath
+	}
+	return dataMountPath + "-" + volume.Name
+}
+
+// storageTypeTag maps a volume storage type onto the directory tag understood by StorageLocation.
+// HDD maps to DISK, the Hadoop StorageType name of spinning disks.
+func storageTypeTag(storageType ozonev1alpha1.DataVolumeStorageType) string {
+	switch storageType {
+	case ozonev1alpha1.StorageTypeSSD:
+		return "[SSD]"
+	case ozonev1alpha1.StorageTypeHDD:
+		return "[DISK]"
+	case ozonev1alpha1.StorageTypeArchive:
+		return "[ARCHIVE]"
+	default:
+		return ""
+	}
+}
+
+// datanodeDirProperty renders the hdds.datanode.dir value listing the storage directory of every
+// configured data volume, tagged with its storage tier when one is configured.
+func datanodeDirProperty(cluster *ozonev1alpha1.OzoneCluster) string {
+	volumes := datanodeVolumes(cluster)
+	paths := make([]string, 0, len(volumes))
+	for i := range volumes {
+		paths = append(paths, storageTypeTag(volumes[i].StorageType)+dataVolumeMountPath(i, &volumes[i])+"/storage")
+	}
+	return strings.Join(paths, ",")
+}
+
+// datanodeReservedProperty renders the hdds.datanode.dir.du.reserved entries of volumes with
+// reserved space configured; empty when none is set.
+func datanodeReservedProperty(cluster *ozonev1alpha1.OzoneCluster) string {
+	volumes := datanodeVolumes(cluster)
+	entries := make([]string, 0, len(volumes))
+	for i := range volumes {
+		if volumes[i].Reserved == nil {
+			continue
+		}
+		entries = append(entries,
+			fmt.Sprintf("%s/storage:%d", dataVolumeMountPath(i, &volumes[i]), volumes[i].Reserved.Value()))
+	}
+	return strings.Join(entries, ",")
+}
+
+// datanodeIdentityInit returns the init container pinning a stable per-ordinal datanode identity
+// on the first data volume. It generates a deterministic datanode.id (hashed from namespace,
+// cluster, and pod name) when the file is missing, so a datanode keeps its SCM identity even
+// after its volume was recreated, and it refuses to boot on a volume recorded for another pod,
+// so a mis-bound claim fails fast instead of registering as a "new" datanode.
+func datanodeIdentityInit(cluster *ozonev1alpha1.OzoneCluster, dataMount corev1.VolumeMount) corev1.Container {
+	script := fmt.Sprintf(`set -e
+mkdir -p %[1]s/metadata
+marker=%[1]s/metadata/datanode.pod
+if [ -f "$marker" ] && [ "$(cat "$marker")" != "$HOSTNAME" ]; then
+  echo "data volume already carries the identity of $(cat "$marker"), refusing to boot as $HOSTNAME" >&2
+  exit 1
+fi
+echo "$HOSTNAME" > "$marker"
+id_file=%[1]s/metadata/datanode.id
+if [ ! -f "$id_file" ]; then
+  hash=$(echo -n "%[2]s/$HOSTNAME" | md5sum | cut -c1-32)
+  uuid=$(echo "$hash" | sed 's/^\(.\{8\}\)\(.\{4\}\)\(.\{4\}\)\(.\{4\}\)/\1-\2-\3-\4-/')
+  printf 'uuid: %%s\n' "$uuid" > "$id_file"
+fi`, dataMountPath, cluster.Namespace+"/"+cluster.Name)
+	return corev1.Container{
+		Name:         "identity",
+		Image:        componentImage(cluster, &cluster.Spec.Datanode.ComponentSpec),
+		Command:      []string{"sh", "-c", script},
+		VolumeMounts: []corev1.VolumeMount{dataMount},
+	}
+}
+
+// datanodeService generates the headless service of the datanodes.
+func datanodeService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	return headlessService(cluster, datanodeComponent, []corev1.ServicePort{
+		{Name: "rpc", Port: datanodeRPCPort},
+		{Name: "ui", Port: datanodeHTTPPort},
+	})
+}
+
+// datanodeStatefulSet generates the datanode StatefulSet with one volumeClaimTemplate per
+// configured data volume. Anti-affinity spreads datanodes across nodes so losing a node does not
+// take multiple container replicas with it; it defaults to Preferred and can be hardened to
+// Required via spec.datanode.antiAffinity.
+func datanodeStatefulSet(cluster *ozonev1alpha1.OzoneCluster) *appsv1.StatefulSet {
+	volumes := datanodeVolumes(cluster)
+	mounts := make([]corev1.VolumeMount, 0, len(volumes))
+	claims := make([]corev1.PersistentVolumeClaim, 0, len(volumes))
+	var podVolumes []corev1.Volume
+	for i := range volumes {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumes[i].Name,
+			MountPath: dataVolumeMountPath(i, &volumes[i]),
+		})
+		if volumes[i].Ephemeral {
+			podVolumes = append(podVolumes, emptyDirDataVolume(volumes[i].Name, volumes[i].Size))
+			continue
+		}
+		claims = append(claims, pvcTemplate(volumes[i].Name,
+			corev1.ResourceList{corev1.ResourceStorage: volumes[i].Size}, volumes[i].StorageClass))
+	}
+
+	pod := corev1.PodSpec{
+		Volumes: podVolumes,
+		InitContainers: append([]corev1.Container{datanodeIdentityInit(cluster, mounts[0])},
+			waitForInitContainers(cluster, scmComponent, scmDatanodePort, scmAddresses(cluster)...)...),
+		Containers: []corev1.Container{{
+			Name:            datanodeComponent,
+			Image:           componentImage(cluster, &cluster.Spec.Datanode.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "datanode"},
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    mounts,
+			// The datanode serves the standard gRPC health service on its client port, so the
+			// kubelet's native gRPC probe checks the daemon itself instead of just the socket.
+			LivenessProbe: livenessProbe(corev1.ProbeHandler{
+				GRPC: &corev1.GRPCAction{Port: datanodeRPCPort},
+			}),
+		}},
+	}
+	// Datanodes close their pipelines and container replicas on SIGTERM; the kubelet default of
+	// 30 seconds is too short for a loaded node, so give them more room by default.
+	grace := defaultDatanodeGracePeriodSeconds
+	pod.TerminationGracePeriodSeconds = &grace
+	antiAffinity := cluster.Spec.Datanode.AntiAffinity
+	if antiAffinity == "" {
+		antiAffinity = ozonev1alpha1.AntiAffinityPreferred
+	}
+	pod.Affinity = componentAntiAffinity(cluster, datanodeComponent, antiAffinity)
+	applyComponentSpec(&pod, &cluster.Spec.Datanode.ComponentSpec)
+	if cluster.Spec.Datanode.SpotTolerant {
+		pod.Tolerations = append(pod.Tolerations, spotTolerations()...)
+	}
+
+	sts := baseStatefulSet(cluster, datanodeComponent, replicasOrDefault(cluster.Spec.Datanode.Replicas, 3),
+		pod, prometheusAnnotations(cluster, datanodeHTTPPort))
+	sts.Spec.VolumeClaimTemplates = claims
+	applyUpdateStrategy(sts, &cluster.Spec.Datanode.ComponentSpec)
+	if cluster.Spec.Datanode.ParallelPodManagement {
+		sts.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	}
+	return sts
+}
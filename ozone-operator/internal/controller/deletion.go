@@ -0,0 +1,228 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ozoneClusterFinalizer defers cluster deletion until the deletion policy has been executed.
+const ozoneClusterFinalizer = "ozone.apache.org/deletion-policy"
+
+// finalBackupComponent names the Job that runs the final backup of a BackupThenDelete cluster.
+const finalBackupComponent = "final-backup"
+
+// finalBackupTimeout is how long a deletion waits for the final backup before giving up and
+// tearing the cluster down anyway, so a broken destination cannot block deletion forever.
+const finalBackupTimeout = 30 * time.Minute
+
+// finalBackupPollInterval is how often the final backup Job is rechecked during deletion.
+const finalBackupPollInterval = 30 * time.Second
+
+// reconcileFinalizer keeps the deletion finalizer in sync with the deletion policy. Only Delete
+// and BackupThenDelete need the reconciler to act on deletion; Retain relies on the PVCs simply
+// being left behind.
+func (r *OzoneClusterReconciler) reconcileFinalizer(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	needed := cluster.Spec.DeletionPolicy == ozonev1alpha1.DeletionPolicyDelete ||
+		cluster.Spec.DeletionPolicy == ozonev1alpha1.DeletionPolicyBackupThenDelete
+	if needed == controllerutil.ContainsFinalizer(cluster, ozoneClusterFinalizer) {
+		return nil
+	}
+	if needed {
+		controllerutil.AddFinalizer(cluster, ozoneClusterFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(cluster, ozoneClusterFinalizer)
+	}
+	return r.Update(ctx, cluster)
+}
+
+// handleDeletion executes the deletion policy of a cluster being deleted. For BackupThenDelete it
+// first drives one full backup Job to completion (bounded by finalBackupTimeout), then removes the
+// PVCs of the cluster and releases the finalizer; the owned StatefulSets, Services and ConfigMaps
+// are garbage-collected through their owner references.
+func (r *OzoneClusterReconciler) handleDeletion(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cluster, ozoneClusterFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.Spec.DeletionPolicy == ozonev1alpha1.DeletionPolicyBackupThenDelete &&
+		cluster.Spec.Backup != nil {
+		done, err := r.runFinalBackup(ctx, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !done {
+			return ctrl.Result{RequeueAfter: finalBackupPollInterval}, nil
+		}
+	}
+
+	if err := r.deleteClusterPVCs(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(cluster, ozoneClusterFinalizer)
+	return ctrl.Result{}, r.Update(ctx, cluster)
+}
+
+// runFinalBackup ensures the final backup Job exists and reports whether deletion may proceed:
+// when the Job succeeded, failed, or ran past finalBackupTimeout since the deletion started.
+func (r *OzoneClusterReconciler) runFinalBackup(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (bool, error) {
+	log := logf.FromContext(ctx)
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, finalBackupComponent)}
+	if err := r.Get(ctx, key, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		job := finalBackupJob(cluster)
+		log.Info("starting final backup before cluster deletion", "job", job.Name)
+		return false, r.apply(ctx, cluster, job)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			return true, nil
+		}
+		if condition.Type == batchv1.JobFailed {
+			log.Error(nil, "final backup failed, deleting the cluster anyway",
+				"job", job.Name, "reason", condition.Reason)
+			r.notify(ctx, cluster, "FinalBackupFailed",
+				fmt.Sprintf("the final backup job %s failed (%s); the cluster is deleted anyway", job.Name, condition.Reason))
+			return true, nil
+		}
+	}
+	if time.Since(cluster.DeletionTimestamp.Time) > finalBackupTimeout {
+		log.Error(nil, "final backup did not finish in time, deleting the cluster anyway",
+			"job", job.Name, "timeout", finalBackupTimeout)
+		return true, nil
+	}
+	return false, nil
+}
+
+// finalBackupJob builds the Job that copies the whole namespace of the cluster to the backup
+// destination with `ozone fs -cp` over the s3a connector, mirroring how replication jobs copy
+// between ofs authorities. With spec.backup.includeRecon a second container copies the Recon
+// database from its PVC under the recon/ prefix, so a restore does not pay the full Recon resync.
+func finalBackupJob(cluster *ozonev1alpha1.OzoneCluster) *batchv1.Job {
+	backup := cluster.Spec.Backup
+	destination := "s3a://" + strings.TrimPrefix(backup.Destination, "s3://")
+	if !strings.HasSuffix(destination, "/") {
+		destination += "/"
+	}
+	args := []string{"ozone", "fs"}
+	if backup.S3 != nil && backup.S3.Endpoint != "" {
+		args = append(args, "-D", "fs.s3a.endpoint="+backup.S3.Endpoint)
+	}
+	args = append(args, "-cp", "-f",
+		"ofs://"+podFQDN(cluster, omComponent, 0)+"/", destination)
+
+	var env []corev1.EnvVar
+	if backup.S3 != nil {
+		env = []corev1.EnvVar{
+			{Name: "AWS_ACCESS_KEY_ID", ValueFrom: secretKeyRef(backup.S3.CredentialsSecret, "accessKey")},
+			{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef(backup.S3.CredentialsSecret, "secretKey")},
+		}
+	}
+	labels := componentLabels(cluster, finalBackupComponent)
+	backoffLimit := int32(2)
+	pod := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers: []corev1.Container{{
+			Name:    "backup",
+			Image:   clusterImage(cluster),
+			Args:    args,
+			Env:     env,
+			EnvFrom: configEnvFrom(cluster),
+		}},
+	}
+	if backup.IncludeRecon && cluster.Spec.Recon != nil && !reconUsesExternalDatabase(cluster) {
+		reconArgs := []string{"ozone", "fs"}
+		if backup.S3 != nil && backup.S3.Endpoint != "" {
+			reconArgs = append(reconArgs, "-D", "fs.s3a.endpoint="+backup.S3.Endpoint)
+		}
+		reconArgs = append(reconArgs, "-cp", "-f", "file:///recon-data/", destination+"recon/")
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "recon-data",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "data-" + componentName(cluster, reconComponent) + "-0",
+				ReadOnly:  true,
+			}},
+		})
+		pod.Containers = append(pod.Containers, corev1.Container{
+			Name:         "recon-backup",
+			Image:        clusterImage(cluster),
+			Args:         reconArgs,
+			Env:          env,
+			EnvFrom:      configEnvFrom(cluster),
+			VolumeMounts: []corev1.VolumeMount{{Name: "recon-data", MountPath: "/recon-data", ReadOnly: true}},
+		})
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentName(cluster, finalBackupComponent),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       pod,
+			},
+		},
+	}
+}
+
+// deleteClusterPVCs removes every PVC of the cluster. The StatefulSet controller stamps the
+// selector labels onto claims created from volumeClaimTemplates, so the cluster label selects
+// exactly the claims of this cluster.
+func (r *OzoneClusterReconciler) deleteClusterPVCs(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, claims, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{"ozone.apache.org/cluster": cluster.Name}); err != nil {
+		return err
+	}
+	for i := range claims.Items {
+		if err := r.Delete(ctx, &claims.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// pendingKeysMetric is the OM gauge counting deleted keys not yet purged, as exported on /prom.
+const pendingKeysMetric = "om_metrics_num_keys_pending_deletion"
+
+// pendingBlocksMetric is the SCM gauge counting block deletion transactions not yet completed on
+// the datanodes.
+const pendingBlocksMetric = "scm_block_deleting_service_metrics_num_pending_deletion_blocks"
+
+// deletionBacklogRequeue is how often the deletion backlog is sampled.
+const deletionBacklogRequeue = 5 * time.Minute
+
+// reconcileDeletionBacklog samples the pending-deletion backlog of OM and SCM into
+// status.deletionBacklog, so the effect of the spec.tuning.keyDeletion knobs is visible after a
+// bulk delete. Since the counters are replicated state, the highest value across the HA replicas
+// is reported.
+func (r *OzoneClusterReconciler) reconcileDeletionBacklog(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Tuning == nil || cluster.Spec.Tuning.KeyDeletion == nil ||
+		cluster.Spec.ExternalControlPlane != nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+	authHeader, err := r.metricsAuthHeader(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sample := func(component string, port int32, replicas int32, metric string) (int64, bool) {
+		value, sampled := int64(0), false
+		for i := int32(0); i < replicas; i++ {
+			url := fmt.Sprintf("%s://%s:%d/prom", metricsScheme(cluster), podFQDN(cluster, component, i), port)
+			metrics, err := r.getPromMetrics(ctx, url, authHeader)
+			if err != nil {
+				log.V(1).Info("skipping unreachable metrics endpoint", "url", url, "error", err.Error())
+				continue
+			}
+			sampled = true
+			if pending := int64(metrics[metric]); pending > value {
+				value = pending
+			}
+		}
+		return value, sampled
+	}
+
+	pendingKeys, omSampled := sample(omComponent, omHTTPPort,
+		*replicasOrDefault(cluster.Spec.OM.Replicas, 1), pendingKeysMetric)
+	pendingBlocks, scmSampled := sample(scmComponent, scmHTTPPort,
+		*replicasOrDefault(cluster.Spec.SCM.Replicas, 1), pendingBlocksMetric)
+	if !omSampled && !scmSampled {
+		// Keep the last backlog numbers while the pods restart; the probe time shows their age.
+		return ctrl.Result{RequeueAfter: deletionBacklogRequeue}, nil
+	}
+	now := metav1.Now()
+	cluster.Status.DeletionBacklog = &ozonev1alpha1.DeletionBacklogStatus{
+		PendingKeys:   pendingKeys,
+		PendingBlocks: pendingBlocks,
+		LastProbeTime: &now,
+	}
+	return ctrl.Result{RequeueAfter: deletionBacklogRequeue}, nil
+}
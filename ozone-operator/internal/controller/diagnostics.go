@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// defaultDiagnosticsTailLines is how many log lines are captured when spec.diagnostics does not
+// set logTailLines.
+const defaultDiagnosticsTailLines = int64(200)
+
+// diagnosticsConfigMapName names the artifact of one crash, keyed by restart count so every crash
+// of a container gets exactly one capture.
+func diagnosticsConfigMapName(pod, container string, restartCount int32) string {
+	return fmt.Sprintf("%s-%s-diag-%d", pod, container, restartCount)
+}
+
+// crashedTermination returns the last abnormal termination of a container, or nil if its last
+// restart was a clean exit (or it never restarted).
+func crashedTermination(status *corev1.ContainerStatus) *corev1.ContainerStateTerminated {
+	terminated := status.LastTerminationState.Terminated
+	if terminated == nil {
+		return nil
+	}
+	if terminated.ExitCode == 0 && terminated.Reason != "OOMKilled" {
+		return nil
+	}
+	return terminated
+}
+
+// reconcileDiagnostics captures diagnostics for component containers that exited abnormally or
+// were OOM-killed: the tail of the crashed container log, the pod events, and optionally a jstack
+// thread dump of the restarted JVM, stored in a per-crash ConfigMap referenced from a pod Event.
+func (r *OzoneClusterReconciler) reconcileDiagnostics(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	diagnostics := cluster.Spec.Diagnostics
+	if diagnostics == nil || r.LogReader == nil {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	clusterSelector := map[string]string{"app": "ozone", "ozone.apache.org/cluster": cluster.Name}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(clusterSelector)); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		for j := range pod.Status.ContainerStatuses {
+			status := &pod.Status.ContainerStatuses[j]
+			terminated := crashedTermination(status)
+			if terminated == nil {
+				continue
+			}
+			name := diagnosticsConfigMapName(pod.Name, status.Name, status.RestartCount)
+			existing := &corev1.ConfigMap{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, existing)
+			if err == nil {
+				continue
+			}
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			if err := r.captureDiagnostics(ctx, cluster, pod, status, terminated, name); err != nil {
+				// Capture is best-effort observability; a failure must not wedge the reconcile.
+				log.Error(err, "failed to capture crash diagnostics", "pod", pod.Name, "container", status.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// captureDiagnostics assembles one crash artifact ConfigMap and announces it with a pod Event.
+func (r *OzoneClusterReconciler) captureDiagnostics(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, pod *corev1.Pod, status *corev1.ContainerStatus,
+	terminated *corev1.ContainerStateTerminated, name string) error {
+	tailLines := defaultDiagnosticsTailLines
+	if lines := cluster.Spec.Diagnostics.LogTailLines; lines != nil {
+		tailLines = *lines
+	}
+
+	data := map[string]string{
+		"crash": fmt.Sprintf("container: %s\nreason: %s\nexitCode: %d\nfinishedAt: %s\nrestartCount: %d\n",
+			status.Name, terminated.Reason, terminated.ExitCode, terminated.FinishedAt.Format("2006-01-02T15:04:05Z07:00"),
+			status.RestartCount),
+	}
+	logs, err := r.LogReader.Logs(ctx, pod.Namespace, pod.Name, status.Name, true, tailLines)
+	if err != nil {
+		logs = fmt.Sprintf("log capture failed: %v\n", err)
+	}
+	data["log"] = logs
+	if events, err := r.podEvents(ctx, pod); err == nil {
+		data["events"] = events
+	} else {
+		data["events"] = fmt.Sprintf("event capture failed: %v\n", err)
+	}
+	if cluster.Spec.Diagnostics.CaptureThreadDump && r.Executor != nil && status.State.Running != nil {
+		dump, err := r.Executor.Exec(ctx, pod.Namespace, pod.Name, status.Name, "jstack", "1")
+		if err != nil {
+			dump = fmt.Sprintf("thread dump failed: %v\n", err)
+		}
+		data["jstack"] = dump
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, pod.Labels["component"]),
+		},
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(cluster, configMap, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, configMap); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	if r.Recorder != nil {
+		r.Recorder.Eventf(pod, corev1.EventTypeWarning, "DiagnosticsCaptured",
+			"container %s crashed (%s, exit code %d); diagnostics captured in ConfigMap %s",
+			status.Name, terminated.Reason, terminated.ExitCode, name)
+	}
+	return nil
+}
+
+// podEvents renders the events of a pod into one text blob for the crash artifact.
+func (r *OzoneClusterReconciler) podEvents(ctx context.Context, pod *corev1.Pod) (string, error) {
+	events := &corev1.EventList{}
+	if err := r.List(ctx, events, client.InNamespace(pod.Namespace)); err != nil {
+		return "", err
+	}
+	var lines []string
+	for i := range events.Items {
+		event := &events.Items[i]
+		if event.InvolvedObject.UID != pod.UID {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s: %s",
+			event.LastTimestamp.Format("2006-01-02T15:04:05Z07:00"), event.Type, event.Reason, event.Message))
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// disabledOptionalComponents returns the optional component names whose spec section is removed.
+func disabledOptionalComponents(cluster *ozonev1alpha1.OzoneCluster) []string {
+	var disabled []string
+	if cluster.Spec.Recon == nil {
+		disabled = append(disabled, reconComponent)
+	}
+	if cluster.Spec.S3Gateway == nil {
+		disabled = append(disabled, s3gComponent)
+	}
+	if cluster.Spec.HttpFS == nil {
+		disabled = append(disabled, httpfsComponent)
+	}
+	if cluster.Spec.KMS == nil {
+		disabled = append(disabled, kmsComponent)
+	}
+	return disabled
+}
+
+// reconcileDisabledComponents handles the workloads of optional components whose spec section was
+// removed. By default they are scaled to zero but kept together with their Services and PVCs, so
+// toggling a component (e.g. a misbehaving Recon) off and on again does not lose its data; with
+// spec.disabledComponentPolicy Purge the workload and its Services and PVCs are deleted instead.
+// The component labels select the leftovers, so S3 Gateway zone Deployments are covered too.
+func (r *OzoneClusterReconciler) reconcileDisabledComponents(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	purge := cluster.Spec.DisabledComponentPolicy == ozonev1alpha1.DisabledComponentPurge
+	for _, component := range disabledOptionalComponents(cluster) {
+		selector := []client.ListOption{client.InNamespace(cluster.Namespace),
+			client.MatchingLabels(componentLabels(cluster, component))}
+
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := r.List(ctx, statefulSets, selector...); err != nil {
+			return err
+		}
+		for i := range statefulSets.Items {
+			sts := &statefulSets.Items[i]
+			if purge {
+				if err := r.Delete(ctx, sts); err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+				continue
+			}
+			if sts.Spec.Replicas == nil || *sts.Spec.Replicas != 0 {
+				sts.Spec.Replicas = suspendedReplicas(true, nil)
+				if err := r.Update(ctx, sts); err != nil {
+					return err
+				}
+			}
+		}
+
+		deployments := &appsv1.DeploymentList{}
+		if err := r.List(ctx, deployments, selector...); err != nil {
+			return err
+		}
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			if purge {
+				if err := r.Delete(ctx, deployment); err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+				continue
+			}
+			if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+				deployment.Spec.Replicas = suspendedReplicas(true, nil)
+				if err := r.Update(ctx, deployment); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !purge {
+			continue
+		}
+		services := &corev1.ServiceList{}
+		if err := r.List(ctx, services, selector...); err != nil {
+			return err
+		}
+		for i := range services.Items {
+			if err := r.Delete(ctx, &services.Items[i]); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		claims := &corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, claims, selector...); err != nil {
+			return err
+		}
+		for i := range claims.Items {
+			if err := r.Delete(ctx, &claims.Items[i]); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// drRequeue rechecks fencing and catch-up progress during promotion.
+const drRequeue = 15 * time.Second
+
+// drPolicyName returns the name of the replication policy a standby maintains against its primary.
+func drPolicyName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-dr"
+}
+
+// drReplicationPolicy builds the policy that continuously replicates the configured buckets from
+// the primary into this standby.
+func drReplicationPolicy(cluster *ozonev1alpha1.OzoneCluster) *ozonev1alpha1.OzoneReplicationPolicy {
+	dr := cluster.Spec.DisasterRecovery
+	return &ozonev1alpha1.OzoneReplicationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      drPolicyName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "dr"),
+		},
+		Spec: ozonev1alpha1.OzoneReplicationPolicySpec{
+			SourceCluster: dr.PrimaryCluster,
+			Target:        ozonev1alpha1.ReplicationTarget{Cluster: cluster.Name},
+			Buckets:       dr.Buckets,
+		},
+	}
+}
+
+// setDRPhase records a phase transition, keeping the transition time stable within a phase, and
+// pushes the transition to the configured notification channels.
+func (r *OzoneClusterReconciler) setDRPhase(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, phase ozonev1alpha1.DRPhase) {
+	if cluster.Status.DisasterRecovery == nil {
+		cluster.Status.DisasterRecovery = &ozonev1alpha1.DisasterRecoveryStatus{}
+	}
+	status := cluster.Status.DisasterRecovery
+	if status.Phase != phase {
+		status.Phase = phase
+		now := metav1.Now()
+		status.LastTransitionTime = &now
+		r.notify(ctx, cluster, "DisasterRecovery"+string(phase),
+			fmt.Sprintf("disaster recovery entered phase %s", phase))
+	}
+}
+
+// reconcileDisasterRecovery drives the standby side of a DR pair. While idle the standby keeps a
+// replication policy against the primary; spec.disasterRecovery.promote walks the standby through
+// fencing the primary S3 Gateway, replicating the final delta and promotion.
+func (r *OzoneClusterReconciler) reconcileDisasterRecovery(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	dr := cluster.Spec.DisasterRecovery
+	if dr == nil || dr.Role != ozonev1alpha1.DRRoleStandby {
+		return ctrl.Result{}, nil
+	}
+	if cluster.Status.DisasterRecovery != nil &&
+		cluster.Status.DisasterRecovery.Phase == ozonev1alpha1.DRPhasePromoted {
+		return ctrl.Result{}, nil
+	}
+
+	if !dr.Promote {
+		r.setDRPhase(ctx, cluster, ozonev1alpha1.DRPhaseReplicating)
+		return ctrl.Result{}, r.apply(ctx, cluster, drReplicationPolicy(cluster))
+	}
+
+	fenced, err := r.fencePrimaryS3Gateway(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status := cluster.Status.DisasterRecovery
+	if !fenced {
+		r.setDRPhase(ctx, cluster, ozonev1alpha1.DRPhaseFencing)
+		return ctrl.Result{RequeueAfter: drRequeue}, nil
+	}
+	if status == nil || status.FencedTime == nil {
+		r.setDRPhase(ctx, cluster, ozonev1alpha1.DRPhaseCatchingUp)
+		now := metav1.Now()
+		cluster.Status.DisasterRecovery.FencedTime = &now
+		return ctrl.Result{RequeueAfter: drRequeue}, nil
+	}
+
+	caughtUp, err := r.standbyCaughtUp(ctx, cluster, status.FencedTime.Time)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !caughtUp {
+		r.setDRPhase(ctx, cluster, ozonev1alpha1.DRPhaseCatchingUp)
+		return ctrl.Result{RequeueAfter: drRequeue}, nil
+	}
+
+	// Promotion: stop replicating from the fenced primary.
+	policy := &ozonev1alpha1.OzoneReplicationPolicy{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: drPolicyName(cluster)}
+	if err := r.Get(ctx, key, policy); err == nil {
+		if err := r.Delete(ctx, policy); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else if !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	r.setDRPhase(ctx, cluster, ozonev1alpha1.DRPhasePromoted)
+	return ctrl.Result{}, nil
+}
+
+// fencePrimaryS3Gateway scales every S3 Gateway Deployment of the primary cluster to zero and
+// reports whether all of its pods are gone.
+func (r *OzoneClusterReconciler) fencePrimaryS3Gateway(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		"app":                      "ozone",
+		"component":                s3gComponent,
+		"ozone.apache.org/cluster": cluster.Spec.DisasterRecovery.PrimaryCluster,
+	}); err != nil {
+		return false, err
+	}
+	fenced := true
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			zero := int32(0)
+			deployment.Spec.Replicas = &zero
+			if err := r.Update(ctx, deployment); err != nil {
+				return false, err
+			}
+		}
+		if deployment.Status.Replicas != 0 {
+			fenced = false
+		}
+	}
+	return fenced, nil
+}
+
+// standbyCaughtUp reports whether every replicated bucket has synced since the primary was fenced.
+func (r *OzoneClusterReconciler) standbyCaughtUp(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, fencedAt time.Time) (bool, error) {
+	policy := &ozonev1alpha1.OzoneReplicationPolicy{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: drPolicyName(cluster)}
+	if err := r.Get(ctx, key, policy); err != nil {
+		if errors.IsNotFound(err) {
+			// Nothing left to replicate; treat a missing policy as caught up.
+			return true, nil
+		}
+		return false, err
+	}
+	if len(policy.Status.Buckets) < len(policy.Spec.Buckets) {
+		return false, nil
+	}
+	for _, bucket := range policy.Status.Buckets {
+		if bucket.LastSyncTime == nil || bucket.LastSyncTime.Time.Before(fencedAt) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
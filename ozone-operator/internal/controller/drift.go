@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionConfigDrift is True while any pod still runs with a configuration hash that differs
+// from the rendered ConfigMap, e.g. after a partial or stalled rollout.
+const ConditionConfigDrift = "ConfigDrift"
+
+// driftCheckInterval is how often the pod config hashes are compared against the desired one.
+const driftCheckInterval = 5 * time.Minute
+
+// reconcileConfigDrift compares the config hash each cluster pod was started with (propagated
+// through the pod-template annotation) against the currently rendered one, and records pods that
+// lag behind in status so stale pods are visible without inspecting every StatefulSet.
+func (r *OzoneClusterReconciler) reconcileConfigDrift(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	pods := &corev1.PodList{}
+	clusterSelector := map[string]string{"app": "ozone", "ozone.apache.org/cluster": cluster.Name}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(clusterSelector)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	desired := configHash(cluster)
+	var drifted []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// Terminating pods are already on their way to the new configuration.
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Annotations[configHashAnnotation] != desired {
+			drifted = append(drifted, pod.Name)
+		}
+	}
+	sort.Strings(drifted)
+	cluster.Status.DriftedPods = drifted
+
+	condition := metav1.Condition{
+		Type:               ConditionConfigDrift,
+		Status:             metav1.ConditionFalse,
+		Reason:             "InSync",
+		Message:            "every pod runs the rendered configuration",
+		ObservedGeneration: cluster.Generation,
+	}
+	if len(drifted) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "StalePods"
+		condition.Message = fmt.Sprintf("%d pods still run an outdated configuration: %s",
+			len(drifted), strings.Join(drifted, ", "))
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return ctrl.Result{RequeueAfter: driftCheckInterval}, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// serviceURL returns the namespace-qualified URL of a component Service.
+func serviceURL(cluster *ozonev1alpha1.OzoneCluster, component string, port int32) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", componentName(cluster, component), cluster.Namespace, port)
+}
+
+// updateEndpoints fills status.endpoints from the deployed components.
+func updateEndpoints(cluster *ozonev1alpha1.OzoneCluster) {
+	endpoints := &ozonev1alpha1.EndpointsStatus{
+		OM: fmt.Sprintf("%s:%d", clientOMAddress(cluster), omRPCPort),
+	}
+	if cluster.Spec.S3Gateway != nil {
+		endpoints.S3 = serviceURL(cluster, s3gComponent, s3gHTTPPort)
+	}
+	if cluster.Spec.Recon != nil {
+		endpoints.Recon = serviceURL(cluster, reconComponent, reconHTTPPort)
+	}
+	cluster.Status.Endpoints = endpoints
+}
+
+// s3ConnectionSecretName returns the name of the well-known S3 connection Secret.
+func s3ConnectionSecretName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-s3-connection"
+}
+
+// reconcileS3EndpointSecret maintains the published S3 connection Secret. The endpoint is kept
+// current; bootstrap credentials are generated once on creation and never overwritten.
+func (r *OzoneClusterReconciler) reconcileS3EndpointSecret(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if cluster.Spec.S3Gateway == nil || !cluster.Spec.S3Gateway.PublishEndpointSecret {
+		return nil
+	}
+
+	endpoint := serviceURL(cluster, s3gComponent, s3gHTTPPort)
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: s3ConnectionSecretName(cluster)}
+	err := r.Get(ctx, key, secret)
+	if err == nil {
+		if string(secret.Data["endpoint"]) == endpoint {
+			return nil
+		}
+		secret.Data["endpoint"] = []byte(endpoint)
+		return r.Update(ctx, secret)
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	random := make([]byte, 20)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    componentLabels(cluster, s3gComponent),
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			"endpoint":  endpoint,
+			"accessKey": cluster.Name + "-s3",
+			"secretKey": hex.EncodeToString(random),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, secret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, secret)
+}
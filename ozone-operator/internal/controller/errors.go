@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// errorClass buckets reconcile failures by how they are resolved, and doubles as the reason of
+// the ReconcileSucceeded condition so users can tell a retry-loop from a broken spec.
+type errorClass string
+
+const (
+	// errorTransient covers momentary API and network failures that resolve on retry.
+	errorTransient errorClass = "TransientError"
+
+	// errorConfig covers invalid or conflicting configuration; retrying cannot help until the
+	// spec changes.
+	errorConfig errorClass = "ConfigError"
+
+	// errorQuorumLost covers operations that need a responsive SCM or OM Ratis quorum.
+	errorQuorumLost errorClass = "QuorumLost"
+
+	// errorStorage covers StorageClass and PersistentVolumeClaim failures.
+	errorStorage errorClass = "StorageError"
+)
+
+// Fixed requeue backoffs of the classes that should not hot-loop on the workqueue's exponential
+// backoff: a lost quorum or a storage failure takes minutes, not milliseconds, to recover.
+const (
+	quorumLostRequeue = time.Minute
+	storageRequeue    = 2 * time.Minute
+)
+
+// classifiedError tags an error with its class while keeping the cause unwrappable.
+type classifiedError struct {
+	class errorClass
+	cause error
+}
+
+func (e *classifiedError) Error() string { return e.cause.Error() }
+
+func (e *classifiedError) Unwrap() error { return e.cause }
+
+// configError marks an error as a configuration problem that only a spec change resolves.
+func configError(err error) error {
+	return &classifiedError{class: errorConfig, cause: err}
+}
+
+// quorumLostError marks an error as caused by an unresponsive SCM or OM quorum.
+func quorumLostError(err error) error {
+	return &classifiedError{class: errorQuorumLost, cause: err}
+}
+
+// storageError marks an error as a StorageClass or volume failure.
+func storageError(err error) error {
+	return &classifiedError{class: errorStorage, cause: err}
+}
+
+// classifyError returns the class of a reconcile error. An explicit tag anywhere in the chain
+// wins; unclassified Kubernetes API rejections of what the operator submitted count as
+// configuration errors, and everything else as transient.
+func classifyError(err error) errorClass {
+	classified := &classifiedError{}
+	if errors.As(err, &classified) {
+		return classified.class
+	}
+	if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+		return errorConfig
+	}
+	return errorTransient
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// eventAggregationInterval is how long repeats of an identical Event are absorbed before one
+	// aggregated Event with the accumulated count is re-emitted.
+	eventAggregationInterval = 5 * time.Minute
+
+	// eventReasonQPS and eventReasonBurst bound how many Events one reason may emit: a burst of
+	// eventReasonBurst distinct messages, refilling at eventReasonQPS per second afterwards.
+	eventReasonQPS   = float32(1) / 60
+	eventReasonBurst = 5
+
+	// eventRecordTTL is how long an idle aggregation record is kept before it is pruned.
+	eventRecordTTL = 30 * time.Minute
+)
+
+// eventRecord tracks one (object, type, reason, message) combination between emissions.
+type eventRecord struct {
+	count       int
+	lastSeen    time.Time
+	lastEmitted time.Time
+}
+
+// dedupingRecorder wraps an EventRecorder with count-based aggregation and per-reason rate limits.
+// A crash-looping datanode repeats the same Warning on every restart; without aggregation each
+// repeat becomes its own Event object. The wrapper emits the first occurrence immediately, absorbs
+// identical repeats for eventAggregationInterval and then re-emits one Event carrying the count,
+// and drops distinct messages of one reason that exceed the per-reason token bucket.
+type dedupingRecorder struct {
+	delegate record.EventRecorder
+
+	mu       sync.Mutex
+	seen     map[string]*eventRecord
+	limiters map[string]flowcontrol.RateLimiter
+}
+
+var _ record.EventRecorder = &dedupingRecorder{}
+
+// NewDedupingRecorder wraps a recorder with aggregation and per-reason rate limits.
+func NewDedupingRecorder(delegate record.EventRecorder) record.EventRecorder {
+	return &dedupingRecorder{
+		delegate: delegate,
+		seen:     map[string]*eventRecord{},
+		limiters: map[string]flowcontrol.RateLimiter{},
+	}
+}
+
+// Event implements record.EventRecorder.
+func (d *dedupingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	d.emit(object, nil, eventtype, reason, message)
+}
+
+// Eventf implements record.EventRecorder.
+func (d *dedupingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string,
+	args ...interface{}) {
+	d.emit(object, nil, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (d *dedupingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string,
+	eventtype, reason, messageFmt string, args ...interface{}) {
+	d.emit(object, annotations, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// emit forwards an Event to the delegate unless it is an absorbed repeat or rate-limited.
+func (d *dedupingRecorder) emit(object runtime.Object, annotations map[string]string,
+	eventtype, reason, message string) {
+	now := time.Now()
+	key := eventKey(object, eventtype, reason, message)
+
+	d.mu.Lock()
+	d.prune(now)
+	rec := d.seen[key]
+	if rec == nil {
+		rec = &eventRecord{}
+		d.seen[key] = rec
+	}
+	rec.count++
+	rec.lastSeen = now
+
+	// Repeats inside the aggregation window are only counted; the count is attached to the next
+	// emission, so nothing is lost when the repeat finally surfaces.
+	if !rec.lastEmitted.IsZero() && now.Sub(rec.lastEmitted) < eventAggregationInterval {
+		d.mu.Unlock()
+		return
+	}
+	// The per-reason budget caps distinct messages, e.g. a rollout failing on every pod in turn.
+	// Known repeats above bypass it, so an aggregated count always gets through eventually.
+	if rec.lastEmitted.IsZero() && !d.limiter(reason).TryAccept() {
+		d.mu.Unlock()
+		return
+	}
+	count := rec.count
+	rec.count = 0
+	rec.lastEmitted = now
+	d.mu.Unlock()
+
+	if count > 1 {
+		message = fmt.Sprintf("%s (%d times in the last %s)",
+			message, count, eventAggregationInterval)
+	}
+	if annotations != nil {
+		d.delegate.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+		return
+	}
+	d.delegate.Event(object, eventtype, reason, message)
+}
+
+// limiter returns the token bucket of one Event reason. Callers must hold d.mu.
+func (d *dedupingRecorder) limiter(reason string) flowcontrol.RateLimiter {
+	limiter := d.limiters[reason]
+	if limiter == nil {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(eventReasonQPS, eventReasonBurst)
+		d.limiters[reason] = limiter
+	}
+	return limiter
+}
+
+// prune drops aggregation records that have been idle longer than eventRecordTTL, so the map
+// stays bounded by the set of recently active Events. Callers must hold d.mu.
+func (d *dedupingRecorder) prune(now time.Time) {
+	for key, rec := range d.seen {
+		if now.Sub(rec.lastSeen) > eventRecordTTL {
+			delete(d.seen, key)
+		}
+	}
+}
+
+// eventKey correlates Events of one object with the same type, reason, and message.
+func eventKey(object runtime.Object, eventtype, reason, message string) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return eventtype + "/" + reason + "/" + message
+	}
+	return string(accessor.GetUID()) + "/" + eventtype + "/" + reason + "/" + message
+}
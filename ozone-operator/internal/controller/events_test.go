@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestDedupingRecorderAggregatesRepeats(t *testing.T) {
+	fake := record.NewFakeRecorder(32)
+	recorder := NewDedupingRecorder(fake)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dn-0", UID: types.UID("uid-1")}}
+
+	for i := 0; i < 10; i++ {
+		recorder.Event(pod, corev1.EventTypeWarning, "CrashLoop", "datanode keeps crashing")
+	}
+	events := drainEvents(fake)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 emitted event, got %d: %v", len(events), events)
+	}
+
+	// Expire the aggregation window; the next repeat must surface with the absorbed count.
+	recorder.(*dedupingRecorder).seen[eventKey(pod, corev1.EventTypeWarning, "CrashLoop",
+		"datanode keeps crashing")].lastEmitted = time.Now().Add(-2 * eventAggregationInterval)
+	recorder.Event(pod, corev1.EventTypeWarning, "CrashLoop", "datanode keeps crashing")
+	events = drainEvents(fake)
+	if len(events) != 1 || !strings.Contains(events[0], "10 times") {
+		t.Errorf("expected one aggregated event carrying the count, got %v", events)
+	}
+}
+
+func TestDedupingRecorderRateLimitsPerReason(t *testing.T) {
+	fake := record.NewFakeRecorder(32)
+	recorder := NewDedupingRecorder(fake)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dn-0", UID: types.UID("uid-1")}}
+
+	for i := 0; i < 2*eventReasonBurst; i++ {
+		recorder.Eventf(pod, corev1.EventTypeWarning, "CrashLoop", "pod dn-%d keeps crashing", i)
+	}
+	if emitted := len(drainEvents(fake)); emitted != eventReasonBurst {
+		t.Errorf("expected the per-reason burst of %d events, got %d", eventReasonBurst, emitted)
+	}
+
+	// A different reason has its own budget.
+	recorder.Event(pod, corev1.EventTypeNormal, "OMRebootstrapStarted", "wiped PVC of om-1")
+	if emitted := len(drainEvents(fake)); emitted != 1 {
+		t.Errorf("expected an event of another reason to pass, got %d", emitted)
+	}
+}
+
+func TestDedupingRecorderKeysPerObject(t *testing.T) {
+	fake := record.NewFakeRecorder(32)
+	recorder := NewDedupingRecorder(fake)
+
+	for i := 0; i < 3; i++ {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("dn-%d", i),
+			UID:  types.UID(fmt.Sprintf("uid-%d", i)),
+		}}
+		recorder.Event(pod, corev1.EventTypeWarning, "CrashLoop", "datanode keeps crashing")
+	}
+	if emitted := len(drainEvents(fake)); emitted != 3 {
+		t.Errorf("expected one event per pod, got %d", emitted)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+	"github.com/peterxcli/ozone/ozone-operator/pkg/ozoneclient"
+)
+
+// PodExecutor runs a command inside a pod container and returns its stdout. Used for operations
+// only reachable through the `ozone admin` CLI, such as Container Balancer management.
+type PodExecutor interface {
+	Exec(ctx context.Context, namespace, pod, container string, command ...string) (string, error)
+}
+
+// PodLogReader fetches container logs, used to capture diagnostics after a container crash.
+type PodLogReader interface {
+	Logs(ctx context.Context, namespace, pod, container string, previous bool, tailLines int64) (string, error)
+}
+
+// remoteExecutor implements PodExecutor through the Kubernetes exec subresource.
+type remoteExecutor struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+// NewPodExecutor returns a PodExecutor talking to the cluster behind the given rest config.
+func NewPodExecutor(config *rest.Config) (PodExecutor, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteExecutor{config: config, clientset: clientset}, nil
+}
+
+func (e *remoteExecutor) Exec(ctx context.Context, namespace, pod, container string,
+	command ...string) (string, error) {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		return "", fmt.Errorf("exec %v in %s/%s: %w: %s", command, namespace, pod, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+func (e *remoteExecutor) Logs(ctx context.Context, namespace, pod, container string,
+	previous bool, tailLines int64) (string, error) {
+	raw, err := e.clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("logs of %s/%s container %s: %w", namespace, pod, container, err)
+	}
+	return string(raw), nil
+}
+
+// adminClient returns the ozoneclient view of one cluster, backed by execing the `ozone` CLI in
+// the first SCM and OM pods through the reconciler's Executor. Tests override AdminClient on the
+// reconciler to substitute an ozoneclient.Mock.
+func (r *OzoneClusterReconciler) adminClient(cluster *ozonev1alpha1.OzoneCluster) ozoneclient.Client {
+	if r.AdminClient != nil {
+		return r.AdminClient(cluster)
+	}
+	exec := func(ctx context.Context, pod, container string, command ...string) (string, error) {
+		return r.Executor.Exec(ctx, cluster.Namespace, pod, container, command...)
+	}
+	return ozoneclient.NewExecClient(exec,
+		componentName(cluster, scmComponent)+"-0", scmComponent,
+		componentName(cluster, omComponent)+"-0", omComponent)
+}
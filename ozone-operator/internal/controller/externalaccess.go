@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// externalServiceName returns the name of the Service exposing one component externally.
+func externalServiceName(cluster *ozonev1alpha1.OzoneCluster, component string) string {
+	return componentName(cluster, component) + "-external"
+}
+
+// externalService builds a LoadBalancer or NodePort Service exposing one component to datanodes
+// outside the Kubernetes cluster.
+func externalService(cluster *ozonev1alpha1.OzoneCluster, component string,
+	ports []corev1.ServicePort) *corev1.Service {
+	access := cluster.Spec.ExternalAccess
+	serviceType := access.ServiceType
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        externalServiceName(cluster, component),
+			Namespace:   cluster.Namespace,
+			Labels:      componentLabels(cluster, component),
+			Annotations: access.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     serviceType,
+			Selector: componentLabels(cluster, component),
+			Ports:    ports,
+		},
+	}
+}
+
+// externalSCMService exposes the SCM RPC ports external datanodes and clients register against.
+func externalSCMService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	return externalService(cluster, scmComponent, []corev1.ServicePort{
+		{Name: "client", Port: scmClientPort},
+		{Name: "datanode", Port: scmDatanodePort},
+		{Name: "block", Port: scmBlockPort},
+	})
+}
+
+// externalOMService exposes the OM RPC port for external clients.
+func externalOMService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	return externalService(cluster, omComponent, []corev1.ServicePort{
+		{Name: "rpc", Port: omRPCPort},
+	})
+}
+
+// externalDatanodeConfigMapName returns the name of the configuration bundle for external datanodes.
+func externalDatanodeConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-external-datanode-config"
+}
+
+// externalDatanodeConfigMap publishes the ozone-site.xml an external (bare-metal) datanode needs
+// to register with the in-cluster SCM and OM through the stable external address.
+func externalDatanodeConfigMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	address := cluster.Spec.ExternalAccess.Address
+	props := map[string]string{
+		"ozone.scm.names":                address,
+		"ozone.scm.client.address":       address,
+		"ozone.scm.block.client.address": address,
+		"ozone.scm.datanode.address":     address,
+		"ozone.om.address":               address,
+		"ozone.metadata.dirs":            dataMountPath + "/metadata",
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      externalDatanodeConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "external-datanode-config"),
+		},
+		Data: map[string]string{
+			"ozone-site.xml": renderHadoopXML(props),
+		},
+	}
+}
+
+// countExternalDatanodes counts registered datanodes whose hostname does not match the pods of
+// this cluster's datanode StatefulSet.
+func countExternalDatanodes(cluster *ozonev1alpha1.OzoneCluster, hostnames []string) int32 {
+	podPrefix := componentName(cluster, datanodeComponent) + "-"
+	external := int32(0)
+	for _, hostname := range hostnames {
+		if !strings.HasPrefix(hostname, podPrefix) {
+			external++
+		}
+	}
+	return external
+}
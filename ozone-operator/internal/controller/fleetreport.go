@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// FleetReportServer serves an aggregated JSON summary of every OzoneCluster the operator manages
+// on GET /fleet, so a platform team gets one pane of glass across namespaces without scraping each
+// cluster's status individually. The server runs on every operator replica, not only the leader:
+// the report is read-only and served from the shared informer cache.
+type FleetReportServer struct {
+	// Reader lists the OzoneClusters; the manager's cached client keeps the report cheap.
+	Reader client.Reader
+
+	// Addr is the listen address, e.g. ":8082".
+	Addr string
+}
+
+// fleetReport is the response body of GET /fleet.
+type fleetReport struct {
+	// Clusters are the per-cluster summaries, sorted by namespace and name.
+	Clusters []fleetClusterSummary `json:"clusters"`
+
+	// Total is the number of managed OzoneClusters.
+	Total int `json:"total"`
+
+	// Available is the number of clusters whose Available condition is True.
+	Available int `json:"available"`
+
+	// Degraded is the number of clusters whose Degraded condition is True.
+	Degraded int `json:"degraded"`
+}
+
+// fleetClusterSummary condenses one OzoneCluster into the fields a fleet overview needs.
+type fleetClusterSummary struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Phase is the coarse lifecycle state: Available, NotReady, Degraded, a hibernation or
+	// disaster recovery phase, or Deleting.
+	Phase string `json:"phase"`
+
+	// Version is the tag of spec.image.
+	Version string `json:"version,omitempty"`
+
+	// RawCapacity is the total datanode volume capacity across replicas, as a quantity string.
+	RawCapacity string `json:"rawCapacity,omitempty"`
+
+	// ReadyDatanodes mirrors status.readyDatanodes.
+	ReadyDatanodes int32 `json:"readyDatanodes"`
+
+	// UnhealthyContainers is taken from status.reconHealth when Recon is deployed.
+	UnhealthyContainers int64 `json:"unhealthyContainers,omitempty"`
+
+	// UnderReplicatedContainers is taken from status.storageHealth.
+	UnderReplicatedContainers int64 `json:"underReplicatedContainers,omitempty"`
+
+	// BackupDestination is spec.backup.destination when backups are configured.
+	BackupDestination string `json:"backupDestination,omitempty"`
+
+	// BackupReachable mirrors the BackupDestinationReachable condition.
+	BackupReachable *bool `json:"backupReachable,omitempty"`
+
+	// LastBackupProbeTime is when the backup destination was last probed.
+	LastBackupProbeTime *metav1.Time `json:"lastBackupProbeTime,omitempty"`
+}
+
+// Start implements manager.Runnable, serving /fleet until the manager stops.
+func (s *FleetReportServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fleet", s.handleFleet)
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	logf.Log.WithName("fleet-report").Info("serving the fleet report", "addr", s.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: the report is read-only, so every
+// replica serves it.
+func (s *FleetReportServer) NeedLeaderElection() bool {
+	return false
+}
+
+// handleFleet builds and serves the aggregated report.
+func (s *FleetReportServer) handleFleet(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	clusters := &ozonev1alpha1.OzoneClusterList{}
+	if err := s.Reader.List(req.Context(), clusters); err != nil {
+		http.Error(w, "listing OzoneClusters: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	report := fleetReport{Clusters: []fleetClusterSummary{}, Total: len(clusters.Items)}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionAvailable) {
+			report.Available++
+		}
+		if meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionDegraded) {
+			report.Degraded++
+		}
+		report.Clusters = append(report.Clusters, summarizeCluster(cluster))
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		left, right := report.Clusters[i], report.Clusters[j]
+		if left.Namespace != right.Namespace {
+			return left.Namespace < right.Namespace
+		}
+		return left.Name < right.Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+}
+
+// summarizeCluster condenses one OzoneCluster into its fleet report entry.
+func summarizeCluster(cluster *ozonev1alpha1.OzoneCluster) fleetClusterSummary {
+	summary := fleetClusterSummary{
+		Namespace:      cluster.Namespace,
+		Name:           cluster.Name,
+		Phase:          clusterPhase(cluster),
+		Version:        imageTag(cluster.Spec.Image),
+		RawCapacity:    rawDatanodeCapacity(cluster),
+		ReadyDatanodes: cluster.Status.ReadyDatanodes,
+	}
+	if health := cluster.Status.ReconHealth; health != nil {
+		summary.UnhealthyContainers = health.UnhealthyContainers
+	}
+	if health := cluster.Status.StorageHealth; health != nil {
+		summary.UnderReplicatedContainers = health.UnderReplicatedContainers
+	}
+	if backup := cluster.Spec.Backup; backup != nil {
+		summary.BackupDestination = backup.Destination
+		if condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionBackupDestinationReachable); condition != nil {
+			reachable := condition.Status == metav1.ConditionTrue
+			summary.BackupReachable = &reachable
+			summary.LastBackupProbeTime = &condition.LastTransitionTime
+		}
+	}
+	return summary
+}
+
+// clusterPhase derives the coarse lifecycle state shown in the fleet report.
+func clusterPhase(cluster *ozonev1alpha1.OzoneCluster) string {
+	switch {
+	case cluster.DeletionTimestamp != nil:
+		return "Deleting"
+	case cluster.Status.Hibernation != nil:
+		return string(cluster.Status.Hibernation.Phase)
+	case cluster.Status.DisasterRecovery != nil &&
+		cluster.Status.DisasterRecovery.Phase != ozonev1alpha1.DRPhaseReplicating &&
+		cluster.Status.DisasterRecovery.Phase != ozonev1alpha1.DRPhasePromoted:
+		return string(cluster.Status.DisasterRecovery.Phase)
+	case meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionDegraded):
+		return "Degraded"
+	case meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionAvailable):
+		return "Available"
+	default:
+		return "NotReady"
+	}
+}
+
+// imageTag extracts the tag of an image reference; a digest or untagged reference yields "".
+func imageTag(image string) string {
+	lastColon := strings.LastIndex(image, ":")
+	if lastColon < 0 || strings.Contains(image[lastColon:], "/") {
+		return ""
+	}
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	return image[lastColon+1:]
+}
+
+// rawDatanodeCapacity sums the datanode volume sizes across replicas.
+func rawDatanodeCapacity(cluster *ozonev1alpha1.OzoneCluster) string {
+	if len(cluster.Spec.Datanode.Volumes) == 0 {
+		return ""
+	}
+	total := int64(0)
+	for i := range cluster.Spec.Datanode.Volumes {
+		total += cluster.Spec.Datanode.Volumes[i].Size.Value()
+	}
+	total *= int64(*replicasOrDefault(cluster.Spec.Datanode.Replicas, 3))
+	return resource.NewQuantity(total, resource.BinarySI).String()
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image string
+		tag   string
+	}{
+		{"apache/ozone:1.4.0", "1.4.0"},
+		{"registry.example.com:5000/apache/ozone:1.4.0", "1.4.0"},
+		{"registry.example.com:5000/apache/ozone", ""},
+		{"apache/ozone@sha256:abc123", ""},
+		{"apache/ozone", ""},
+	}
+	for _, tc := range tests {
+		if got := imageTag(tc.image); got != tc.tag {
+			t.Errorf("imageTag(%q) = %q, want %q", tc.image, got, tc.tag)
+		}
+	}
+}
+
+func TestClusterPhase(t *testing.T) {
+	available := []metav1.Condition{{Type: ConditionAvailable, Status: metav1.ConditionTrue}}
+	degraded := []metav1.Condition{
+		{Type: ConditionAvailable, Status: metav1.ConditionTrue},
+		{Type: ConditionDegraded, Status: metav1.ConditionTrue},
+	}
+	now := metav1.Now()
+	tests := []struct {
+		name    string
+		cluster ozonev1alpha1.OzoneCluster
+		phase   string
+	}{
+		{
+			name:  "no status yet",
+			phase: "NotReady",
+		},
+		{
+			name:    "available",
+			cluster: ozonev1alpha1.OzoneCluster{Status: ozonev1alpha1.OzoneClusterStatus{Conditions: available}},
+			phase:   "Available",
+		},
+		{
+			name:    "degraded wins over available",
+			cluster: ozonev1alpha1.OzoneCluster{Status: ozonev1alpha1.OzoneClusterStatus{Conditions: degraded}},
+			phase:   "Degraded",
+		},
+		{
+			name: "hibernation wins over conditions",
+			cluster: ozonev1alpha1.OzoneCluster{Status: ozonev1alpha1.OzoneClusterStatus{
+				Conditions:  available,
+				Hibernation: &ozonev1alpha1.HibernationStatus{Phase: ozonev1alpha1.HibernationHibernated},
+			}},
+			phase: "Hibernated",
+		},
+		{
+			name: "failover in progress",
+			cluster: ozonev1alpha1.OzoneCluster{Status: ozonev1alpha1.OzoneClusterStatus{
+				Conditions:       available,
+				DisasterRecovery: &ozonev1alpha1.DisasterRecoveryStatus{Phase: ozonev1alpha1.DRPhaseCatchingUp},
+			}},
+			phase: "CatchingUp",
+		},
+		{
+			name: "deleting",
+			cluster: ozonev1alpha1.OzoneCluster{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Status:     ozonev1alpha1.OzoneClusterStatus{Conditions: available},
+			},
+			phase: "Deleting",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := clusterPhase(&tc.cluster); got != tc.phase {
+				t.Fatalf("clusterPhase = %q, want %q", got, tc.phase)
+			}
+		})
+	}
+}
+
+func TestRawDatanodeCapacity(t *testing.T) {
+	replicas := int32(5)
+	cluster := &ozonev1alpha1.OzoneCluster{Spec: ozonev1alpha1.OzoneClusterSpec{
+		Datanode: ozonev1alpha1.DatanodeSpec{
+			ComponentSpec: ozonev1alpha1.ComponentSpec{Replicas: &replicas},
+			Volumes: []ozonev1alpha1.DataVolume{
+				{Name: "data0", Size: resource.MustParse("100Gi")},
+				{Name: "data1", Size: resource.MustParse("100Gi")},
+			},
+		},
+	}}
+	if got := rawDatanodeCapacity(cluster); got != "1000Gi" {
+		t.Errorf("rawDatanodeCapacity = %q, want 1000Gi", got)
+	}
+	if got := rawDatanodeCapacity(&ozonev1alpha1.OzoneCluster{}); got != "" {
+		t.Errorf("expected no capacity without volumes, got %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// Gateway API versions of the route kinds the operator creates. The routes are built as
+// unstructured objects so the Gateway API CRDs stay an install-time choice instead of an
+// operator dependency.
+const (
+	httpRouteAPIVersion = "gateway.networking.k8s.io/v1"
+	tlsRouteAPIVersion  = "gateway.networking.k8s.io/v1alpha2"
+)
+
+// gatewayRoutes builds the Gateway API routes exposing the S3 endpoint and the Recon UI through
+// the Gateway referenced by spec.gatewayAPI.
+func gatewayRoutes(cluster *ozonev1alpha1.OzoneCluster) []client.Object {
+	gw := cluster.Spec.GatewayAPI
+	var routes []client.Object
+	if cluster.Spec.S3Gateway != nil {
+		routes = append(routes, gatewayRoute(cluster, s3gComponent, s3Hostnames(cluster), s3gHTTPPort))
+	}
+	if cluster.Spec.Recon != nil {
+		var hostnames []string
+		if gw.ReconHostname != "" {
+			hostnames = []string{gw.ReconHostname}
+		}
+		routes = append(routes, gatewayRoute(cluster, reconComponent, hostnames, reconHTTPPort))
+	}
+	return routes
+}
+
+// s3Hostnames returns the hostnames of the S3 route: the configured S3 hostname, plus the
+// virtual-host domain and its wildcard when virtual-host-style addressing is enabled, so
+// per-bucket names like bucket.s3.example.com reach the gateway.
+func s3Hostnames(cluster *ozonev1alpha1.OzoneCluster) []string {
+	gw := cluster.Spec.GatewayAPI
+	var hostnames []string
+	if gw.S3Hostname != "" {
+		hostnames = append(hostnames, gw.S3Hostname)
+	}
+	if domain := cluster.Spec.S3Gateway.DomainName; domain != "" {
+		if domain != gw.S3Hostname {
+			hostnames = append(hostnames, domain)
+		}
+		hostnames = append(hostnames, "*."+domain)
+	}
+	return hostnames
+}
+
+// gatewayRoute builds the HTTPRoute (or TLSRoute with TLS passthrough) sending the given
+// hostnames of the referenced Gateway to a component Service. Values must stay JSON-native
+// (int64, not int32) because unstructured objects are deep-copied as JSON.
+func gatewayRoute(cluster *ozonev1alpha1.OzoneCluster, component string, hostnames []string,
+	port int32) *unstructured.Unstructured {
+	gw := cluster.Spec.GatewayAPI
+	parentRef := map[string]interface{}{"name": gw.GatewayName}
+	if gw.GatewayNamespace != "" {
+		parentRef["namespace"] = gw.GatewayNamespace
+	}
+	if gw.SectionName != "" {
+		parentRef["sectionName"] = gw.SectionName
+	}
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules": []interface{}{map[string]interface{}{
+			"backendRefs": []interface{}{map[string]interface{}{
+				"name": componentName(cluster, component),
+				"port": int64(port),
+			}},
+		}},
+	}
+	if len(hostnames) > 0 {
+		names := make([]interface{}, 0, len(hostnames))
+		for _, hostname := range hostnames {
+			names = append(names, hostname)
+		}
+		spec["hostnames"] = names
+	}
+	route := &unstructured.Unstructured{}
+	if gw.TLSPassthrough {
+		route.SetAPIVersion(tlsRouteAPIVersion)
+		route.SetKind("TLSRoute")
+	} else {
+		route.SetAPIVersion(httpRouteAPIVersion)
+		route.SetKind("HTTPRoute")
+	}
+	route.SetName(componentName(cluster, component))
+	route.SetNamespace(cluster.Namespace)
+	route.SetLabels(componentLabels(cluster, component))
+	route.Object["spec"] = spec
+	return route
+}
@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// reconHealthRequeue is how often Recon is polled for cluster health.
+const reconHealthRequeue = time.Minute
+
+// reconUnhealthyContainersResponse is the summary part of GET /api/v1/containers/unhealthy.
+type reconUnhealthyContainersResponse struct {
+	MissingCount         int64 `json:"missingCount"`
+	UnderReplicatedCount int64 `json:"underReplicatedCount"`
+	OverReplicatedCount  int64 `json:"overReplicatedCount"`
+	MisReplicatedCount   int64 `json:"misReplicatedCount"`
+}
+
+// reconDatanodesResponse is the relevant part of GET /api/v1/datanodes.
+type reconDatanodesResponse struct {
+	TotalCount int32 `json:"totalCount"`
+	Datanodes  []struct {
+		Hostname string `json:"hostname"`
+		State    string `json:"state"`
+	} `json:"datanodes"`
+}
+
+// reconAPIBaseURL returns the in-cluster base URL of the Recon REST API.
+func reconAPIBaseURL(cluster *ozonev1alpha1.OzoneCluster) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d",
+		componentName(cluster, reconComponent), cluster.Namespace, reconHTTPPort)
+}
+
+// getReconJSON fetches one Recon API endpoint and decodes the JSON response.
+func (r *OzoneClusterReconciler) getReconJSON(ctx context.Context, url string, into interface{}) error {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// reconcileReconHealth pulls container and datanode health from the Recon REST API into status and
+// the ContainersHealthy condition. Recon being unreachable is reported as Unknown, not an error.
+func (r *OzoneClusterReconciler) reconcileReconHealth(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Recon == nil {
+		cluster.Status.ReconHealth = nil
+		return ctrl.Result{}, nil
+	}
+
+	base := reconAPIBaseURL(cluster)
+	var containers reconUnhealthyContainersResponse
+	var datanodes reconDatanodesResponse
+	containersErr := r.getReconJSON(ctx, base+"/api/v1/containers/unhealthy", &containers)
+	datanodesErr := r.getReconJSON(ctx, base+"/api/v1/datanodes", &datanodes)
+	if containersErr != nil || datanodesErr != nil {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               ConditionContainersHealthy,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ReconUnreachable",
+			Message:            "the Recon API could not be queried for container health",
+			ObservedGeneration: cluster.Generation,
+		})
+		return ctrl.Result{RequeueAfter: reconHealthRequeue}, nil
+	}
+
+	healthy := int32(0)
+	hostnames := make([]string, 0, len(datanodes.Datanodes))
+	for _, datanode := range datanodes.Datanodes {
+		if datanode.State == "HEALTHY" {
+			healthy++
+		}
+		hostnames = append(hostnames, datanode.Hostname)
+	}
+	now := metav1.Now()
+	unhealthy := containers.MissingCount + containers.UnderReplicatedCount +
+		containers.OverReplicatedCount + containers.MisReplicatedCount
+	cluster.Status.ReconHealth = &ozonev1alpha1.ReconHealthStatus{
+		MissingContainers:   containers.MissingCount,
+		UnhealthyContainers: unhealthy,
+		HealthyDatanodes:    healthy,
+		TotalDatanodes:      datanodes.TotalCount,
+		LastProbeTime:       &now,
+	}
+	if cluster.Spec.ExternalAccess != nil {
+		cluster.Status.ReconHealth.ExternalDatanodes = countExternalDatanodes(cluster, hostnames)
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionContainersHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AllContainersHealthy",
+		Message:            "Recon reports no unhealthy containers",
+		ObservedGeneration: cluster.Generation,
+	}
+	if unhealthy > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UnhealthyContainers"
+		condition.Message = fmt.Sprintf("Recon reports %d unhealthy containers (%d missing)",
+			unhealthy, containers.MissingCount)
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return ctrl.Result{RequeueAfter: reconHealthRequeue}, nil
+}
@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// hibernationRequeue is how often hibernate/resume progress is re-checked.
+const hibernationRequeue = 15 * time.Second
+
+// zeroReplicas overrides a replica field to zero for this reconcile pass; the override is never
+// persisted, the spec keeps the replica counts restored on resume.
+func zeroReplicas(replicas **int32) {
+	zero := int32(0)
+	*replicas = &zero
+}
+
+// setHibernationPhase records a phase change with its transition time and pushes the transition
+// to the configured notification channels.
+func (r *OzoneClusterReconciler) setHibernationPhase(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, phase ozonev1alpha1.HibernationPhase) {
+	if cluster.Status.Hibernation != nil && cluster.Status.Hibernation.Phase == phase {
+		return
+	}
+	now := metav1.Now()
+	cluster.Status.Hibernation = &ozonev1alpha1.HibernationStatus{Phase: phase, LastTransitionTime: &now}
+	r.notify(ctx, cluster, "Hibernation"+string(phase),
+		fmt.Sprintf("hibernation entered phase %s", phase))
+}
+
+// statefulSetCounts returns the current and ready pod counts of a component StatefulSet; a missing
+// StatefulSet counts as stopped.
+func (r *OzoneClusterReconciler) statefulSetCounts(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, name string) (current, ready int32, err error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, sts); err != nil {
+		if errors.IsNotFound(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	return sts.Status.Replicas, sts.Status.ReadyReplicas, nil
+}
+
+// deploymentCounts returns the current and ready pod counts of a component Deployment; a missing
+// Deployment counts as stopped.
+func (r *OzoneClusterReconciler) deploymentCounts(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, name string) (current, ready int32, err error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	return deployment.Status.Replicas, deployment.Status.ReadyReplicas, nil
+}
+
+// gatewayPods counts the pods of the stateless front-end workloads: S3 Gateway (including per-zone
+// Deployments), HttpFS and Recon.
+func (r *OzoneClusterReconciler) gatewayPods(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (int32, error) {
+	total := int32(0)
+	if cluster.Spec.S3Gateway != nil {
+		names := []string{componentName(cluster, s3gComponent)}
+		for _, zone := range s3gZones(cluster) {
+			names = append(names, componentName(cluster, s3gComponent)+"-"+zone)
+		}
+		for _, name := range names {
+			current, _, err := r.deploymentCounts(ctx, cluster, name)
+			if err != nil {
+				return 0, err
+			}
+			total += current
+		}
+	}
+	if cluster.Spec.HttpFS != nil {
+		current, _, err := r.deploymentCounts(ctx, cluster, componentName(cluster, httpfsComponent))
+		if err != nil {
+			return 0, err
+		}
+		total += current
+	}
+	if cluster.Spec.Recon != nil {
+		name := componentName(cluster, reconComponent)
+		var current int32
+		var err error
+		if reconUsesExternalDatabase(cluster) {
+			current, _, err = r.deploymentCounts(ctx, cluster, name)
+		} else {
+			current, _, err = r.statefulSetCounts(ctx, cluster, name)
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += current
+	}
+	return total, nil
+}
+
+// zeroGatewayReplicas stops the stateless front ends for this reconcile pass.
+func zeroGatewayReplicas(cluster *ozonev1alpha1.OzoneCluster) {
+	if cluster.Spec.S3Gateway != nil {
+		zeroReplicas(&cluster.Spec.S3Gateway.Replicas)
+	}
+	if cluster.Spec.HttpFS != nil {
+		zeroReplicas(&cluster.Spec.HttpFS.Replicas)
+	}
+	if cluster.Spec.Recon != nil {
+		zeroReplicas(&cluster.Spec.Recon.Replicas)
+	}
+}
+
+// reconcileHibernation scales the cluster to zero while spec.hibernate is set, stopping gateways
+// first, then datanodes, OM and SCM so nothing writes into a stopping lower layer, and brings the
+// components back in reverse order when it is unset. PVCs and configuration are untouched, the
+// replica counts in the spec are only overridden in memory.
+func (r *OzoneClusterReconciler) reconcileHibernation(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if !cluster.Spec.Hibernate && cluster.Status.Hibernation == nil {
+		return ctrl.Result{}, nil
+	}
+	managedControlPlane := cluster.Spec.ExternalControlPlane == nil
+
+	if cluster.Spec.Hibernate {
+		if cluster.Status.Hibernation == nil ||
+			cluster.Status.Hibernation.Phase != ozonev1alpha1.HibernationHibernated {
+			r.setHibernationPhase(ctx, cluster, ozonev1alpha1.HibernationHibernating)
+		}
+		zeroGatewayReplicas(cluster)
+		gatewayPods, err := r.gatewayPods(ctx, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if gatewayPods > 0 {
+			return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+		}
+		zeroReplicas(&cluster.Spec.Datanode.Replicas)
+		datanodes, _, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, datanodeComponent))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if datanodes > 0 {
+			return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+		}
+		if managedControlPlane {
+			zeroReplicas(&cluster.Spec.OM.Replicas)
+			omPods, _, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, omComponent))
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if omPods > 0 {
+				return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+			}
+			zeroReplicas(&cluster.Spec.SCM.Replicas)
+			scmPods, _, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, scmComponent))
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if scmPods > 0 {
+				return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+			}
+		}
+		r.setHibernationPhase(ctx, cluster, ozonev1alpha1.HibernationHibernated)
+		return ctrl.Result{}, nil
+	}
+
+	// Resume: SCM first, then OM, datanodes and finally the gateways.
+	r.setHibernationPhase(ctx, cluster, ozonev1alpha1.HibernationResuming)
+	if managedControlPlane {
+		_, scmReady, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, scmComponent))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if scmReady < *replicasOrDefault(cluster.Spec.SCM.Replicas, 1) {
+			zeroReplicas(&cluster.Spec.OM.Replicas)
+			zeroReplicas(&cluster.Spec.Datanode.Replicas)
+			zeroGatewayReplicas(cluster)
+			return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+		}
+		_, omReady, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, omComponent))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if omReady < *replicasOrDefault(cluster.Spec.OM.Replicas, 1) {
+			zeroReplicas(&cluster.Spec.Datanode.Replicas)
+			zeroGatewayReplicas(cluster)
+			return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+		}
+	}
+	_, datanodesReady, err := r.statefulSetCounts(ctx, cluster, componentName(cluster, datanodeComponent))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if datanodesReady < *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3) {
+		zeroGatewayReplicas(cluster)
+		return ctrl.Result{RequeueAfter: hibernationRequeue}, nil
+	}
+	cluster.Status.Hibernation = nil
+	r.notify(ctx, cluster, "HibernationResumed", "all components are back at their configured replica counts")
+	return ctrl.Result{}, nil
+}
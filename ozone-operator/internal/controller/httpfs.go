@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// httpfsService generates the headless service of the HttpFS gateway.
+func httpfsService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	return headlessService(cluster, httpfsComponent, []corev1.ServicePort{
+		{Name: "rest", Port: httpfsHTTPPort},
+	})
+}
+
+// httpfsDeployment generates the HttpFS gateway Deployment. Like the S3 Gateway it is stateless,
+// so /data lives on an emptyDir.
+func httpfsDeployment(cluster *ozonev1alpha1.OzoneCluster) *appsv1.Deployment {
+	dataMount := corev1.VolumeMount{Name: "data", MountPath: dataMountPath}
+	pod := corev1.PodSpec{
+		InitContainers: waitForInitContainers(cluster, omComponent, omRPCPort, omAddresses(cluster)...),
+		Containers: []corev1.Container{{
+			Name:            httpfsComponent,
+			Image:           componentImage(cluster, &cluster.Spec.HttpFS.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "httpfs"},
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    []corev1.VolumeMount{dataMount},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/webhdfs/v1/?op=LISTSTATUS&user.name=hadoop",
+					Port: intstr.FromInt32(httpfsHTTPPort),
+				},
+			}, startupWindow(&cluster.Spec.HttpFS.ComponentSpec, gatewayStartupWindow)),
+			LivenessProbe: livenessProbe(corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/webhdfs/v1/?op=LISTSTATUS&user.name=hadoop",
+					Port: intstr.FromInt32(httpfsHTTPPort),
+				},
+			}),
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}},
+	}
+	applyComponentSpec(&pod, &cluster.Spec.HttpFS.ComponentSpec)
+
+	return baseDeployment(cluster, httpfsComponent, replicasOrDefault(cluster.Spec.HttpFS.Replicas, 1),
+		pod, nil)
+}
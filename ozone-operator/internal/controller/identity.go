@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionIdentityConsistent is False when the identity on the SCM metadata volume no longer
+// matches the cluster id and SCM id recorded in status, e.g. after a PVC reuse or a restore of
+// foreign metadata.
+const ConditionIdentityConsistent = "IdentityConsistent"
+
+// identityProbeRequeue is how often the on-disk cluster identity is re-verified.
+const identityProbeRequeue = 10 * time.Minute
+
+// scmVersionFile is where SCM persists its identity under the metadata mount.
+const scmVersionFile = dataMountPath + "/metadata/scm/current/VERSION"
+
+// checkClusterIdentity refuses to boot SCM or OM replicas on metadata claims stamped with a
+// different cluster name. The OM/SCM Ratis service id is derived from the cluster name, so
+// recreating a cluster under a new name against reused PVCs would start components with a
+// mismatched service id and corrupt the persisted metadata. The StatefulSet controller stamps
+// the selector labels onto claims created from volumeClaimTemplates, so the cluster label on an
+// existing claim records which cluster wrote it.
+func (r *OzoneClusterReconciler) checkClusterIdentity(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if cluster.Spec.ExternalControlPlane != nil {
+		return nil
+	}
+	for component, replicas := range map[string]*int32{
+		scmComponent: cluster.Spec.SCM.Replicas,
+		omComponent:  cluster.Spec.OM.Replicas,
+	} {
+		for i := int32(0); i < *replicasOrDefault(replicas, 1); i++ {
+			name := fmt.Sprintf("data-%s-%d", componentName(cluster, component), i)
+			claim := &corev1.PersistentVolumeClaim{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, claim)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if owner := claim.Labels["ozone.apache.org/cluster"]; owner != "" && owner != cluster.Name {
+				return configError(fmt.Errorf(
+					"PVC %s was written by OzoneCluster %q: the OM/SCM service id is derived from the cluster "+
+						"name, so booting %q on this metadata would corrupt it; restore the original cluster name or "+
+						"remove the reused claims", name, owner, cluster.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileClusterIdentity records the cluster id and SCM id from the SCM VERSION file into
+// status.identity after initialization, and on later probes verifies the on-disk identity still
+// matches, catching PVC reuse and restores of foreign metadata through the IdentityConsistent
+// condition before the mismatch surfaces as corruption.
+func (r *OzoneClusterReconciler) reconcileClusterIdentity(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if r.Executor == nil || cluster.Spec.ExternalControlPlane != nil {
+		return ctrl.Result{}, nil
+	}
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	output, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent, "cat", scmVersionFile)
+	if err != nil {
+		// The SCM pod is down or has not formatted its metadata yet; probe again later.
+		return ctrl.Result{RequeueAfter: identityProbeRequeue}, nil
+	}
+	clusterID, scmID := parseSCMVersionFile(output)
+	if clusterID == "" {
+		return ctrl.Result{RequeueAfter: identityProbeRequeue}, nil
+	}
+
+	if cluster.Status.Identity == nil {
+		now := metav1.Now()
+		cluster.Status.Identity = &ozonev1alpha1.ClusterIdentityStatus{
+			ClusterID:    clusterID,
+			SCMID:        scmID,
+			RecordedTime: &now,
+		}
+	}
+	recorded := cluster.Status.Identity
+	condition := metav1.Condition{
+		Type:               ConditionIdentityConsistent,
+		Status:             metav1.ConditionTrue,
+		Reason:             "IdentityVerified",
+		Message:            fmt.Sprintf("on-disk cluster id %s matches the recorded identity", clusterID),
+		ObservedGeneration: cluster.Generation,
+	}
+	switch {
+	case recorded.ClusterID != clusterID:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ClusterIDMismatch"
+		condition.Message = fmt.Sprintf("SCM metadata reports cluster id %s but %s was recorded at initialization; "+
+			"the metadata volume was reused or restored from another cluster", clusterID, recorded.ClusterID)
+	case recorded.SCMID != "" && scmID != "" && recorded.SCMID != scmID:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SCMIDMismatch"
+		condition.Message = fmt.Sprintf("SCM metadata reports SCM id %s but %s was recorded at initialization",
+			scmID, recorded.SCMID)
+	}
+	previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionIdentityConsistent)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	// Notify only when the condition flips, not on every probe.
+	if (previous == nil && condition.Status == metav1.ConditionFalse) ||
+		(previous != nil && previous.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return ctrl.Result{RequeueAfter: identityProbeRequeue}, nil
+}
+
+// parseSCMVersionFile extracts the cluster id and SCM id from the properties-style SCM VERSION
+// file. Missing keys come back empty.
+func parseSCMVersionFile(output string) (clusterID, scmID string) {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "clusterID":
+			clusterID = value
+		case "scmUuid", "scmId":
+			scmID = value
+		}
+	}
+	return clusterID, scmID
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import "testing"
+
+func TestParseSCMVersionFile(t *testing.T) {
+	version := `
+#Thu Feb 01 10:15:00 UTC 2024
+cTime=1706782500000
+clusterID=CID-5f70bc31-9a53-4a60-b746-dd1f2f97d55a
+scmUuid=7f3a2c92-6a1f-4f8e-9c35-1f0f2a9a4b11
+nodeType=SCM
+layoutVersion=3
+`
+	clusterID, scmID := parseSCMVersionFile(version)
+	if clusterID != "CID-5f70bc31-9a53-4a60-b746-dd1f2f97d55a" {
+		t.Errorf("unexpected cluster id %q", clusterID)
+	}
+	if scmID != "7f3a2c92-6a1f-4f8e-9c35-1f0f2a9a4b11" {
+		t.Errorf("unexpected SCM id %q", scmID)
+	}
+
+	clusterID, scmID = parseSCMVersionFile("cat: /data/metadata/scm/current/VERSION: No such file or directory")
+	if clusterID != "" || scmID != "" {
+		t.Errorf("expected empty ids for a missing file, got %q and %q", clusterID, scmID)
+	}
+}
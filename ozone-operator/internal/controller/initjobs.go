@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// initJobRetry is how often a pending or retried initialization Job is re-checked.
+const initJobRetry = time.Minute
+
+// initJobsEnabled reports whether the one-time `ozone scm --init` and `ozone om --init` runs are
+// driven through operator-managed Jobs on the primordial metadata claims, instead of implicitly
+// inside the pods. Ephemeral storage keeps the in-pod initialization because every pod boots a
+// fresh volume a Job cannot prepare.
+func initJobsEnabled(cluster *ozonev1alpha1.OzoneCluster) bool {
+	return cluster.Spec.ExternalControlPlane == nil &&
+		!cluster.Spec.SCM.Storage.Ephemeral && !cluster.Spec.OM.Storage.Ephemeral
+}
+
+// initJobName returns the name of the initialization Job of a component.
+func initJobName(cluster *ozonev1alpha1.OzoneCluster, component string) string {
+	return componentName(cluster, component) + "-init"
+}
+
+// initJobsComplete reports whether every initialization Job of the cluster has completed.
+func initJobsComplete(cluster *ozonev1alpha1.OzoneCluster) bool {
+	return initJobSucceeded(cluster, scmComponent) && initJobSucceeded(cluster, omComponent)
+}
+
+// gatedOnInitJob reports whether a desired object is an SCM or OM StatefulSet whose
+// initialization Job has not completed yet, so its creation must wait.
+func gatedOnInitJob(cluster *ozonev1alpha1.OzoneCluster, obj client.Object) (string, bool) {
+	sts, ok := obj.(*appsv1.StatefulSet)
+	if !ok || !initJobsEnabled(cluster) {
+		return "", false
+	}
+	for _, component := range []string{scmComponent, omComponent} {
+		if sts.Name == componentName(cluster, component) && !initJobSucceeded(cluster, component) {
+			return component, true
+		}
+	}
+	return "", false
+}
+
+// initJobSucceeded reports whether the initialization Job of a component completed, per the
+// durable record in status; the Job itself may have been cleaned up since.
+func initJobSucceeded(cluster *ozonev1alpha1.OzoneCluster, component string) bool {
+	name := initJobName(cluster, component)
+	for _, job := range cluster.Status.InitJobs {
+		if job.Name == name && job.Succeeded {
+			return true
+		}
+	}
+	return false
+}
+
+// setInitJobStatus records the current outcome of an initialization Job in status.
+func setInitJobStatus(cluster *ozonev1alpha1.OzoneCluster, name string, succeeded bool,
+	completionTime *metav1.Time) {
+	for i := range cluster.Status.InitJobs {
+		if cluster.Status.InitJobs[i].Name == name {
+			cluster.Status.InitJobs[i].Succeeded = succeeded
+			cluster.Status.InitJobs[i].CompletionTime = completionTime
+			return
+		}
+	}
+	cluster.Status.InitJobs = append(cluster.Status.InitJobs, ozonev1alpha1.InitJobStatus{
+		Name:           name,
+		Succeeded:      succeeded,
+		CompletionTime: completionTime,
+	})
+}
+
+// reconcileInitJobs drives the initialization Jobs of SCM and OM. The SCM and OM StatefulSets
+// are only created once their Job completed, so initialization is observable through the Job and
+// status.initJobs instead of happening implicitly inside the first pod. There is no separate
+// security init: the operator configures no Kerberos, and `ozone scm --init` covers certificate
+// bootstrap.
+func (r *OzoneClusterReconciler) reconcileInitJobs(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if !initJobsEnabled(cluster) {
+		return nil
+	}
+	for _, component := range []string{scmComponent, omComponent} {
+		if initJobSucceeded(cluster, component) {
+			continue
+		}
+		if err := r.runInitJob(ctx, cluster, component); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runInitJob ensures the initialization Job of a component exists and records its outcome. A
+// failed Job is deleted after recording the failure, so the next reconcile recreates and thereby
+// retries it.
+func (r *OzoneClusterReconciler) runInitJob(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, component string) error {
+	if err := r.ensureInitPVC(ctx, cluster, component); err != nil {
+		return err
+	}
+	name := initJobName(cluster, component)
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: name}
+	if err := r.Get(ctx, key, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return r.apply(ctx, cluster, initJob(cluster, component))
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			setInitJobStatus(cluster, name, true, job.Status.CompletionTime)
+			return nil
+		}
+		if condition.Type == batchv1.JobFailed {
+			setInitJobStatus(cluster, name, false, nil)
+			r.notify(ctx, cluster, "InitJobFailed",
+				fmt.Sprintf("initialization job %s failed (%s) and is retried", name, condition.Reason))
+			propagation := metav1.DeletePropagationBackground
+			return r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &propagation})
+		}
+	}
+	return nil
+}
+
+// ensureInitPVC creates the ordinal-0 metadata claim of a component if it does not exist yet.
+// The claim carries the name and spec of the StatefulSet's volumeClaimTemplate, so the
+// StatefulSet adopts it once the pods are created.
+func (r *OzoneClusterReconciler) ensureInitPVC(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, component string) error {
+	storage := &cluster.Spec.SCM.Storage
+	if component == omComponent {
+		storage = &cluster.Spec.OM.Storage
+	}
+	name := fmt.Sprintf("data-%s-0", componentName(cluster, component))
+	existing := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, existing)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	claim := storagePVCTemplate(storage)
+	claim.Name = name
+	claim.Namespace = cluster.Namespace
+	claim.Labels = componentLabels(cluster, component)
+	return r.apply(ctx, cluster, &claim)
+}
+
+// initJob builds the Job running the one-time initialization of a component on its primordial
+// metadata claim: `ozone scm --init` formats the cluster, `ozone om --init` registers the OM with
+// the running SCM, which the Job waits for with the same startup gate the OM pods use.
+func initJob(cluster *ozonev1alpha1.OzoneCluster, component string) *batchv1.Job {
+	spec := &cluster.Spec.SCM.ComponentSpec
+	args := []string{"ozone", "scm", "--init"}
+	var initContainers []corev1.Container
+	if component == omComponent {
+		spec = &cluster.Spec.OM.ComponentSpec
+		args = []string{"ozone", "om", "--init"}
+		initContainers = waitForInitContainers(cluster, scmComponent, scmHTTPPort, scmAddresses(cluster)...)
+	}
+	labels := componentLabels(cluster, component)
+	backoffLimit := int32(2)
+	// The Job is the first writer on the freshly provisioned claim, so it needs the same fsGroup
+	// the component pods get through baseStatefulSet or the non-root image cannot write the volume.
+	fsGroup := hadoopUserGroup
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      initJobName(cluster, component),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:   corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{FSGroup: &fsGroup},
+					InitContainers:  initContainers,
+					Containers: []corev1.Container{{
+						Name:    "init",
+						Image:   componentImage(cluster, spec),
+						Args:    args,
+						EnvFrom: configEnvFrom(cluster),
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "data", MountPath: dataMountPath},
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: fmt.Sprintf("data-%s-0", componentName(cluster, component)),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
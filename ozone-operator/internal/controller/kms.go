@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// embeddedKMSURI returns the Hadoop key provider URI of the embedded KMS.
+func embeddedKMSURI(cluster *ozonev1alpha1.OzoneCluster) string {
+	return fmt.Sprintf("kms://http@%s:%d/kms", podFQDN(cluster, kmsComponent, 0), kmsHTTPPort)
+}
+
+// kmsService generates the headless service of the embedded KMS.
+func kmsService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	return headlessService(cluster, kmsComponent, []corev1.ServicePort{
+		{Name: "http", Port: kmsHTTPPort},
+	})
+}
+
+// kmsStatefulSet generates the embedded KMS StatefulSet with the keystore on the data PVC. A
+// single replica is the default; the JavaKeyStoreProvider does not coordinate concurrent writers.
+func kmsStatefulSet(cluster *ozonev1alpha1.OzoneCluster) *appsv1.StatefulSet {
+	kms := cluster.Spec.KMS
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:            kmsComponent,
+			Image:           componentImage(cluster, &kms.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"hadoop", "kms"},
+			Env: []corev1.EnvVar{{
+				Name:  "HADOOP_OPTS",
+				Value: "-Dhadoop.kms.key.provider.uri=jceks://file@" + dataMountPath + "/kms.keystore",
+			}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: dataMountPath}},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(kmsHTTPPort)},
+			}, startupWindow(&kms.ComponentSpec, gatewayStartupWindow)),
+			LivenessProbe: livenessProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(kmsHTTPPort)},
+			}),
+		}},
+	}
+	if kms.KeystorePasswordSecret != "" {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, corev1.EnvVar{
+			Name:      "HADOOP_KEYSTORE_PASSWORD",
+			ValueFrom: secretKeyRef(kms.KeystorePasswordSecret, "password"),
+		})
+	}
+	applyComponentSpec(&pod, &kms.ComponentSpec)
+
+	sts := baseStatefulSet(cluster, kmsComponent, replicasOrDefault(kms.Replicas, 1), pod, nil)
+	applyComponentStorage(sts, &kms.Storage)
+	applyUpdateStrategy(sts, &kms.ComponentSpec)
+	return sts
+}
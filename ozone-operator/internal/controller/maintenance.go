@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// maintenanceAnnotation marks a Node for planned maintenance; its datanodes are put into
+	// Ozone maintenance mode just like on a cordon.
+	maintenanceAnnotation = "ozone.apache.org/maintenance"
+
+	// maintenanceRetry is the retry interval after a failed maintenance mode transition.
+	maintenanceRetry = 30 * time.Second
+)
+
+// nodeUnderMaintenance reports whether a node is cordoned or annotated for planned maintenance.
+func nodeUnderMaintenance(node *corev1.Node) bool {
+	return node.Spec.Unschedulable || node.Annotations[maintenanceAnnotation] == "true"
+}
+
+// reconcileMaintenance puts datanodes on cordoned or maintenance-annotated nodes into Ozone
+// maintenance mode before they are evicted, and recommissions them once the node is schedulable
+// again. This keeps SCM from re-replicating every container of a datanode that is only down for
+// a planned node drain.
+func (r *OzoneClusterReconciler) reconcileMaintenance(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if r.Executor == nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+
+	desired, err := r.datanodesOnMaintenanceNodes(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	current := map[string]bool{}
+	if cluster.Status.Maintenance != nil {
+		for _, host := range cluster.Status.Maintenance.Datanodes {
+			current[host] = true
+		}
+	}
+
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	failed := false
+	for host := range desired {
+		if current[host] {
+			continue
+		}
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent,
+			"ozone", "admin", "datanode", "maintenance", host); err != nil {
+			log.Error(err, "failed to enter datanode maintenance mode", "datanode", host)
+			failed = true
+			continue
+		}
+		current[host] = true
+	}
+	recommissioned := false
+	for host := range current {
+		if desired[host] {
+			continue
+		}
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent,
+			"ozone", "admin", "datanode", "recommission", host); err != nil {
+			log.Error(err, "failed to recommission datanode", "datanode", host)
+			failed = true
+			continue
+		}
+		delete(current, host)
+		recommissioned = true
+	}
+	if recommissioned && cluster.Spec.Datanode.SpotTolerant {
+		r.rebalanceAfterRecommission(ctx, cluster)
+	}
+
+	hosts := make([]string, 0, len(current))
+	for host := range current {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	if len(hosts) == 0 {
+		cluster.Status.Maintenance = nil
+	} else {
+		cluster.Status.Maintenance = &ozonev1alpha1.MaintenanceStatus{Datanodes: hosts}
+	}
+	if failed {
+		return ctrl.Result{RequeueAfter: maintenanceRetry}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// datanodesOnMaintenanceNodes returns the host names of datanode pods currently scheduled on
+// nodes that are cordoned or annotated for maintenance.
+func (r *OzoneClusterReconciler) datanodesOnMaintenanceNodes(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (map[string]bool, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(componentLabels(cluster, datanodeComponent))); err != nil {
+		return nil, err
+	}
+	hosts := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if nodeUnderMaintenance(node) ||
+			(cluster.Spec.Datanode.SpotTolerant && nodeHasTerminationNotice(node)) {
+			// Datanodes register with their pod DNS name (hdds.datanode.use.datanode.hostname).
+			hosts[pod.Name+"."+componentName(cluster, datanodeComponent)] = true
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,230 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionMemoryStarved is True while any component JVM runs above the configured heap occupancy
+// threshold, the usual precursor of GC thrashing and an eventual OOM kill.
+const ConditionMemoryStarved = "MemoryStarved"
+
+// defaultHeapWarningPercent is the heap occupancy threshold when spec.monitoring does not set one.
+const defaultHeapWarningPercent = int32(85)
+
+// defaultGCPauseWarning is the p99 GC pause threshold of the generated alerting rules.
+const defaultGCPauseWarning = time.Second
+
+// jvmHealthRequeue is how often the component heaps are re-sampled.
+const jvmHealthRequeue = 5 * time.Minute
+
+// heapWarningPercent returns the configured heap occupancy threshold.
+func heapWarningPercent(monitoring *ozonev1alpha1.MonitoringSpec) int32 {
+	if monitoring.HeapWarningPercent != nil {
+		return *monitoring.HeapWarningPercent
+	}
+	return defaultHeapWarningPercent
+}
+
+// alertRulesConfigMapName names the ConfigMap carrying the generated Prometheus alerting rules.
+func alertRulesConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-alert-rules"
+}
+
+// alertRulesConfigMap renders Prometheus alerting rules over the JVM metrics every component
+// exposes on /prom: sustained GC pauses and heap occupancy that stays high across collections.
+// The ConfigMap is meant to be loaded into an existing Prometheus via its rule-file discovery.
+func alertRulesConfigMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	monitoring := cluster.Spec.Monitoring
+	gcPause := defaultGCPauseWarning
+	if monitoring.GCPauseWarning != nil {
+		gcPause = monitoring.GCPauseWarning.Duration
+	}
+	rules := fmt.Sprintf(`groups:
+  - name: ozone-jvm
+    rules:
+      - alert: OzoneJVMGCPauseHigh
+        expr: quantile_over_time(0.99, rate(jvm_metrics_gc_time_millis[1m])[15m:1m]) > %d
+        for: 10m
+        labels:
+          severity: warning
+        annotations:
+          summary: an Ozone component spends too long in GC pauses
+          description: the p99 GC time over 15m exceeds %dms per second; the component is likely memory-starved
+      - alert: OzoneJVMHeapStarved
+        expr: min_over_time((jvm_metrics_mem_heap_used_m / jvm_metrics_mem_heap_max_m)[15m:1m]) * 100 > %d
+        for: 10m
+        labels:
+          severity: warning
+        annotations:
+          summary: an Ozone component heap stays nearly full
+          description: heap occupancy never dropped below %d%% over 15m, so full GCs no longer reclaim memory
+`, gcPause.Milliseconds(), gcPause.Milliseconds(),
+		heapWarningPercent(monitoring), heapWarningPercent(monitoring))
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alertRulesConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "monitoring"),
+		},
+		Data: map[string]string{"ozone-jvm-alerts.yaml": rules},
+	}
+}
+
+// monitoredComponent pairs the HTTP port of a sampled component with its replica count.
+type monitoredComponent struct {
+	port     int32
+	replicas int32
+}
+
+// monitoredComponents lists the components whose heaps are sampled.
+func monitoredComponents(cluster *ozonev1alpha1.OzoneCluster) map[string]monitoredComponent {
+	components := map[string]monitoredComponent{
+		datanodeComponent: {port: datanodeHTTPPort, replicas: *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3)},
+	}
+	if cluster.Spec.ExternalControlPlane == nil {
+		components[scmComponent] = monitoredComponent{
+			port: scmHTTPPort, replicas: *replicasOrDefault(cluster.Spec.SCM.Replicas, 1)}
+		components[omComponent] = monitoredComponent{
+			port: omHTTPPort, replicas: *replicasOrDefault(cluster.Spec.OM.Replicas, 1)}
+	}
+	return components
+}
+
+// reconcileJVMHealth samples heap occupancy of every SCM, OM, and datanode JVM through /prom and
+// maintains the MemoryStarved condition. Unreachable pods are skipped; they are covered by the
+// readiness probes, not by memory telemetry.
+func (r *OzoneClusterReconciler) reconcileJVMHealth(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	monitoring := cluster.Spec.Monitoring
+	if monitoring == nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+	threshold := float64(heapWarningPercent(monitoring))
+	authHeader, err := r.metricsAuthHeader(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sampled := 0
+	var starved []string
+	for component, target := range monitoredComponents(cluster) {
+		for i := int32(0); i < target.replicas; i++ {
+			url := fmt.Sprintf("%s://%s:%d/prom", metricsScheme(cluster), podFQDN(cluster, component, i), target.port)
+			metrics, err := r.getPromMetrics(ctx, url, authHeader)
+			if err != nil {
+				log.V(1).Info("skipping unreachable metrics endpoint", "url", url, "error", err.Error())
+				continue
+			}
+			used, max := metrics["jvm_metrics_mem_heap_used_m"], metrics["jvm_metrics_mem_heap_max_m"]
+			if max <= 0 {
+				continue
+			}
+			sampled++
+			if occupancy := used / max * 100; occupancy > threshold {
+				starved = append(starved, fmt.Sprintf("%s-%d (%.0f%%)", componentName(cluster, component), i, occupancy))
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionMemoryStarved,
+		Status:             metav1.ConditionFalse,
+		Reason:             "HeapHeadroomOK",
+		Message:            fmt.Sprintf("every sampled JVM heap is below %.0f%% occupancy", threshold),
+		ObservedGeneration: cluster.Generation,
+	}
+	switch {
+	case sampled == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "MetricsUnreachable"
+		condition.Message = "no component /prom endpoint could be sampled"
+	case len(starved) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "HeapOccupancyHigh"
+		condition.Message = fmt.Sprintf("JVM heap occupancy exceeds %.0f%%: %s",
+			threshold, strings.Join(starved, ", "))
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return ctrl.Result{RequeueAfter: jvmHealthRequeue}, nil
+}
+
+// getPromMetrics fetches a /prom endpoint, presenting the configured credentials, and returns its
+// plain (unlabeled-name) samples.
+func (r *OzoneClusterReconciler) getPromMetrics(ctx context.Context, url,
+	authHeader string) (map[string]float64, error) {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	metrics := map[string]float64{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		// Strip the label set; the JVM metrics carry only process-identifying labels.
+		if brace := strings.IndexByte(name, '{'); brace >= 0 {
+			name = name[:brace]
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		metrics[name] = parsed
+	}
+	return metrics, scanner.Err()
+}
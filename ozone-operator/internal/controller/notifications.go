@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// notificationPayload is the body posted to spec.notifications.webhookURL.
+type notificationPayload struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+}
+
+// notify pushes one operator-level event to every channel configured under spec.notifications.
+// Delivery is best-effort: a channel that cannot be reached is logged and skipped, and notify
+// never fails the reconcile that triggered it. Callers are responsible for only notifying on
+// transitions, not on every pass over an unchanged state.
+func (r *OzoneClusterReconciler) notify(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	reason, message string) {
+	notifications := cluster.Spec.Notifications
+	if notifications == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+	if notifications.SlackWebhookSecret != "" {
+		if err := r.notifySlack(ctx, cluster, reason, message); err != nil {
+			log.Error(err, "failed to deliver Slack notification", "reason", reason)
+		}
+	}
+	if notifications.WebhookURL != "" {
+		if err := r.notifyWebhook(ctx, cluster, reason, message); err != nil {
+			log.Error(err, "failed to deliver webhook notification", "reason", reason)
+		}
+	}
+	if notifications.SMTP != nil {
+		if err := r.notifySMTP(ctx, cluster, reason, message); err != nil {
+			log.Error(err, "failed to deliver mail notification", "reason", reason)
+		}
+	}
+}
+
+// notifySlack posts the event to the Slack incoming webhook URL held by the configured Secret.
+func (r *OzoneClusterReconciler) notifySlack(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	reason, message string) error {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.Notifications.SlackWebhookSecret}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return fmt.Errorf("reading Slack webhook secret %s: %w", key.Name, err)
+	}
+	url := strings.TrimSpace(string(secret.Data["url"]))
+	if url == "" {
+		return fmt.Errorf("secret %s has no url entry", key.Name)
+	}
+	text := fmt.Sprintf("[%s/%s] %s: %s", cluster.Namespace, cluster.Name, reason, message)
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	return r.postNotification(ctx, url, body)
+}
+
+// notifyWebhook posts the event as JSON to spec.notifications.webhookURL.
+func (r *OzoneClusterReconciler) notifyWebhook(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	reason, message string) error {
+	body, err := json.Marshal(notificationPayload{
+		Cluster:   cluster.Name,
+		Namespace: cluster.Namespace,
+		Reason:    reason,
+		Message:   message,
+	})
+	if err != nil {
+		return err
+	}
+	return r.postNotification(ctx, cluster.Spec.Notifications.WebhookURL, body)
+}
+
+// postNotification sends one JSON POST and treats any non-2xx answer as a delivery failure.
+func (r *OzoneClusterReconciler) postNotification(ctx context.Context, url string, body []byte) error {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+// notifySMTP mails the event using the host, port, username, password and from entries of the
+// configured credentials Secret. An empty username sends without authentication.
+func (r *OzoneClusterReconciler) notifySMTP(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	reason, message string) error {
+	smtpSpec := cluster.Spec.Notifications.SMTP
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: smtpSpec.CredentialsSecret}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return fmt.Errorf("reading SMTP credentials secret %s: %w", key.Name, err)
+	}
+	host := string(secret.Data["host"])
+	port := string(secret.Data["port"])
+	from := string(secret.Data["from"])
+	if host == "" || port == "" || from == "" {
+		return fmt.Errorf("secret %s must have host, port and from entries", key.Name)
+	}
+	var auth smtp.Auth
+	if username := string(secret.Data["username"]); username != "" {
+		auth = smtp.PlainAuth("", username, string(secret.Data["password"]), host)
+	}
+	subject := fmt.Sprintf("OzoneCluster %s/%s: %s", cluster.Namespace, cluster.Name, reason)
+	mail := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		from, strings.Join(smtpSpec.To, ", "), subject, message)
+	return smtp.SendMail(host+":"+port, auth, from, smtpSpec.To, []byte(mail))
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// omServicePorts returns the Service ports of the OM, shared by the headless and the per-pod
+// Services.
+func omServicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{Name: "rpc", Port: omRPCPort},
+		{Name: "ui", Port: omHTTPPort},
+	}
+}
+
+// omService generates the headless service of the OM.
+func omService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	service := headlessService(cluster, omComponent, omServicePorts())
+	// OM Ratis peers must resolve each other to form a ring before any replica reports ready.
+	service.Spec.PublishNotReadyAddresses = true
+	return addAPIProxyPort(cluster, omComponent, redirectUIPort(cluster, omComponent, service))
+}
+
+// omStatefulSet generates the OM StatefulSet. The OM waits for the SCM before starting. With
+// persistent storage the om-init Job initializes the primordial metadata volume before this
+// StatefulSet is created; the entrypoint env fallback remains for ephemeral storage and for the
+// extra HA replicas, which initialize their own volumes on first boot.
+func omStatefulSet(cluster *ozonev1alpha1.OzoneCluster) *appsv1.StatefulSet {
+	dataMount := corev1.VolumeMount{Name: "data", MountPath: dataMountPath}
+	env := []corev1.EnvVar{
+		{Name: "ENSURE_OM_INITIALIZED", Value: dataMountPath + "/metadata/om/current/VERSION"},
+	}
+	if initJobsEnabled(cluster) && *replicasOrDefault(cluster.Spec.OM.Replicas, 1) <= 1 {
+		env = nil
+	}
+	pod := corev1.PodSpec{
+		InitContainers: waitForInitContainers(cluster, scmComponent, scmHTTPPort, scmAddresses(cluster)...),
+		Containers: []corev1.Container{{
+			Name:            omComponent,
+			Image:           componentImage(cluster, &cluster.Spec.OM.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "om"},
+			Env:             env,
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    []corev1.VolumeMount{dataMount},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(omRPCPort)},
+			}, startupWindow(&cluster.Spec.OM.ComponentSpec, metadataStartupWindow)),
+			LivenessProbe: adminCheckProbe(omComponent),
+		}},
+	}
+	if *replicasOrDefault(cluster.Spec.OM.Replicas, 1) > 1 {
+		// Hand off OM Ratis leadership before shutdown so rolling restarts stay client-transparent.
+		pod.Containers[0].Lifecycle = leadershipTransferPreStop(omComponent)
+	}
+	pod.Affinity = componentAntiAffinity(cluster, omComponent, cluster.Spec.OM.AntiAffinity)
+	pod.TopologySpreadConstraints = zoneSpreadConstraints(cluster, omComponent)
+	applyComponentSpec(&pod, &cluster.Spec.OM.ComponentSpec)
+	applyUIAuth(cluster, omComponent, &pod, omHTTPPort)
+	applyAPIProxy(cluster, omComponent, &pod, omHTTPPort)
+
+	sts := baseStatefulSet(cluster, omComponent, replicasOrDefault(cluster.Spec.OM.Replicas, 1),
+		pod, prometheusAnnotations(cluster, omHTTPPort))
+	applyComponentStorage(sts, &cluster.Spec.OM.Storage)
+	applyUpdateStrategy(sts, &cluster.Spec.OM.ComponentSpec)
+	return sts
+}
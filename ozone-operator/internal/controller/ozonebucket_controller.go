@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// OzoneBucketReconciler reconciles OzoneBucket objects into buckets of their OzoneCluster,
+// applying quotas and reflecting usage back into status.
+type OzoneBucketReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone sh` commands inside the OM pod.
+	Executor PodExecutor
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonebuckets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonebuckets/status,verbs=get;update;patch
+
+// Reconcile ensures the Ozone bucket exists with the configured quotas and refreshes its usage.
+func (r *OzoneBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	bucket := &ozonev1alpha1.OzoneBucket{}
+	if err := r.Get(ctx, req.NamespacedName, bucket); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	clusterKey := types.NamespacedName{Namespace: bucket.Namespace, Name: bucket.Spec.Cluster}
+	if err := r.Get(ctx, clusterKey, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.setCondition(ctx, bucket, metav1.ConditionFalse,
+				"ClusterNotFound", fmt.Sprintf("OzoneCluster %q does not exist", bucket.Spec.Cluster))
+		}
+		return ctrl.Result{}, err
+	}
+
+	name := bucket.Spec.BucketName
+	if name == "" {
+		name = bucket.Name
+	}
+	address := bucket.Spec.Volume + "/" + name
+	pod := omPodName(cluster)
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+		"ozone", "sh", "bucket", "info", address); err != nil {
+		createArgs := append([]string{"ozone", "sh", "bucket", "create"}, quotaArgs(bucket.Spec.Quota)...)
+		if key := bucketEncryptionKey(cluster, bucket); key != "" {
+			createArgs = append(createArgs, "-k", key)
+		}
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			append(createArgs, address)...); err != nil {
+			if setErr := r.setCondition(ctx, bucket, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("creating bucket failed: %v", err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+	} else if args := quotaArgs(bucket.Spec.Quota); len(args) > 0 {
+		setquota := append([]string{"ozone", "sh", "bucket", "setquota"}, args...)
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			append(setquota, address)...); err != nil {
+			if setErr := r.setCondition(ctx, bucket, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("applying quota failed: %v", err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+	}
+
+	output, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+		"ozone", "sh", "bucket", "info", address)
+	if err == nil {
+		if usage, parseErr := parseUsage(output); parseErr == nil {
+			bucket.Status.Usage = usage
+			recordBucketUsage(bucket, name, usage)
+		}
+	}
+	if err := r.setCondition(ctx, bucket, metav1.ConditionTrue, "BucketReady",
+		fmt.Sprintf("bucket %s exists with the configured quotas", address)); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: quotaUsageRequeue}, nil
+}
+
+// bucketEncryptionKey resolves the encryption key of a bucket: its own spec.encryptionKey, or the
+// cluster-wide default bucket key when TDE is configured.
+func bucketEncryptionKey(cluster *ozonev1alpha1.OzoneCluster, bucket *ozonev1alpha1.OzoneBucket) string {
+	if bucket.Spec.EncryptionKey != "" {
+		return bucket.Spec.EncryptionKey
+	}
+	if encryption := clusterEncryption(cluster); encryption != nil {
+		return encryption.BucketKey
+	}
+	return ""
+}
+
+// setCondition writes the Provisioned condition and persists the status.
+func (r *OzoneBucketReconciler) setCondition(ctx context.Context, bucket *ozonev1alpha1.OzoneBucket,
+	status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&bucket.Status.Conditions, metav1.Condition{
+		Type:               ConditionProvisioned,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: bucket.Generation,
+	})
+	return r.Status().Update(ctx, bucket)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneBucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneBucket{}).
+		Complete(r)
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// OzoneBucketPolicyReconciler reconciles OzoneBucketPolicy objects, continuously re-applying the
+// declared bucket ACLs and tenant user assignments so direct API changes are corrected.
+type OzoneBucketPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone sh` and `ozone tenant` commands inside the OM pod.
+	Executor PodExecutor
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonebucketpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonebucketpolicies/status,verbs=get;update;patch
+
+// Reconcile applies the declared ACL list with setacl and assigns missing tenant users, then
+// requeues so drift is corrected on the next pass.
+func (r *OzoneBucketPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	policy := &ozonev1alpha1.OzoneBucketPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	clusterKey := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.Cluster}
+	if err := r.Get(ctx, clusterKey, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.setCondition(ctx, policy, metav1.ConditionFalse,
+				"ClusterNotFound", fmt.Sprintf("OzoneCluster %q does not exist", policy.Spec.Cluster))
+		}
+		return ctrl.Result{}, err
+	}
+
+	address := policy.Spec.Volume + "/" + policy.Spec.Bucket
+	pod := omPodName(cluster)
+	if acls := aclStrings(policy.Spec.ACLs); len(acls) > 0 {
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			"ozone", "sh", "bucket", "setacl", "--acls="+strings.Join(acls, ","), address); err != nil {
+			if setErr := r.setCondition(ctx, policy, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("applying bucket ACLs failed: %v", err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+	}
+
+	if err := r.assignTenantUsers(ctx, cluster, policy); err != nil {
+		if setErr := r.setCondition(ctx, policy, metav1.ConditionFalse, "ExecFailed",
+			fmt.Sprintf("assigning tenant users failed: %v", err)); setErr != nil {
+			return ctrl.Result{}, setErr
+		}
+		return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+	}
+
+	if err := r.setCondition(ctx, policy, metav1.ConditionTrue, "PolicyApplied",
+		fmt.Sprintf("%d ACL entries applied to %s", len(policy.Spec.ACLs), address)); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: quotaUsageRequeue}, nil
+}
+
+// aclStrings renders the ACL entries in the type:name:rights form of the shell; world entries
+// carry an empty name.
+func aclStrings(acls []ozonev1alpha1.OzoneACLSpec) []string {
+	rendered := make([]string, 0, len(acls))
+	for _, acl := range acls {
+		rendered = append(rendered, fmt.Sprintf("%s:%s:%s", acl.Type, acl.Name, acl.Rights))
+	}
+	return rendered
+}
+
+// assignTenantUsers assigns the declared users to the tenant, skipping users the tenant already
+// lists so repeated reconciliations stay idempotent.
+func (r *OzoneBucketPolicyReconciler) assignTenantUsers(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, policy *ozonev1alpha1.OzoneBucketPolicy) error {
+	tenant := policy.Spec.Tenant
+	if tenant == nil || len(tenant.Users) == 0 {
+		return nil
+	}
+	pod := omPodName(cluster)
+	assigned, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+		"ozone", "tenant", "user", "list", tenant.Name)
+	if err != nil {
+		return fmt.Errorf("listing users of tenant %s: %w", tenant.Name, err)
+	}
+	for _, user := range tenant.Users {
+		if strings.Contains(assigned, user) {
+			continue
+		}
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			"ozone", "tenant", "user", "assign", user, "--tenant="+tenant.Name); err != nil {
+			return fmt.Errorf("assigning user %s to tenant %s: %w", user, tenant.Name, err)
+		}
+	}
+	return nil
+}
+
+// setCondition writes the Provisioned condition and persists the status.
+func (r *OzoneBucketPolicyReconciler) setCondition(ctx context.Context,
+	policy *ozonev1alpha1.OzoneBucketPolicy, status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               ConditionProvisioned,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: policy.Generation,
+	})
+	return r.Status().Update(ctx, policy)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneBucketPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneBucketPolicy{}).
+		Complete(r)
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// OzoneClusterBackupReconciler drives the restore Job, the backup destination probe, and the
+// disaster recovery pairing of an OzoneCluster on its own workqueue, so a slow or unreachable backup endpoint
+// delays only these checks and never the structural cluster reconcile.
+type OzoneClusterBackupReconciler struct {
+	*OzoneClusterReconciler
+}
+
+// Reconcile refreshes the backup and disaster recovery state of one OzoneCluster.
+func (r *OzoneClusterBackupReconciler) Reconcile(ctx context.Context,
+	req ctrl.Request) (ctrl.Result, error) {
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if err := r.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// Backups and the disaster recovery sync need running pods; the status update at the end of
+	// hibernation re-triggers this controller.
+	if cluster.Status.Hibernation != nil {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.reconcileRestore(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	backupResult, err := r.reconcileBackup(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, backupResult)
+	drResult, err := r.reconcileDisasterRecovery(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, drResult)
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	return result, nil
+}
+
+// SetupWithManager registers the backup reconciler with the manager under its own name, so it
+// gets a workqueue separate from the structural OzoneCluster controller.
+func (r *OzoneClusterBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ozonecluster-backup").
+		For(&ozonev1alpha1.OzoneCluster{}).
+		Complete(r)
+}
@@ -0,0 +1,446 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+	"github.com/peterxcli/ozone/ozone-operator/pkg/ozoneclient"
+)
+
+// Condition types reported on OzoneCluster status.
+const (
+	// ConditionAvailable is True once every requested datanode is ready.
+	ConditionAvailable = "Available"
+
+	// ConditionDurable is False when any component runs on ephemeral (emptyDir) storage.
+	ConditionDurable = "Durable"
+
+	// ConditionSafemodeProlonged is True while SCM stays in safemode beyond the configured grace
+	// period, typically waiting for datanodes after a full-cluster restart.
+	ConditionSafemodeProlonged = "SafemodeProlonged"
+
+	// ConditionContainersHealthy is False while Recon reports unhealthy or missing containers.
+	ConditionContainersHealthy = "ContainersHealthy"
+
+	// ConditionReconcileSucceeded reports the outcome of the last reconcile; its reason carries
+	// the error class (transient, config, quorum, storage) when reconciliation fails.
+	ConditionReconcileSucceeded = "ReconcileSucceeded"
+)
+
+// OzoneClusterReconciler reconciles OzoneCluster objects into the ConfigMap, Services and
+// StatefulSets that make up a running Ozone cluster. The periodic observation and backup loops
+// run on separate workqueues, see OzoneClusterMonitoringReconciler and
+// OzoneClusterBackupReconciler; both share this reconciler's clients and helpers.
+type OzoneClusterReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone admin` commands inside cluster pods; optional in tests.
+	Executor PodExecutor
+
+	// HTTPClient queries component REST APIs such as Recon; a default client is used when nil.
+	HTTPClient *http.Client
+
+	// LogReader fetches container logs for crash diagnostics; capture is skipped when nil.
+	LogReader PodLogReader
+
+	// Recorder emits Events, e.g. references to captured crash diagnostics; optional in tests.
+	Recorder record.EventRecorder
+
+	// AdminClient overrides how the ozoneclient of a cluster is built; tests inject an
+	// ozoneclient.Mock here. When nil, admin operations exec the `ozone` CLI through Executor.
+	AdminClient func(cluster *ozonev1alpha1.OzoneCluster) ozoneclient.Client
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozoneclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozoneclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozoneconfigtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets;deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services;configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile drives the child resources of one OzoneCluster towards its spec.
+func (r *OzoneClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if err := r.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if cluster.DeletionTimestamp != nil {
+		return r.handleDeletion(ctx, cluster)
+	}
+	if err := r.reconcileFinalizer(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+
+	if err := r.checkClusterIdentity(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.pinDefaultStorageClass(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileReconfig(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.applyScaleDownGuard(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	scalingResult, err := applyS3GatewayScalingWindows(cluster, time.Now())
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	hibernationResult, err := r.reconcileHibernation(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileTopology(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileInitJobs(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileQuotaHeadroom(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+
+	for _, obj := range desiredObjects(cluster) {
+		if component, gated := gatedOnInitJob(cluster, obj); gated {
+			log.V(1).Info("waiting for the initialization job before creating the StatefulSet",
+				"component", component)
+			continue
+		}
+		if err := r.apply(ctx, cluster, obj); err != nil {
+			log.Error(err, "failed to apply child resource",
+				"kind", obj.GetObjectKind().GroupVersionKind().Kind, "name", obj.GetName())
+			return r.failed(ctx, cluster, err)
+		}
+	}
+
+	if err := r.cleanupReconWorkload(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.cleanupS3GatewayStatefulSet(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileDisabledComponents(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	if err := r.reconcileS3EndpointSecret(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+
+	// While hibernating or resuming the pods the operational reconcilers talk to are stopped,
+	// so only the hibernation progress is driven until the cluster is fully back. The monitoring
+	// and backup controllers check the same status and stand down too.
+	if cluster.Status.Hibernation != nil {
+		if err := r.updateStatus(ctx, cluster); err != nil {
+			return r.failed(ctx, cluster, err)
+		}
+		return mergeResults(hibernationResult, scalingResult), nil
+	}
+
+	result, err := r.reconcileBalancer(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	maintenanceResult, err := r.reconcileMaintenance(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, maintenanceResult)
+	recoveryResult, err := r.reconcilePodRecovery(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, recoveryResult)
+	rebootstrapResult, err := r.reconcileOMRebootstrap(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, rebootstrapResult)
+	compactionResult, err := r.reconcileOMCompaction(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, compactionResult)
+	result = mergeResults(result, scalingResult)
+	if initJobsEnabled(cluster) && !initJobsComplete(cluster) {
+		result = mergeResults(result, ctrl.Result{RequeueAfter: initJobRetry})
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               ConditionReconcileSucceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReconcileComplete",
+		Message:            "all reconcile loops completed",
+		ObservedGeneration: cluster.Generation,
+	})
+	if err := r.updateStatus(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	return result, nil
+}
+
+// failed records a classified reconcile failure in the ReconcileSucceeded condition and turns it
+// into the retry behavior of its class: transient errors keep the workqueue's exponential
+// backoff, quorum and storage problems requeue on slower fixed intervals, and configuration
+// errors stop retrying until the spec changes instead of hot-looping on a terminal state.
+func (r *OzoneClusterReconciler) failed(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	err error) (ctrl.Result, error) {
+	class := classifyError(err)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               ConditionReconcileSucceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             string(class),
+		Message:            err.Error(),
+		ObservedGeneration: cluster.Generation,
+	})
+	// Best-effort: surfacing the original error matters more than this status write.
+	_ = r.Status().Update(ctx, cluster)
+	switch class {
+	case errorConfig:
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case errorQuorumLost:
+		logf.FromContext(ctx).Error(err, "reconcile paused until the quorum recovers")
+		return ctrl.Result{RequeueAfter: quorumLostRequeue}, nil
+	case errorStorage:
+		logf.FromContext(ctx).Error(err, "reconcile paused on a storage failure")
+		return ctrl.Result{RequeueAfter: storageRequeue}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+// mergeResults combines reconcile results, keeping the earliest requeue.
+func mergeResults(a, b ctrl.Result) ctrl.Result {
+	if a.RequeueAfter == 0 {
+		return b
+	}
+	if b.RequeueAfter == 0 || a.RequeueAfter < b.RequeueAfter {
+		return a
+	}
+	return b
+}
+
+// desiredObjects assembles every child resource of the cluster in creation order.
+func desiredObjects(cluster *ozonev1alpha1.OzoneCluster) []client.Object {
+	objects := []client.Object{
+		configMap(cluster),
+		clientConfigMap(cluster),
+	}
+	if len(cluster.Spec.ExtraConfigFiles) > 0 {
+		objects = append(objects, extraConfigMap(cluster))
+	}
+	if cluster.Spec.Monitoring != nil {
+		objects = append(objects, alertRulesConfigMap(cluster))
+	}
+	// With an external control plane the cluster only contributes datanode capacity.
+	if cluster.Spec.ExternalControlPlane == nil {
+		objects = append(objects,
+			scmService(cluster),
+			scmStatefulSet(cluster),
+			omService(cluster),
+			omStatefulSet(cluster))
+		if cluster.Spec.PerPodServices {
+			for _, service := range perPodServices(cluster) {
+				objects = append(objects, service)
+			}
+		}
+	}
+	objects = append(objects,
+		datanodeService(cluster),
+		datanodeStatefulSet(cluster))
+	if cluster.Spec.Recon != nil {
+		objects = append(objects, reconService(cluster))
+		if reconUsesExternalDatabase(cluster) {
+			objects = append(objects, reconDeployment(cluster))
+		} else {
+			objects = append(objects, reconStatefulSet(cluster))
+		}
+	}
+	if cluster.Spec.S3Gateway != nil {
+		if s3gRateLimit(cluster) != nil {
+			objects = append(objects, s3gRateLimitConfigMap(cluster))
+		}
+		objects = append(objects, s3gService(cluster))
+		zones := s3gZones(cluster)
+		if len(zones) == 0 {
+			objects = append(objects, s3gDeployment(cluster))
+		} else {
+			for _, zone := range zones {
+				objects = append(objects, s3gZoneService(cluster, zone), s3gZoneDeployment(cluster, zone))
+			}
+		}
+	}
+	if cluster.Spec.HttpFS != nil {
+		objects = append(objects, httpfsService(cluster), httpfsDeployment(cluster))
+	}
+	if cluster.Spec.KMS != nil {
+		objects = append(objects, kmsService(cluster), kmsStatefulSet(cluster))
+	}
+	if cluster.Spec.ExternalAccess != nil {
+		objects = append(objects, externalSCMService(cluster), externalOMService(cluster),
+			externalDatanodeConfigMap(cluster))
+	}
+	if cluster.Spec.GatewayAPI != nil {
+		objects = append(objects, gatewayRoutes(cluster)...)
+	}
+	return objects
+}
+
+// cleanupReconWorkload deletes the Recon workload flavor not in use, so switching between the
+// embedded-database StatefulSet and the external-database Deployment leaves a single workload.
+func (r *OzoneClusterReconciler) cleanupReconWorkload(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if cluster.Spec.Recon == nil {
+		return nil
+	}
+	var stale client.Object = &appsv1.StatefulSet{}
+	if !reconUsesExternalDatabase(cluster) {
+		stale = &appsv1.Deployment{}
+	}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, reconComponent)}
+	if err := r.Get(ctx, key, stale); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return r.Delete(ctx, stale)
+}
+
+// cleanupS3GatewayStatefulSet removes the StatefulSet left behind by operator versions that ran
+// the stateless S3 Gateway as a StatefulSet, so the migration to a Deployment is automatic.
+func (r *OzoneClusterReconciler) cleanupS3GatewayStatefulSet(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if cluster.Spec.S3Gateway == nil {
+		return nil
+	}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, s3gComponent)}
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, key, sts); err == nil {
+		if err := r.Delete(ctx, sts); err != nil {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	// With per-zone services the zone-less Deployment is superseded by the zone Deployments.
+	if len(s3gZones(cluster)) == 0 {
+		return nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return r.Delete(ctx, deployment)
+}
+
+// apply creates the object if it does not exist yet, otherwise updates it in place.
+func (r *OzoneClusterReconciler) apply(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster,
+	obj client.Object) error {
+	if err := controllerutil.SetControllerReference(cluster, obj, r.Scheme); err != nil {
+		return err
+	}
+	existing := obj.DeepCopyObject().(client.Object)
+	err := r.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, cluster) {
+		// Resources from a previous deployment method (e.g. the community Helm chart) are only
+		// taken over when the user opted in; the update below then writes the controller
+		// reference set above and reconciles the resource towards this spec.
+		if !cluster.Spec.AdoptExisting {
+			return fmt.Errorf("%s %s/%s exists but is not owned by this OzoneCluster; "+
+				"set spec.adoptExisting to take it over",
+				obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+		}
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return r.Update(ctx, obj)
+}
+
+// updateStatus persists the status fields and conditions the reconcile loops above set on the
+// cluster. Datanode readiness, endpoints, and the Available/Durable conditions are aggregated
+// separately by OzoneClusterStatusReconciler, so they stay fresh while provisioning is blocked.
+func (r *OzoneClusterReconciler) updateStatus(ctx context.Context, cluster *ozonev1alpha1.OzoneCluster) error {
+	return r.Status().Update(ctx, cluster)
+}
+
+// clustersForNode enqueues every OzoneCluster when a Node changes, so cordons and maintenance
+// annotations are picked up without waiting for the next periodic requeue.
+func (r *OzoneClusterReconciler) clustersForNode(ctx context.Context, _ client.Object) []reconcile.Request {
+	clusters := &ozonev1alpha1.OzoneClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: clusters.Items[i].Namespace,
+			Name:      clusters.Items[i].Name,
+		}})
+	}
+	return requests
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneCluster{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.clustersForNode)).
+		Complete(r)
+}
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// OzoneClusterMonitoringReconciler runs the periodic observation loops of an OzoneCluster —
+// safemode, Recon container health, JVM telemetry, crash diagnostics, and config drift — on its
+// own workqueue. Split from the structural controller, a flapping health endpoint requeues only
+// these checks instead of re-running the entire cluster reconcile; findings reach the rest of the
+// operator through the OzoneCluster status.
+type OzoneClusterMonitoringReconciler struct {
+	*OzoneClusterReconciler
+}
+
+// Reconcile refreshes the health-derived status of one OzoneCluster.
+func (r *OzoneClusterMonitoringReconciler) Reconcile(ctx context.Context,
+	req ctrl.Request) (ctrl.Result, error) {
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if err := r.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// While hibernating or resuming, the pods these checks talk to are stopped; the status update
+	// at the end of hibernation re-triggers this controller.
+	if cluster.Status.Hibernation != nil {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.reconcileSafemode(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	healthResult, err := r.reconcileReconHealth(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, healthResult)
+	jvmResult, err := r.reconcileJVMHealth(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, jvmResult)
+	if err := r.reconcileDiagnostics(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	driftResult, err := r.reconcileConfigDrift(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, driftResult)
+	storageResult, err := r.reconcileStorageHealth(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, storageResult)
+	expandResult, err := r.reconcileStorageExpansion(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, expandResult)
+	volumeResult, err := r.reconcileVolumeFailures(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, volumeResult)
+	scanResult, err := r.reconcileScannerStatus(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, scanResult)
+	backlogResult, err := r.reconcileDeletionBacklog(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, backlogResult)
+	identityResult, err := r.reconcileClusterIdentity(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, identityResult)
+	schedulingResult, err := r.reconcilePodScheduling(ctx, cluster)
+	if err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	result = mergeResults(result, schedulingResult)
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return r.failed(ctx, cluster, err)
+	}
+	return result, nil
+}
+
+// SetupWithManager registers the monitoring reconciler with the manager under its own name, so it
+// gets a workqueue separate from the structural OzoneCluster controller.
+func (r *OzoneClusterMonitoringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ozonecluster-monitoring").
+		For(&ozonev1alpha1.OzoneCluster{}).
+		Complete(r)
+}
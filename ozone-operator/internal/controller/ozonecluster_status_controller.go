@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// OzoneClusterStatusReconciler aggregates the observed state of an OzoneCluster — datanode
+// readiness, endpoints, and the Available and Durable conditions — from its workloads. It watches
+// the cluster's StatefulSets and patches only the status subresource, so status stays fresh even
+// while the slower provisioning reconcile is blocked or erroring.
+type OzoneClusterStatusReconciler struct {
+	client.Client
+}
+
+// Reconcile refreshes the aggregated status of one OzoneCluster.
+func (r *OzoneClusterStatusReconciler) Reconcile(ctx context.Context,
+	req ctrl.Request) (ctrl.Result, error) {
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if err := r.Get(ctx, req.NamespacedName, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	base := cluster.DeepCopy()
+
+	datanodes := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, datanodeComponent)}
+	if err := r.Get(ctx, key, datanodes); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	cluster.Status.ReadyDatanodes = datanodes.Status.ReadyReplicas
+	updateEndpoints(cluster)
+	condition := metav1.Condition{
+		Type:               ConditionAvailable,
+		Status:             metav1.ConditionFalse,
+		Reason:             "DatanodesNotReady",
+		Message:            "waiting for datanodes to become ready",
+		ObservedGeneration: cluster.Generation,
+	}
+	if datanodes.Status.ReadyReplicas > 0 &&
+		datanodes.Status.ReadyReplicas == *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DatanodesReady"
+		condition.Message = "all requested datanodes are ready"
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	durable := metav1.Condition{
+		Type:               ConditionDurable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PersistentStorage",
+		Message:            "all components use persistent volume claims",
+		ObservedGeneration: cluster.Generation,
+	}
+	if hasEphemeralStorage(cluster) {
+		durable.Status = metav1.ConditionFalse
+		durable.Reason = "EphemeralStorage"
+		durable.Message = "one or more components run on emptyDir storage; data does not survive pod restarts"
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, durable)
+
+	return ctrl.Result{}, r.Status().Patch(ctx, cluster, client.MergeFrom(base))
+}
+
+// SetupWithManager registers the status reconciler with the manager under its own name. Watching
+// the owned StatefulSets re-aggregates status as soon as workload readiness changes.
+func (r *OzoneClusterStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ozonecluster-status").
+		For(&ozonev1alpha1.OzoneCluster{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&appsv1.Deployment{}).
+		Complete(r)
+}
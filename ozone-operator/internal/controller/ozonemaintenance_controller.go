@@ -0,0 +1,370 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// maintenanceStepRetry spaces out maintenance steps and retries after failures, giving restarted
+// pods time to come back before the next one is taken down.
+const maintenanceStepRetry = 30 * time.Second
+
+// OzoneMaintenanceReconciler executes planned OzoneMaintenance operations against the targeted
+// cluster, one CR per maintenance, recording an audit trail in the CR status.
+type OzoneMaintenanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone admin` commands inside cluster pods; required for datanode actions.
+	Executor PodExecutor
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonemaintenances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonemaintenances/status,verbs=get;update;patch
+
+// appendMaintenanceLog records one audit trail entry.
+func appendMaintenanceLog(maintenance *ozonev1alpha1.OzoneMaintenance, target, message string) {
+	maintenance.Status.Log = append(maintenance.Status.Log, ozonev1alpha1.MaintenanceLogEntry{
+		Time:    metav1.Now(),
+		Target:  target,
+		Message: message,
+	})
+}
+
+// finishMaintenance moves the maintenance into a terminal phase.
+func finishMaintenance(maintenance *ozonev1alpha1.OzoneMaintenance, phase ozonev1alpha1.MaintenancePhase) {
+	now := metav1.Now()
+	maintenance.Status.Phase = phase
+	maintenance.Status.CompletionTime = &now
+}
+
+// Reconcile drives one planned maintenance: wait for its window, process every target with the
+// required Ozone-side preparation, and record each step in the status audit trail.
+func (r *OzoneMaintenanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	maintenance := &ozonev1alpha1.OzoneMaintenance{}
+	if err := r.Get(ctx, req.NamespacedName, maintenance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if maintenance.Status.Phase == ozonev1alpha1.MaintenanceCompleted ||
+		maintenance.Status.Phase == ozonev1alpha1.MaintenanceFailed {
+		return ctrl.Result{}, nil
+	}
+
+	if result, done := r.checkWindow(maintenance); done {
+		return result, r.Status().Update(ctx, maintenance)
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	clusterKey := types.NamespacedName{Namespace: maintenance.Namespace, Name: maintenance.Spec.Cluster}
+	if err := r.Get(ctx, clusterKey, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			appendMaintenanceLog(maintenance, maintenance.Spec.Cluster,
+				fmt.Sprintf("OzoneCluster %q does not exist", maintenance.Spec.Cluster))
+			finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+			return ctrl.Result{}, r.Status().Update(ctx, maintenance)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if maintenance.Status.StartTime == nil {
+		now := metav1.Now()
+		maintenance.Status.StartTime = &now
+	}
+	maintenance.Status.Phase = ozonev1alpha1.MaintenanceInProgress
+
+	targets, err := r.resolveTargets(ctx, cluster, maintenance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var result ctrl.Result
+	switch maintenance.Spec.Action {
+	case ozonev1alpha1.MaintenanceActionRestart:
+		result, err = r.restartTargets(ctx, cluster, maintenance, targets)
+	case ozonev1alpha1.MaintenanceActionDecommission, ozonev1alpha1.MaintenanceActionMaintenanceMode:
+		result, err = r.datanodeAdminTargets(ctx, cluster, maintenance, targets)
+	case ozonev1alpha1.MaintenanceActionTransferLeader:
+		result, err = r.transferLeadership(ctx, cluster, maintenance)
+	default:
+		appendMaintenanceLog(maintenance, "", fmt.Sprintf("unknown action %q", maintenance.Spec.Action))
+		finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, r.Status().Update(ctx, maintenance)
+}
+
+// checkWindow enforces the maintenance window: before it opens the maintenance stays Pending, and
+// a maintenance not started before the window closes fails with WindowExpired.
+func (r *OzoneMaintenanceReconciler) checkWindow(maintenance *ozonev1alpha1.OzoneMaintenance) (ctrl.Result, bool) {
+	window := maintenance.Spec.Window
+	if window == nil || maintenance.Status.StartTime != nil {
+		return ctrl.Result{}, false
+	}
+	now := time.Now()
+	if window.End != nil && now.After(window.End.Time) {
+		appendMaintenanceLog(maintenance, "",
+			fmt.Sprintf("window closed at %s before the maintenance started", window.End.Format(time.RFC3339)))
+		finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+		return ctrl.Result{}, true
+	}
+	if window.Start != nil && now.Before(window.Start.Time) {
+		maintenance.Status.Phase = ozonev1alpha1.MaintenancePending
+		return ctrl.Result{RequeueAfter: time.Until(window.Start.Time)}, true
+	}
+	return ctrl.Result{}, false
+}
+
+// resolveTargets returns the pod names the maintenance applies to, sorted for a stable order.
+func (r *OzoneMaintenanceReconciler) resolveTargets(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, maintenance *ozonev1alpha1.OzoneMaintenance) ([]string, error) {
+	if len(maintenance.Spec.Pods) > 0 {
+		targets := append([]string(nil), maintenance.Spec.Pods...)
+		sort.Strings(targets)
+		return targets, nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(componentLabels(cluster, maintenance.Spec.Component))); err != nil {
+		return nil, err
+	}
+	targets := make([]string, 0, len(pods.Items))
+	for i := range pods.Items {
+		targets = append(targets, pods.Items[i].Name)
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// completedTarget reports whether a target was already processed.
+func completedTarget(maintenance *ozonev1alpha1.OzoneMaintenance, target string) bool {
+	for _, done := range maintenance.Status.CompletedTargets {
+		if done == target {
+			return true
+		}
+	}
+	return false
+}
+
+// restartTargets restarts the target pods one at a time, waiting for the previously restarted pod
+// to be ready again before taking down the next one.
+func (r *OzoneMaintenanceReconciler) restartTargets(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, maintenance *ozonev1alpha1.OzoneMaintenance,
+	targets []string) (ctrl.Result, error) {
+	for _, done := range maintenance.Status.CompletedTargets {
+		ready, err := r.podReady(ctx, cluster.Namespace, done)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !ready {
+			return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+		}
+	}
+	for _, target := range targets {
+		if completedTarget(maintenance, target) {
+			continue
+		}
+		pod := &corev1.Pod{}
+		key := types.NamespacedName{Namespace: cluster.Namespace, Name: target}
+		if err := r.Get(ctx, key, pod); err != nil {
+			if errors.IsNotFound(err) {
+				appendMaintenanceLog(maintenance, target, "pod not found, skipped")
+				maintenance.Status.CompletedTargets = append(maintenance.Status.CompletedTargets, target)
+				continue
+			}
+			return ctrl.Result{}, err
+		}
+		r.handOffLeadership(ctx, cluster, maintenance, target)
+		if err := r.Delete(ctx, pod); err != nil {
+			appendMaintenanceLog(maintenance, target, fmt.Sprintf("restart failed: %v", err))
+			return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+		}
+		appendMaintenanceLog(maintenance, target, "pod restarted")
+		maintenance.Status.CompletedTargets = append(maintenance.Status.CompletedTargets, target)
+		return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+	}
+	finishMaintenance(maintenance, ozonev1alpha1.MaintenanceCompleted)
+	return ctrl.Result{}, nil
+}
+
+// datanodeAdminTargets applies the decommission or maintenance-mode admin command to every target
+// datanode through the SCM, so SCM prepares the containers before the datanodes go away.
+func (r *OzoneMaintenanceReconciler) datanodeAdminTargets(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, maintenance *ozonev1alpha1.OzoneMaintenance,
+	targets []string) (ctrl.Result, error) {
+	if maintenance.Spec.Component != datanodeComponent {
+		appendMaintenanceLog(maintenance, "",
+			fmt.Sprintf("action %s only applies to datanodes, not %q",
+				maintenance.Spec.Action, maintenance.Spec.Component))
+		finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+		return ctrl.Result{}, nil
+	}
+	if r.Executor == nil {
+		return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+	}
+	subcommand := "maintenance"
+	if maintenance.Spec.Action == ozonev1alpha1.MaintenanceActionDecommission {
+		subcommand = "decommission"
+	}
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	failed := false
+	for _, target := range targets {
+		if completedTarget(maintenance, target) {
+			continue
+		}
+		host := target + "." + componentName(cluster, datanodeComponent)
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent,
+			"ozone", "admin", "datanode", subcommand, host); err != nil {
+			appendMaintenanceLog(maintenance, host, fmt.Sprintf("%s failed: %v", subcommand, err))
+			failed = true
+			continue
+		}
+		appendMaintenanceLog(maintenance, host, subcommand+" issued")
+		maintenance.Status.CompletedTargets = append(maintenance.Status.CompletedTargets, target)
+	}
+	if failed {
+		return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+	}
+	finishMaintenance(maintenance, ozonev1alpha1.MaintenanceCompleted)
+	return ctrl.Result{}, nil
+}
+
+// transferLeadership hands the Ratis leadership of an HA SCM or OM ring to the single pod named
+// in spec.pods, or to a random peer when no pod is named. Ratis node ids equal pod names in
+// clusters managed by this operator, so the pod name is passed to the transfer command as-is.
+func (r *OzoneMaintenanceReconciler) transferLeadership(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, maintenance *ozonev1alpha1.OzoneMaintenance) (ctrl.Result, error) {
+	component := maintenance.Spec.Component
+	if component != scmComponent && component != omComponent {
+		appendMaintenanceLog(maintenance, "",
+			fmt.Sprintf("action %s only applies to scm and om, not %q",
+				ozonev1alpha1.MaintenanceActionTransferLeader, component))
+		finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+		return ctrl.Result{}, nil
+	}
+	if len(maintenance.Spec.Pods) > 1 {
+		appendMaintenanceLog(maintenance, "",
+			fmt.Sprintf("action %s accepts at most one target pod", ozonev1alpha1.MaintenanceActionTransferLeader))
+		finishMaintenance(maintenance, ozonev1alpha1.MaintenanceFailed)
+		return ctrl.Result{}, nil
+	}
+	if r.Executor == nil {
+		return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+	}
+	target := ""
+	command := []string{"ozone", "admin", component, "transfer", "-r"}
+	if len(maintenance.Spec.Pods) == 1 {
+		target = maintenance.Spec.Pods[0]
+		command = []string{"ozone", "admin", component, "transfer", "-n", target}
+	}
+	pod := componentName(cluster, component) + "-0"
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, component, command...); err != nil {
+		appendMaintenanceLog(maintenance, target, fmt.Sprintf("leadership transfer failed: %v", err))
+		return ctrl.Result{RequeueAfter: maintenanceStepRetry}, nil
+	}
+	if target == "" {
+		appendMaintenanceLog(maintenance, target, "leadership handed to a random peer")
+	} else {
+		appendMaintenanceLog(maintenance, target, "leadership transferred")
+	}
+	finishMaintenance(maintenance, ozonev1alpha1.MaintenanceCompleted)
+	return ctrl.Result{}, nil
+}
+
+// handOffLeadership transfers Ratis leadership away from an HA SCM or OM pod that is about to be
+// restarted, so clients follow the new leader instead of waiting out an election timeout.
+// Best-effort: on any failure the restart proceeds and the pod's preStop hook retries the handoff.
+func (r *OzoneMaintenanceReconciler) handOffLeadership(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, maintenance *ozonev1alpha1.OzoneMaintenance, target string) {
+	component := maintenance.Spec.Component
+	if r.Executor == nil || (component != scmComponent && component != omComponent) {
+		return
+	}
+	roles, err := r.Executor.Exec(ctx, cluster.Namespace, target, component,
+		"ozone", "admin", component, "roles")
+	if err != nil || ratisLeader(roles) != target {
+		return
+	}
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, target, component,
+		"ozone", "admin", component, "transfer", "-r"); err != nil {
+		appendMaintenanceLog(maintenance, target,
+			fmt.Sprintf("leadership handoff before restart failed, relying on the preStop hook: %v", err))
+		return
+	}
+	appendMaintenanceLog(maintenance, target, "leadership transferred away before restart")
+}
+
+// ratisLeader returns the node id reported as LEADER by `ozone admin om roles` or
+// `ozone admin scm roles`, or "" when the output lists no leader.
+func ratisLeader(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ':' || r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if field == "LEADER" {
+				return fields[0]
+			}
+		}
+	}
+	return ""
+}
+
+// podReady reports whether a pod exists and has the Ready condition True.
+func (r *OzoneMaintenanceReconciler) podReady(ctx context.Context, namespace, name string) (bool, error) {
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneMaintenanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneMaintenance{}).
+		Complete(r)
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import "testing"
+
+func TestRatisLeader(t *testing.T) {
+	omRoles := `
+cluster-om-0 : FOLLOWER (cluster-om-0.cluster-om)
+cluster-om-1 : LEADER (cluster-om-1.cluster-om)
+cluster-om-2 : FOLLOWER (cluster-om-2.cluster-om)
+`
+	scmRoles := `
+cluster-scm-0:FOLLOWER
+cluster-scm-1:FOLLOWER
+cluster-scm-2:LEADER
+`
+	cases := []struct {
+		name   string
+		output string
+		leader string
+	}{
+		{name: "om roles", output: omRoles, leader: "cluster-om-1"},
+		{name: "scm roles", output: scmRoles, leader: "cluster-scm-2"},
+		{name: "no leader during election", output: "cluster-om-0 : FOLLOWER\ncluster-om-1 : FOLLOWER\n"},
+		{name: "empty output"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if leader := ratisLeader(tc.output); leader != tc.leader {
+				t.Errorf("expected leader %q, got %q", tc.leader, leader)
+			}
+		})
+	}
+}
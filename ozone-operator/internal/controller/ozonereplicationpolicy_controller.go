@@ -0,0 +1,272 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionReplicating is True while replication jobs exist for every selected bucket.
+	ConditionReplicating = "Replicating"
+
+	// defaultReplicationSchedule runs each replication job hourly unless the policy overrides it.
+	defaultReplicationSchedule = "0 * * * *"
+
+	// replicationLagRequeue refreshes per-bucket lag between job completions.
+	replicationLagRequeue = time.Minute
+)
+
+// OzoneReplicationPolicyReconciler reconciles OzoneReplicationPolicy objects into the CronJobs
+// that copy selected buckets from the source cluster to the target.
+type OzoneReplicationPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonereplicationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonereplicationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile maintains one replication CronJob per selected bucket and refreshes per-bucket lag.
+func (r *OzoneReplicationPolicyReconciler) Reconcile(ctx context.Context,
+	req ctrl.Request) (ctrl.Result, error) {
+	policy := &ozonev1alpha1.OzoneReplicationPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	source := &ozonev1alpha1.OzoneCluster{}
+	sourceKey := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.SourceCluster}
+	if err := r.Get(ctx, sourceKey, source); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.updateStatus(ctx, policy, nil, metav1.Condition{
+				Type:    ConditionReplicating,
+				Status:  metav1.ConditionFalse,
+				Reason:  "SourceClusterNotFound",
+				Message: fmt.Sprintf("source OzoneCluster %q does not exist", policy.Spec.SourceCluster),
+			})
+		}
+		return ctrl.Result{}, err
+	}
+
+	targetOM, err := r.targetOMAddress(ctx, policy)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.updateStatus(ctx, policy, nil, metav1.Condition{
+				Type:    ConditionReplicating,
+				Status:  metav1.ConditionFalse,
+				Reason:  "TargetClusterNotFound",
+				Message: fmt.Sprintf("target OzoneCluster %q does not exist", policy.Spec.Target.Cluster),
+			})
+		}
+		return ctrl.Result{}, err
+	}
+
+	jobs := make(map[string]*batchv1.CronJob, len(policy.Spec.Buckets))
+	for _, bucket := range policy.Spec.Buckets {
+		job := replicationCronJob(policy, source, targetOM, bucket)
+		if err := controllerutil.SetControllerReference(policy, job, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		existing := &batchv1.CronJob{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, existing)
+		if errors.IsNotFound(err) {
+			if err := r.Create(ctx, job); err != nil {
+				return ctrl.Result{}, err
+			}
+		} else if err != nil {
+			return ctrl.Result{}, err
+		} else {
+			job.ResourceVersion = existing.ResourceVersion
+			job.Status = existing.Status
+			if err := r.Update(ctx, job); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		jobs[job.Name] = job
+	}
+	if err := r.cleanupStaleJobs(ctx, policy, jobs); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	buckets, err := r.bucketStatuses(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	condition := metav1.Condition{
+		Type:    ConditionReplicating,
+		Status:  metav1.ConditionTrue,
+		Reason:  "JobsScheduled",
+		Message: fmt.Sprintf("replication jobs scheduled for %d buckets", len(policy.Spec.Buckets)),
+	}
+	if policy.Spec.Suspend {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Suspended"
+		condition.Message = "replication is suspended by spec.suspend"
+	}
+	if err := r.updateStatus(ctx, policy, buckets, condition); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: replicationLagRequeue}, nil
+}
+
+// targetOMAddress resolves the OM address of the replication target, either from a managed
+// OzoneCluster in the same namespace or from the configured remote address.
+func (r *OzoneReplicationPolicyReconciler) targetOMAddress(ctx context.Context,
+	policy *ozonev1alpha1.OzoneReplicationPolicy) (string, error) {
+	if policy.Spec.Target.Cluster == "" {
+		return policy.Spec.Target.OMAddress, nil
+	}
+	target := &ozonev1alpha1.OzoneCluster{}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.Target.Cluster}
+	if err := r.Get(ctx, key, target); err != nil {
+		return "", err
+	}
+	return externalPodFQDN(target, omComponent, 0), nil
+}
+
+// replicationJobName returns the CronJob name for one replicated bucket.
+func replicationJobName(policy *ozonev1alpha1.OzoneReplicationPolicy,
+	bucket ozonev1alpha1.ReplicatedBucket) string {
+	return fmt.Sprintf("%s-%s-%s", policy.Name, bucket.Volume, bucket.Bucket)
+}
+
+// replicationCronJob builds the CronJob that copies one bucket from the source cluster to the
+// target OM. The copy runs `ozone fs -cp -f` between ofs authorities, so no distcp cluster is
+// needed and repeated runs converge on the target contents.
+func replicationCronJob(policy *ozonev1alpha1.OzoneReplicationPolicy,
+	source *ozonev1alpha1.OzoneCluster, targetOM string,
+	bucket ozonev1alpha1.ReplicatedBucket) *batchv1.CronJob {
+	schedule := policy.Spec.Schedule
+	if schedule == "" {
+		schedule = defaultReplicationSchedule
+	}
+	sourcePath := fmt.Sprintf("ofs://%s/%s/%s/", externalPodFQDN(source, omComponent, 0),
+		bucket.Volume, bucket.Bucket)
+	targetPath := fmt.Sprintf("ofs://%s/%s/%s/", targetOM, bucket.Volume, bucket.Bucket)
+	labels := map[string]string{
+		"app":                          "ozone-replication",
+		"ozone.apache.org/policy":      policy.Name,
+		"ozone.apache.org/volume":      bucket.Volume,
+		"ozone.apache.org/bucket-name": bucket.Bucket,
+	}
+	suspend := policy.Spec.Suspend
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      replicationJobName(policy, bucket),
+			Namespace: policy.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          schedule,
+			Suspend:           &suspend,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers: []corev1.Container{{
+								Name:    "replicate",
+								Image:   source.Spec.Image,
+								Args:    []string{"ozone", "fs", "-cp", "-f", sourcePath + "*", targetPath},
+								EnvFrom: configEnvFrom(source),
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cleanupStaleJobs deletes replication CronJobs for buckets no longer selected by the policy.
+func (r *OzoneReplicationPolicyReconciler) cleanupStaleJobs(ctx context.Context,
+	policy *ozonev1alpha1.OzoneReplicationPolicy, desired map[string]*batchv1.CronJob) error {
+	jobs := &batchv1.CronJobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(policy.Namespace),
+		client.MatchingLabels{"ozone.apache.org/policy": policy.Name}); err != nil {
+		return err
+	}
+	for i := range jobs.Items {
+		if _, ok := desired[jobs.Items[i].Name]; ok {
+			continue
+		}
+		if err := r.Delete(ctx, &jobs.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// bucketStatuses derives per-bucket sync time and lag from the CronJobs' last successful runs.
+func (r *OzoneReplicationPolicyReconciler) bucketStatuses(ctx context.Context,
+	policy *ozonev1alpha1.OzoneReplicationPolicy) ([]ozonev1alpha1.ReplicatedBucketStatus, error) {
+	statuses := make([]ozonev1alpha1.ReplicatedBucketStatus, 0, len(policy.Spec.Buckets))
+	for _, bucket := range policy.Spec.Buckets {
+		status := ozonev1alpha1.ReplicatedBucketStatus{Volume: bucket.Volume, Bucket: bucket.Bucket}
+		job := &batchv1.CronJob{}
+		key := types.NamespacedName{Namespace: policy.Namespace, Name: replicationJobName(policy, bucket)}
+		if err := r.Get(ctx, key, job); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if job.Status.LastSuccessfulTime != nil {
+			status.LastSyncTime = job.Status.LastSuccessfulTime
+			status.Lag = &metav1.Duration{Duration: time.Since(job.Status.LastSuccessfulTime.Time).Truncate(time.Second)}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// updateStatus writes the observed buckets and condition back to the policy.
+func (r *OzoneReplicationPolicyReconciler) updateStatus(ctx context.Context,
+	policy *ozonev1alpha1.OzoneReplicationPolicy, buckets []ozonev1alpha1.ReplicatedBucketStatus,
+	condition metav1.Condition) error {
+	condition.ObservedGeneration = policy.Generation
+	policy.Status.Buckets = buckets
+	meta.SetStatusCondition(&policy.Status.Conditions, condition)
+	return r.Status().Update(ctx, policy)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneReplicationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneReplicationPolicy{}).
+		Owns(&batchv1.CronJob{}).
+		Complete(r)
+}
@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// snapshotTimestampFormat suffixes periodic snapshot names; second granularity keeps the names
+// unique and sortable.
+const snapshotTimestampFormat = "20060102-150405"
+
+// OzoneSnapshotReconciler reconciles OzoneSnapshot objects into Ozone-native bucket snapshots,
+// taking them once or on an interval and pruning them per the retention rules.
+type OzoneSnapshotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone sh snapshot` commands inside the OM pod.
+	Executor PodExecutor
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonesnapshots/status,verbs=get;update;patch
+
+// Reconcile takes the next due snapshot of the bucket and prunes retained snapshots that fell out
+// of the retention rules.
+func (r *OzoneSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	snapshot := &ozonev1alpha1.OzoneSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	clusterKey := types.NamespacedName{Namespace: snapshot.Namespace, Name: snapshot.Spec.Cluster}
+	if err := r.Get(ctx, clusterKey, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.setCondition(ctx, snapshot, metav1.ConditionFalse,
+				"ClusterNotFound", fmt.Sprintf("OzoneCluster %q does not exist", snapshot.Spec.Cluster))
+		}
+		return ctrl.Result{}, err
+	}
+
+	bucketPath := "/" + snapshot.Spec.Volume + "/" + snapshot.Spec.Bucket
+	pod := omPodName(cluster)
+	now := metav1.Now()
+	if name, due := nextSnapshotName(snapshot, now.Time); due {
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			"ozone", "sh", "snapshot", "create", bucketPath, name); err != nil {
+			if setErr := r.setCondition(ctx, snapshot, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("creating snapshot %s failed: %v", name, err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+		snapshot.Status.Snapshots = append(snapshot.Status.Snapshots,
+			ozonev1alpha1.CreatedSnapshot{Name: name, CreationTime: &now})
+		snapshot.Status.LastSnapshotTime = &now
+	}
+
+	if err := r.pruneSnapshots(ctx, cluster, snapshot, bucketPath, now.Time); err != nil {
+		if setErr := r.setCondition(ctx, snapshot, metav1.ConditionFalse, "ExecFailed",
+			fmt.Sprintf("pruning snapshots failed: %v", err)); setErr != nil {
+			return ctrl.Result{}, setErr
+		}
+		return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+	}
+
+	exportRequeue, err := r.reconcileSnapshotExport(ctx, cluster, snapshot)
+	if err != nil {
+		if setErr := r.setCondition(ctx, snapshot, metav1.ConditionFalse, "ExportFailed", err.Error()); setErr != nil {
+			return ctrl.Result{}, setErr
+		}
+		return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+	}
+
+	if err := r.setCondition(ctx, snapshot, metav1.ConditionTrue, "SnapshotsReady",
+		fmt.Sprintf("%d snapshots of %s retained", len(snapshot.Status.Snapshots), bucketPath)); err != nil {
+		return ctrl.Result{}, err
+	}
+	requeue := snapshotRequeueAfter(snapshot, now.Time)
+	if exportRequeue > 0 && (requeue == 0 || exportRequeue < requeue) {
+		requeue = exportRequeue
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// nextSnapshotName reports whether a snapshot is due now and under which name. One-shot objects
+// use the base name once; periodic objects add a timestamp suffix every interval.
+func nextSnapshotName(snapshot *ozonev1alpha1.OzoneSnapshot, now time.Time) (string, bool) {
+	base := snapshot.Spec.SnapshotName
+	if base == "" {
+		base = snapshot.Name
+	}
+	if snapshot.Spec.Interval == nil {
+		return base, snapshot.Status.LastSnapshotTime == nil
+	}
+	if last := snapshot.Status.LastSnapshotTime; last != nil &&
+		now.Sub(last.Time) < snapshot.Spec.Interval.Duration {
+		return "", false
+	}
+	return base + "-" + now.UTC().Format(snapshotTimestampFormat), true
+}
+
+// pruneSnapshots deletes the retained snapshots that violate the retention rules, oldest first.
+func (r *OzoneSnapshotReconciler) pruneSnapshots(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, snapshot *ozonev1alpha1.OzoneSnapshot,
+	bucketPath string, now time.Time) error {
+	retention := snapshot.Spec.Retention
+	if retention == nil {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+	pod := omPodName(cluster)
+	kept := snapshot.Status.Snapshots
+	for len(kept) > 0 {
+		oldest := kept[0]
+		overCount := retention.MaxCount != nil && int32(len(kept)) > *retention.MaxCount
+		overAge := retention.MaxAge != nil && oldest.CreationTime != nil &&
+			now.Sub(oldest.CreationTime.Time) > retention.MaxAge.Duration
+		if !overCount && !overAge {
+			break
+		}
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			"ozone", "sh", "snapshot", "delete", bucketPath, oldest.Name); err != nil {
+			snapshot.Status.Snapshots = kept
+			return fmt.Errorf("deleting snapshot %s: %w", oldest.Name, err)
+		}
+		log.Info("pruned bucket snapshot", "bucket", bucketPath, "snapshot", oldest.Name)
+		kept = kept[1:]
+	}
+	snapshot.Status.Snapshots = kept
+	return nil
+}
+
+// snapshotRequeueAfter returns when this object needs attention next: the next periodic snapshot,
+// or the next age-based pruning. Zero means no requeue is needed.
+func snapshotRequeueAfter(snapshot *ozonev1alpha1.OzoneSnapshot, now time.Time) time.Duration {
+	var next time.Duration
+	if interval := snapshot.Spec.Interval; interval != nil {
+		next = interval.Duration
+		if last := snapshot.Status.LastSnapshotTime; last != nil {
+			if remaining := interval.Duration - now.Sub(last.Time); remaining > 0 {
+				next = remaining
+			}
+		}
+	}
+	if retention := snapshot.Spec.Retention; retention != nil && retention.MaxAge != nil &&
+		len(snapshot.Status.Snapshots) > 0 {
+		oldest := snapshot.Status.Snapshots[0]
+		expiry := retention.MaxAge.Duration
+		if oldest.CreationTime != nil {
+			if remaining := expiry - now.Sub(oldest.CreationTime.Time); remaining > 0 {
+				expiry = remaining
+			} else {
+				expiry = time.Second
+			}
+		}
+		if next == 0 || expiry < next {
+			next = expiry
+		}
+	}
+	return next
+}
+
+// setCondition writes the Provisioned condition and persists the status.
+func (r *OzoneSnapshotReconciler) setCondition(ctx context.Context,
+	snapshot *ozonev1alpha1.OzoneSnapshot, status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
+		Type:               ConditionProvisioned,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: snapshot.Generation,
+	})
+	return r.Status().Update(ctx, snapshot)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneSnapshot{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestNextSnapshotName(t *testing.T) {
+	now := time.Date(2024, 4, 2, 12, 30, 0, 0, time.UTC)
+	hour := &metav1.Duration{Duration: time.Hour}
+	recent := metav1.NewTime(now.Add(-10 * time.Minute))
+	stale := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	tests := []struct {
+		name     string
+		snapshot ozonev1alpha1.OzoneSnapshot
+		wantName string
+		wantDue  bool
+	}{
+		{
+			name: "one-shot uses the object name once",
+			snapshot: ozonev1alpha1.OzoneSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "before-upgrade"},
+			},
+			wantName: "before-upgrade",
+			wantDue:  true,
+		},
+		{
+			name: "one-shot already taken",
+			snapshot: ozonev1alpha1.OzoneSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "before-upgrade"},
+				Status:     ozonev1alpha1.OzoneSnapshotStatus{LastSnapshotTime: &recent},
+			},
+			wantDue: false,
+		},
+		{
+			name: "periodic first snapshot gets a timestamp suffix",
+			snapshot: ozonev1alpha1.OzoneSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "hourly"},
+				Spec:       ozonev1alpha1.OzoneSnapshotSpec{SnapshotName: "backup", Interval: hour},
+			},
+			wantName: "backup-20240402-123000",
+			wantDue:  true,
+		},
+		{
+			name: "periodic snapshot not due within the interval",
+			snapshot: ozonev1alpha1.OzoneSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "hourly"},
+				Spec:       ozonev1alpha1.OzoneSnapshotSpec{Interval: hour},
+				Status:     ozonev1alpha1.OzoneSnapshotStatus{LastSnapshotTime: &recent},
+			},
+			wantDue: false,
+		},
+		{
+			name: "periodic snapshot due after the interval elapsed",
+			snapshot: ozonev1alpha1.OzoneSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "hourly"},
+				Spec:       ozonev1alpha1.OzoneSnapshotSpec{Interval: hour},
+				Status:     ozonev1alpha1.OzoneSnapshotStatus{LastSnapshotTime: &stale},
+			},
+			wantName: "hourly-20240402-123000",
+			wantDue:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, due := nextSnapshotName(&tt.snapshot, now)
+			if due != tt.wantDue {
+				t.Fatalf("due = %v, want %v", due, tt.wantDue)
+			}
+			if due && name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestSnapshotRequeueAfter(t *testing.T) {
+	now := time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC)
+	taken := metav1.NewTime(now.Add(-20 * time.Minute))
+
+	oneShot := &ozonev1alpha1.OzoneSnapshot{}
+	if got := snapshotRequeueAfter(oneShot, now); got != 0 {
+		t.Errorf("one-shot without retention should not requeue, got %v", got)
+	}
+
+	periodic := &ozonev1alpha1.OzoneSnapshot{
+		Spec:   ozonev1alpha1.OzoneSnapshotSpec{Interval: &metav1.Duration{Duration: time.Hour}},
+		Status: ozonev1alpha1.OzoneSnapshotStatus{LastSnapshotTime: &taken},
+	}
+	if got := snapshotRequeueAfter(periodic, now); got != 40*time.Minute {
+		t.Errorf("periodic requeue = %v, want 40m", got)
+	}
+
+	expired := metav1.NewTime(now.Add(-40 * time.Minute))
+	aged := &ozonev1alpha1.OzoneSnapshot{
+		Spec: ozonev1alpha1.OzoneSnapshotSpec{
+			Retention: &ozonev1alpha1.SnapshotRetentionSpec{MaxAge: &metav1.Duration{Duration: 30 * time.Minute}},
+		},
+		Status: ozonev1alpha1.OzoneSnapshotStatus{
+			Snapshots: []ozonev1alpha1.CreatedSnapshot{{Name: "old", CreationTime: &expired}},
+		},
+	}
+	if got := snapshotRequeueAfter(aged, now); got != time.Second {
+		t.Errorf("an already-expired snapshot should requeue promptly, got %v", got)
+	}
+}
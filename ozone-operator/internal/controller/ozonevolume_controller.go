@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionProvisioned is True once the volume or bucket exists in OM with its quotas applied.
+	ConditionProvisioned = "Provisioned"
+
+	// quotaUsageRequeue is how often quota usage is re-read from OM.
+	quotaUsageRequeue = 5 * time.Minute
+
+	// quotaExecRetry is the retry interval after a failed `ozone sh` invocation, typically while
+	// the cluster is still starting.
+	quotaExecRetry = time.Minute
+)
+
+// omPodName returns the OM pod `ozone sh` commands are executed in.
+func omPodName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return componentName(cluster, omComponent) + "-0"
+}
+
+// omUsageInfo is the usage part of the JSON printed by `ozone sh volume info` and
+// `ozone sh bucket info`.
+type omUsageInfo struct {
+	UsedBytes     int64 `json:"usedBytes"`
+	UsedNamespace int64 `json:"usedNamespace"`
+}
+
+// quotaArgs renders the quota flags shared by the volume and bucket `ozone sh` subcommands.
+func quotaArgs(quota *ozonev1alpha1.QuotaSpec) []string {
+	var args []string
+	if quota == nil {
+		return args
+	}
+	if quota.Space != nil {
+		args = append(args, "--space-quota", fmt.Sprintf("%dB", quota.Space.Value()))
+	}
+	if quota.Namespace != nil {
+		args = append(args, "--namespace-quota", fmt.Sprintf("%d", *quota.Namespace))
+	}
+	return args
+}
+
+// parseUsage decodes the usage fields out of an `ozone sh ... info` JSON response.
+func parseUsage(output string) (*ozonev1alpha1.QuotaUsageStatus, error) {
+	var info omUsageInfo
+	if err := json.Unmarshal([]byte(output), &info); err != nil {
+		return nil, err
+	}
+	now := metav1.Now()
+	return &ozonev1alpha1.QuotaUsageStatus{
+		UsedBytes:     info.UsedBytes,
+		UsedNamespace: info.UsedNamespace,
+		LastProbeTime: &now,
+	}, nil
+}
+
+// OzoneVolumeReconciler reconciles OzoneVolume objects into volumes of their OzoneCluster,
+// applying quotas and reflecting usage back into status.
+type OzoneVolumeReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Executor runs `ozone sh` commands inside the OM pod.
+	Executor PodExecutor
+}
+
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonevolumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ozone.apache.org,resources=ozonevolumes/status,verbs=get;update;patch
+
+// Reconcile ensures the Ozone volume exists with the configured quotas and refreshes its usage.
+func (r *OzoneVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	volume := &ozonev1alpha1.OzoneVolume{}
+	if err := r.Get(ctx, req.NamespacedName, volume); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	clusterKey := types.NamespacedName{Namespace: volume.Namespace, Name: volume.Spec.Cluster}
+	if err := r.Get(ctx, clusterKey, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.setCondition(ctx, volume, metav1.ConditionFalse,
+				"ClusterNotFound", fmt.Sprintf("OzoneCluster %q does not exist", volume.Spec.Cluster))
+		}
+		return ctrl.Result{}, err
+	}
+
+	name := volume.Spec.VolumeName
+	if name == "" {
+		name = volume.Name
+	}
+	pod := omPodName(cluster)
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+		"ozone", "sh", "volume", "info", name); err != nil {
+		createArgs := append([]string{"ozone", "sh", "volume", "create"}, quotaArgs(volume.Spec.Quota)...)
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			append(createArgs, name)...); err != nil {
+			if setErr := r.setCondition(ctx, volume, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("creating volume failed: %v", err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+	} else if args := quotaArgs(volume.Spec.Quota); len(args) > 0 {
+		setquota := append([]string{"ozone", "sh", "volume", "setquota"}, args...)
+		if _, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+			append(setquota, name)...); err != nil {
+			if setErr := r.setCondition(ctx, volume, metav1.ConditionFalse, "ExecFailed",
+				fmt.Sprintf("applying quota failed: %v", err)); setErr != nil {
+				return ctrl.Result{}, setErr
+			}
+			return ctrl.Result{RequeueAfter: quotaExecRetry}, nil
+		}
+	}
+
+	output, err := r.Executor.Exec(ctx, cluster.Namespace, pod, omComponent,
+		"ozone", "sh", "volume", "info", name)
+	if err == nil {
+		if usage, parseErr := parseUsage(output); parseErr == nil {
+			volume.Status.Usage = usage
+			recordVolumeUsage(volume, name, usage)
+		}
+	}
+	if err := r.setCondition(ctx, volume, metav1.ConditionTrue, "VolumeReady",
+		fmt.Sprintf("volume %s exists with the configured quotas", name)); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: quotaUsageRequeue}, nil
+}
+
+// setCondition writes the Provisioned condition and persists the status.
+func (r *OzoneVolumeReconciler) setCondition(ctx context.Context, volume *ozonev1alpha1.OzoneVolume,
+	status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&volume.Status.Conditions, metav1.Condition{
+		Type:               ConditionProvisioned,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: volume.Generation,
+	})
+	return r.Status().Update(ctx, volume)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *OzoneVolumeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ozonev1alpha1.OzoneVolume{}).
+		Complete(r)
+}
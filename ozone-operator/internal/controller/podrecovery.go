@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// defaultPodRecoveryTimeout is how long a pod may stay stuck on a lost node before force-deletion.
+const defaultPodRecoveryTimeout = 5 * time.Minute
+
+// podRecoveryTimeout returns the configured grace timeout of stuck pod recovery.
+func podRecoveryTimeout(cluster *ozonev1alpha1.OzoneCluster) time.Duration {
+	if recovery := cluster.Spec.PodRecovery; recovery != nil && recovery.GraceTimeout != nil {
+		return recovery.GraceTimeout.Duration
+	}
+	return defaultPodRecoveryTimeout
+}
+
+// reconcilePodRecovery force-deletes pods stuck on lost nodes. After a node disappears its
+// StatefulSet pods stay Terminating (or NotReady) indefinitely because the kubelet never confirms
+// the deletion, which blocks the replacement pod. Once the configured grace timeout has passed
+// and the node is gone or unready, the pod is deleted with grace period zero so the StatefulSet
+// can reschedule it.
+func (r *OzoneClusterReconciler) reconcilePodRecovery(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	recovery := cluster.Spec.PodRecovery
+	if recovery == nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+
+	components := []string{datanodeComponent}
+	if recovery.IncludeS3Gateway {
+		components = append(components, s3gComponent)
+	}
+	timeout := podRecoveryTimeout(cluster)
+	requeue := false
+	for _, component := range components {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+			client.MatchingLabels(componentLabels(cluster, component))); err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			stuck, err := r.podStuckOnLostNode(ctx, pod, timeout)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !stuck {
+				if pod.DeletionTimestamp != nil {
+					// Still inside the grace timeout; come back to check again.
+					requeue = true
+				}
+				continue
+			}
+			log.Info("force-deleting pod stuck on lost node", "pod", pod.Name, "node", pod.Spec.NodeName)
+			zero := int64(0)
+			if err := r.Delete(ctx, pod, &client.DeleteOptions{GracePeriodSeconds: &zero}); err != nil &&
+				!errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	if requeue {
+		return ctrl.Result{RequeueAfter: timeout}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// podStuckOnLostNode reports whether a pod has been stuck longer than the grace timeout on a node
+// that no longer exists or is unready.
+func (r *OzoneClusterReconciler) podStuckOnLostNode(ctx context.Context, pod *corev1.Pod,
+	timeout time.Duration) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return false, nil
+	}
+	node := &corev1.Node{}
+	err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+	if err == nil && nodeReady(node) {
+		return false, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	// The node is gone or unready; only act once the pod has been stuck past the grace timeout.
+	if pod.DeletionTimestamp != nil {
+		return time.Since(pod.DeletionTimestamp.Time) > timeout, nil
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			return time.Since(condition.LastTransitionTime.Time) > timeout, nil
+		}
+	}
+	return false, nil
+}
+
+// nodeReady reports whether a node has a Ready condition with status True.
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
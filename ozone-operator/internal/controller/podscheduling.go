@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionPodsSchedulable is False while component pods cannot start, with the per-pod reasons
+// (unschedulable, image pull failures, unbound PVCs) in the message, so the diagnosis is on the
+// OzoneCluster instead of spread over the pods.
+const ConditionPodsSchedulable = "PodsSchedulable"
+
+// podSchedulingRequeue is how often the component pods are rechecked for startup failures.
+const podSchedulingRequeue = 2 * time.Minute
+
+// imagePullFailureReasons are the waiting reasons of a container that cannot get its image.
+var imagePullFailureReasons = map[string]bool{
+	"ImagePullBackOff":  true,
+	"ErrImagePull":      true,
+	"ErrImageNeverPull": true,
+}
+
+// pendingPodReason returns why a pod cannot start, or empty for a pod that is past those
+// failures. Scheduling failures carry the scheduler's own message, which already names the unmet
+// constraint (nodeSelector, unbound PVCs, insufficient resources); image failures carry the
+// kubelet's waiting message.
+func pendingPodReason(pod *corev1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return pod.Name + " Pending: " + condition.Message
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...)
+	for i := range statuses {
+		waiting := statuses[i].State.Waiting
+		if waiting != nil && imagePullFailureReasons[waiting.Reason] {
+			return pod.Name + " " + waiting.Reason + ": " + waiting.Message
+		}
+	}
+	return ""
+}
+
+// reconcilePodScheduling rolls the startup failures of all component pods up into the
+// PodsSchedulable condition, so an unschedulable datanode or a wrong image tag is diagnosed on
+// the OzoneCluster without spelunking through the pods manually.
+func (r *OzoneClusterReconciler) reconcilePodScheduling(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	pods := &corev1.PodList{}
+	clusterSelector := map[string]string{"app": "ozone", "ozone.apache.org/cluster": cluster.Name}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(clusterSelector)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var reasons []string
+	for i := range pods.Items {
+		if reason := pendingPodReason(&pods.Items[i]); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionPodsSchedulable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PodsStarting",
+		Message:            "no component pod is stuck on scheduling or image pull failures",
+		ObservedGeneration: cluster.Generation,
+	}
+	if len(reasons) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PodStartupFailures"
+		condition.Message = strings.Join(reasons, "; ")
+	}
+	previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionPodsSchedulable)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	if (previous == nil && condition.Status == metav1.ConditionFalse) ||
+		(previous != nil && previous.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return ctrl.Result{RequeueAfter: podSchedulingRequeue}, nil
+}
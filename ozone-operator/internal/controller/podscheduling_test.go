@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPendingPodReason(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  corev1.Pod
+		want string
+	}{
+		{
+			name: "running pod reports nothing",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-om-0"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			want: "",
+		},
+		{
+			name: "unschedulable pod carries the scheduler message",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-datanode-3"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					Conditions: []corev1.PodCondition{{
+						Type:    corev1.PodScheduled,
+						Status:  corev1.ConditionFalse,
+						Reason:  corev1.PodReasonUnschedulable,
+						Message: "0/5 nodes are available: 5 node(s) didn't match Pod's node affinity/selector.",
+					}},
+				},
+			},
+			want: "cluster-datanode-3 Pending: 0/5 nodes are available: 5 node(s) didn't match Pod's node affinity/selector.",
+		},
+		{
+			name: "image pull backoff is reported also on a running pod",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-scm-0"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: "Back-off pulling image \"apache/ozone:9.9.9\"",
+						}},
+					}},
+				},
+			},
+			want: "cluster-scm-0 ImagePullBackOff: Back-off pulling image \"apache/ozone:9.9.9\"",
+		},
+		{
+			name: "waiting on a normal startup reports nothing",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-recon-0"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+							Reason: "PodInitializing",
+						}},
+					}},
+				},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pendingPodReason(&tt.pod); got != tt.want {
+				t.Errorf("pendingPodReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
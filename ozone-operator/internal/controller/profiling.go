@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// profilingEnabled reports whether a component is covered by spec.profiling.
+func profilingEnabled(cluster *ozonev1alpha1.OzoneCluster, component string) bool {
+	profiling := cluster.Spec.Profiling
+	if profiling == nil {
+		return false
+	}
+	if len(profiling.Components) == 0 {
+		return true
+	}
+	for _, name := range profiling.Components {
+		if name == component {
+			return true
+		}
+	}
+	return false
+}
+
+// withProfilingAnnotations adds the Pyroscope discovery annotations to a profiled component's pod
+// template, so annotation-based scrapers pick the pods up without extra configuration.
+func withProfilingAnnotations(cluster *ozonev1alpha1.OzoneCluster, component string,
+	annotations map[string]string) map[string]string {
+	if !profilingEnabled(cluster, component) {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["pyroscope.io/scrape"] = "true"
+	annotations["pyroscope.io/application-name"] = componentName(cluster, component)
+	return annotations
+}
+
+// applyProfiling feeds the profiler agent configuration into the main container of a profiled
+// component; the image's startup hook attaches the agent when these are present.
+func applyProfiling(cluster *ozonev1alpha1.OzoneCluster, component string, pod *corev1.PodSpec) {
+	if !profilingEnabled(cluster, component) {
+		return
+	}
+	pod.Containers[0].Env = append(pod.Containers[0].Env,
+		corev1.EnvVar{Name: "PYROSCOPE_SERVER_ADDRESS", Value: cluster.Spec.Profiling.ServerAddress},
+		corev1.EnvVar{Name: "PYROSCOPE_APPLICATION_NAME", Value: componentName(cluster, component)},
+	)
+}
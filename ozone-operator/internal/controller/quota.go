@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionQuotaExceeded is True while the namespace ResourceQuotas cannot fit the desired
+// components, with the exact missing resources in the message, so an undersized quota shows up on
+// the OzoneCluster instead of as pods stuck Pending.
+const ConditionQuotaExceeded = "QuotaExceeded"
+
+// addScaled adds replicas times the quantity to one resource of the usage list.
+func addScaled(usage corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity, replicas int64) {
+	total := usage[name]
+	total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*replicas, quantity.Format))
+	usage[name] = total
+}
+
+// podQuotaUsage adds the quota-relevant resources of replicas pods of one pod spec: the pod
+// count and the cpu and memory requests and limits of the main containers.
+func podQuotaUsage(usage corev1.ResourceList, pod *corev1.PodSpec, replicas int64) {
+	addScaled(usage, corev1.ResourcePods, *resource.NewQuantity(1, resource.DecimalSI), replicas)
+	for i := range pod.Containers {
+		for name, quantity := range pod.Containers[i].Resources.Requests {
+			switch name {
+			case corev1.ResourceCPU:
+				addScaled(usage, corev1.ResourceRequestsCPU, quantity, replicas)
+			case corev1.ResourceMemory:
+				addScaled(usage, corev1.ResourceRequestsMemory, quantity, replicas)
+			}
+		}
+		for name, quantity := range pod.Containers[i].Resources.Limits {
+			switch name {
+			case corev1.ResourceCPU:
+				addScaled(usage, corev1.ResourceLimitsCPU, quantity, replicas)
+			case corev1.ResourceMemory:
+				addScaled(usage, corev1.ResourceLimitsMemory, quantity, replicas)
+			}
+		}
+	}
+}
+
+// desiredQuotaUsage aggregates the quota-relevant resources the desired workloads of the cluster
+// claim in total: pods, cpu and memory requests and limits, and the PVC count and storage of the
+// StatefulSet volumeClaimTemplates.
+func desiredQuotaUsage(cluster *ozonev1alpha1.OzoneCluster) corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	for _, obj := range desiredObjects(cluster) {
+		switch workload := obj.(type) {
+		case *appsv1.StatefulSet:
+			replicas := int64(*replicasOrDefault(workload.Spec.Replicas, 1))
+			podQuotaUsage(usage, &workload.Spec.Template.Spec, replicas)
+			for i := range workload.Spec.VolumeClaimTemplates {
+				claim := &workload.Spec.VolumeClaimTemplates[i]
+				addScaled(usage, corev1.ResourcePersistentVolumeClaims,
+					*resource.NewQuantity(1, resource.DecimalSI), replicas)
+				if storage, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+					addScaled(usage, corev1.ResourceRequestsStorage, storage, replicas)
+				}
+			}
+		case *appsv1.Deployment:
+			podQuotaUsage(usage, &workload.Spec.Template.Spec, int64(*replicasOrDefault(workload.Spec.Replicas, 1)))
+		}
+	}
+	return usage
+}
+
+// quotaShortfalls lists the resources of one ResourceQuota the desired usage does not fit into,
+// each with the exact missing amount. The bare cpu and memory quota spellings count against the
+// requests, matching how the quota controller tracks them.
+func quotaShortfalls(desired corev1.ResourceList, quota *corev1.ResourceQuota) []string {
+	names := make([]string, 0, len(quota.Spec.Hard))
+	for name := range quota.Spec.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var shortfalls []string
+	for _, name := range names {
+		key := corev1.ResourceName(name)
+		switch key {
+		case corev1.ResourceCPU:
+			key = corev1.ResourceRequestsCPU
+		case corev1.ResourceMemory:
+			key = corev1.ResourceRequestsMemory
+		}
+		need, ok := desired[key]
+		if !ok {
+			continue
+		}
+		hard := quota.Spec.Hard[corev1.ResourceName(name)]
+		if need.Cmp(hard) <= 0 {
+			continue
+		}
+		missing := need.DeepCopy()
+		missing.Sub(hard)
+		shortfalls = append(shortfalls, fmt.Sprintf("%s: the components need %s but ResourceQuota %s caps it at %s "+
+			"(%s missing)", name, need.String(), quota.Name, hard.String(), missing.String()))
+	}
+	return shortfalls
+}
+
+// reconcileQuotaHeadroom compares the total resources the desired components claim against every
+// ResourceQuota of the namespace and reports undersized quotas through the QuotaExceeded
+// condition, before the pods sit Pending with no explanation at the OzoneCluster level. The
+// comparison is against the quota limits, not the free headroom, so it flags specs that can never
+// fit; creation is not blocked, the quota controller remains the authority.
+func (r *OzoneClusterReconciler) reconcileQuotaHeadroom(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(cluster.Namespace)); err != nil {
+		return err
+	}
+	var shortfalls []string
+	if len(quotas.Items) > 0 {
+		desired := desiredQuotaUsage(cluster)
+		for i := range quotas.Items {
+			shortfalls = append(shortfalls, quotaShortfalls(desired, &quotas.Items[i])...)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "QuotaSufficient",
+		Message:            "the namespace quotas leave room for the desired components",
+		ObservedGeneration: cluster.Generation,
+	}
+	if len(shortfalls) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "QuotaExceeded"
+		condition.Message = strings.Join(shortfalls, "; ")
+	}
+	previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionQuotaExceeded)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	if (previous == nil && condition.Status == metav1.ConditionTrue) ||
+		(previous != nil && previous.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return nil
+}
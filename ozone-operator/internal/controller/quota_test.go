@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func quotaWithHard(hard corev1.ResourceList) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}
+}
+
+func TestQuotaShortfalls(t *testing.T) {
+	desired := corev1.ResourceList{
+		corev1.ResourcePods:            resource.MustParse("9"),
+		corev1.ResourceRequestsCPU:     resource.MustParse("10"),
+		corev1.ResourceRequestsMemory:  resource.MustParse("96Gi"),
+		corev1.ResourceRequestsStorage: resource.MustParse("500Gi"),
+	}
+	tests := []struct {
+		name         string
+		hard         corev1.ResourceList
+		wantCount    int
+		wantContains string
+	}{
+		{
+			name: "sufficient quota reports nothing",
+			hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("16"),
+				corev1.ResourceRequestsMemory: resource.MustParse("128Gi"),
+			},
+			wantCount: 0,
+		},
+		{
+			name: "undersized memory reports the exact missing amount",
+			hard: corev1.ResourceList{
+				corev1.ResourceRequestsMemory: resource.MustParse("64Gi"),
+			},
+			wantCount:    1,
+			wantContains: "32Gi missing",
+		},
+		{
+			name: "bare cpu spelling counts against the requests",
+			hard: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+			},
+			wantCount:    1,
+			wantContains: "2 missing",
+		},
+		{
+			name: "multiple undersized resources are all reported",
+			hard: corev1.ResourceList{
+				corev1.ResourcePods:            resource.MustParse("6"),
+				corev1.ResourceRequestsStorage: resource.MustParse("200Gi"),
+			},
+			wantCount: 2,
+		},
+		{
+			name: "resources the components do not claim are ignored",
+			hard: corev1.ResourceList{
+				corev1.ResourceName("services.loadbalancers"): resource.MustParse("0"),
+			},
+			wantCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortfalls := quotaShortfalls(desired, quotaWithHard(tt.hard))
+			if len(shortfalls) != tt.wantCount {
+				t.Fatalf("quotaShortfalls() = %v, want %d entries", shortfalls, tt.wantCount)
+			}
+			if tt.wantContains != "" && !strings.Contains(strings.Join(shortfalls, "; "), tt.wantContains) {
+				t.Errorf("quotaShortfalls() = %v, want it to mention %q", shortfalls, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// defaultRebootstrapMinRestarts is how many OM crashes are required before a follower is treated
+// as irrecoverable when spec.om.rebootstrap does not set minRestarts.
+const defaultRebootstrapMinRestarts = int32(5)
+
+// rebootstrapRetryInterval is how long to wait before checking on a replica being re-bootstrapped.
+const rebootstrapRetryInterval = time.Minute
+
+// rebootstrapLogTailLines is how much of the crashed OM log is scanned for failure markers.
+const rebootstrapLogTailLines = int64(500)
+
+// rebootstrapLogMarkers identify an OM that cannot recover on its own: corrupt RocksDB or Ratis
+// state, or a follower so far behind that installing the leader checkpoint keeps failing.
+// Matched case-insensitively against the crashed container log.
+var rebootstrapLogMarkers = []string{
+	"corrupt",
+	"checksum mismatch",
+	"failed to install snapshot",
+	"installsnapshot",
+}
+
+// reconcileOMRebootstrap detects OM followers that crash-loop on corrupt metadata or failed Ratis
+// snapshot installation and, when spec.om.rebootstrap.allowDataWipe is set, deletes the replica's
+// PVC and pod so the replacement re-bootstraps from the leader checkpoint. Without allowDataWipe
+// the broken follower is only reported through an Event.
+func (r *OzoneClusterReconciler) reconcileOMRebootstrap(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	rebootstrap := cluster.Spec.OM.Rebootstrap
+	if rebootstrap == nil || cluster.Spec.ExternalControlPlane != nil || r.LogReader == nil {
+		return ctrl.Result{}, nil
+	}
+	replicas := *replicasOrDefault(cluster.Spec.OM.Replicas, 1)
+	// A single OM has no leader to re-bootstrap from; wiping it would destroy the namespace.
+	if replicas < 2 {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(componentLabels(cluster, omComponent))); err != nil {
+		return ctrl.Result{}, err
+	}
+	ready := int32(0)
+	for i := range pods.Items {
+		if podIsReady(&pods.Items[i]) {
+			ready++
+		}
+	}
+
+	result := ctrl.Result{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podIsReady(pod) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		status := containerStatus(pod, omComponent)
+		if status == nil || status.RestartCount < rebootstrapMinRestarts(rebootstrap) {
+			continue
+		}
+		if crashedTermination(status) == nil {
+			continue
+		}
+		logs, err := r.LogReader.Logs(ctx, pod.Namespace, pod.Name, omComponent, true, rebootstrapLogTailLines)
+		if err != nil {
+			log.Error(err, "failed to read crashed OM log for rebootstrap check", "pod", pod.Name)
+			continue
+		}
+		if !rebootstrapNeeded(logs) {
+			continue
+		}
+		// Only wipe while a Ratis majority of the other replicas is ready, so the replacement
+		// has a leader to install the checkpoint from.
+		if ready < replicas/2+1 {
+			log.Info("OM follower needs rebootstrap but the remaining quorum is not ready",
+				"pod", pod.Name, "ready", ready)
+			result = ctrl.Result{RequeueAfter: rebootstrapRetryInterval}
+			continue
+		}
+		if !rebootstrap.AllowDataWipe {
+			if r.Recorder != nil {
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "OMRebootstrapRequired",
+					"OM replica %s keeps crashing on corrupt or irrecoverably lagging metadata; "+
+						"set spec.om.rebootstrap.allowDataWipe to wipe its PVC and re-bootstrap it from the leader", pod.Name)
+			}
+			continue
+		}
+		if err := r.wipeOMReplica(ctx, cluster, pod); err != nil {
+			return ctrl.Result{}, err
+		}
+		result = ctrl.Result{RequeueAfter: rebootstrapRetryInterval}
+	}
+	return result, nil
+}
+
+// rebootstrapMinRestarts returns the configured crash threshold of the rebootstrap policy.
+func rebootstrapMinRestarts(rebootstrap *ozonev1alpha1.OMRebootstrapSpec) int32 {
+	if rebootstrap.MinRestarts != nil {
+		return *rebootstrap.MinRestarts
+	}
+	return defaultRebootstrapMinRestarts
+}
+
+// rebootstrapNeeded reports whether a crashed OM log shows a failure the replica cannot recover
+// from on its own.
+func rebootstrapNeeded(logs string) bool {
+	logs = strings.ToLower(logs)
+	for _, marker := range rebootstrapLogMarkers {
+		if strings.Contains(logs, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// wipeOMReplica deletes the data PVC and pod of one OM replica. The StatefulSet recreates both,
+// and the fresh OM installs a checkpoint from the Ratis leader on startup.
+func (r *OzoneClusterReconciler) wipeOMReplica(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, pod *corev1.Pod) error {
+	log := logf.FromContext(ctx)
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Namespace: pod.Namespace,
+		Name:      "data-" + pod.Name,
+	}}
+	if err := r.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+		return storageError(fmt.Errorf("deleting PVC of OM replica %s: %w", pod.Name, err))
+	}
+	// The PVC deletion only completes once its pod is gone; delete the pod right away so the
+	// StatefulSet can recreate both.
+	if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	log.Info("wiped broken OM replica for rebootstrap", "pod", pod.Name)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "OMRebootstrapStarted",
+			"wiped PVC of broken OM replica %s; it will re-bootstrap from the leader", pod.Name)
+	}
+	return nil
+}
+
+// containerStatus returns the status of the named container of a pod, or nil if not reported yet.
+func containerStatus(pod *corev1.Pod, name string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == name {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// podIsReady reports whether a pod has the Ready condition True.
+func podIsReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// reconService generates the headless service of Recon.
+func reconService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	svc := redirectUIPort(cluster, reconComponent, headlessService(cluster, reconComponent, []corev1.ServicePort{
+		{Name: "ui", Port: reconHTTPPort},
+		{Name: "rpc", Port: reconRPCPort},
+	}))
+	return addAPIProxyPort(cluster, reconComponent, svc)
+}
+
+// reconUsesExternalDatabase reports whether Recon is backed by an external SQL database.
+func reconUsesExternalDatabase(cluster *ozonev1alpha1.OzoneCluster) bool {
+	return cluster.Spec.Recon != nil && cluster.Spec.Recon.Database != nil
+}
+
+// reconDatabaseEnv renders the Recon SQL connection settings as OZONE-SITE.XML_ environment
+// entries, taking credentials from the configured Secret.
+func reconDatabaseEnv(cluster *ozonev1alpha1.OzoneCluster) []corev1.EnvVar {
+	db := cluster.Spec.Recon.Database
+	driver := db.Driver
+	if driver == "" {
+		driver = "org.postgresql.Driver"
+	}
+	env := []corev1.EnvVar{
+		{Name: ozoneSitePrefix + "ozone.recon.sql.db.jdbc.url", Value: db.URL},
+		{Name: ozoneSitePrefix + "ozone.recon.sql.db.driver", Value: driver},
+	}
+	if db.CredentialsSecret != "" {
+		env = append(env,
+			corev1.EnvVar{Name: ozoneSitePrefix + "ozone.recon.sql.db.username",
+				ValueFrom: secretKeyRef(db.CredentialsSecret, "username")},
+			corev1.EnvVar{Name: ozoneSitePrefix + "ozone.recon.sql.db.password",
+				ValueFrom: secretKeyRef(db.CredentialsSecret, "password")})
+	}
+	return env
+}
+
+// reconPodSpec assembles the Recon pod shared by both workload flavors.
+func reconPodSpec(cluster *ozonev1alpha1.OzoneCluster) corev1.PodSpec {
+	dataMount := corev1.VolumeMount{Name: "data", MountPath: dataMountPath}
+	pod := corev1.PodSpec{
+		InitContainers: waitForInitContainers(cluster, scmComponent, scmHTTPPort, scmAddresses(cluster)...),
+		Containers: []corev1.Container{{
+			Name:            reconComponent,
+			Image:           componentImage(cluster, &cluster.Spec.Recon.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "recon"},
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    []corev1.VolumeMount{dataMount},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(reconRPCPort)},
+			}, startupWindow(&cluster.Spec.Recon.ComponentSpec, metadataStartupWindow)),
+			LivenessProbe: livenessProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(reconRPCPort)},
+			}),
+		}},
+	}
+	if reconUsesExternalDatabase(cluster) {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, reconDatabaseEnv(cluster)...)
+	}
+	applyComponentSpec(&pod, &cluster.Spec.Recon.ComponentSpec)
+	applyUIAuth(cluster, reconComponent, &pod, reconHTTPPort)
+	applyAPIProxy(cluster, reconComponent, &pod, reconHTTPPort)
+	return pod
+}
+
+// reconStatefulSet generates the Recon StatefulSet backed by the embedded database on a PVC.
+func reconStatefulSet(cluster *ozonev1alpha1.OzoneCluster) *appsv1.StatefulSet {
+	sts := baseStatefulSet(cluster, reconComponent,
+		suspendedReplicas(cluster.Spec.Recon.Suspended, replicasOrDefault(cluster.Spec.Recon.Replicas, 1)),
+		reconPodSpec(cluster), prometheusAnnotations(cluster, reconHTTPPort))
+	applyComponentStorage(sts, &cluster.Spec.Recon.Storage)
+	applyUpdateStrategy(sts, &cluster.Spec.Recon.ComponentSpec)
+	return sts
+}
+
+// reconDeployment generates the Recon Deployment used with an external database. Recon is then
+// disposable: /data only caches the OM snapshot mirror and lives on an emptyDir.
+func reconDeployment(cluster *ozonev1alpha1.OzoneCluster) *appsv1.Deployment {
+	pod := reconPodSpec(cluster)
+	pod.Volumes = append(pod.Volumes, emptyDirDataVolume("data", resource.Quantity{}))
+	return baseDeployment(cluster, reconComponent,
+		suspendedReplicas(cluster.Spec.Recon.Suspended, replicasOrDefault(cluster.Spec.Recon.Replicas, 1)),
+		pod, prometheusAnnotations(cluster, reconHTTPPort))
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// appliedReconfigAnnotation records on the cluster which reload-safe configuration has been
+// pushed to the running pods, so only actual changes trigger the reconfig round.
+const appliedReconfigAnnotation = "ozone.apache.org/applied-reconfig-hash"
+
+// reconfigEndpoints maps each dynamically reconfigurable component onto its RPC port.
+var reconfigEndpoints = map[string]int32{
+	scmComponent:      scmClientPort,
+	omComponent:       omRPCPort,
+	datanodeComponent: datanodeRPCPort,
+}
+
+// reconcileReconfig pushes changes limited to dynamically reconfigurable properties to the
+// running components through `ozone admin reconfig`, instead of rolling every pod. Properties
+// requiring a restart are excluded from this path; they change the config hash annotation and
+// roll the pods instead.
+func (r *OzoneClusterReconciler) reconcileReconfig(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if cluster.Spec.Hibernate || cluster.Status.Hibernation != nil {
+		return nil
+	}
+	desired := reloadableConfigHash(cluster)
+	applied := cluster.Annotations[appliedReconfigAnnotation]
+	if applied == desired {
+		return nil
+	}
+	// On the very first reconcile there is nothing running to reconfigure; just record the hash.
+	if applied != "" {
+		if r.Executor == nil {
+			return nil
+		}
+		if err := r.reconfigureComponents(ctx, cluster); err != nil {
+			return err
+		}
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[appliedReconfigAnnotation] = desired
+	return r.Update(ctx, cluster)
+}
+
+// reconfigureComponents issues `ozone admin reconfig start` for every SCM, OM, and datanode pod.
+func (r *OzoneClusterReconciler) reconfigureComponents(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	log := logf.FromContext(ctx)
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	for component, port := range reconfigEndpoints {
+		if cluster.Spec.ExternalControlPlane != nil && component != datanodeComponent {
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+			client.MatchingLabels(componentLabels(cluster, component))); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			address := fmt.Sprintf("%s.%s:%d", pods.Items[i].Name, componentName(cluster, component), port)
+			if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent,
+				"ozone", "admin", "reconfig", "--address", address, "start"); err != nil {
+				return fmt.Errorf("reconfiguring %s: %w", address, err)
+			}
+			log.Info("applied dynamic reconfiguration", "address", address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// restoreComponent names the Job that seeds the cluster from spec.restore.source.
+const restoreComponent = "restore"
+
+// restorePollInterval is how often the running restore Job is rechecked.
+const restorePollInterval = 30 * time.Second
+
+// restoreScript copies the backup key by key instead of one recursive copy, so an interrupted
+// restore resumes: keys already present at the right size are skipped on the next run. A
+// recursive `ozone fs -cp` of a multi-hundred-GB backup would restart from scratch on any
+// failure. The optional sleep after each key bounds the average copy rate for WAN links; the
+// size is compared instead of a checksum because the s3a MD5 and the ofs CRC are not comparable.
+// The awk strips the seven fields before the path instead of printing $NF, so keys containing
+// spaces survive the pipe into `read` intact.
+const restoreScript = `set -e
+ozone fs %[1]s -ls -R %[2]s |
+  awk '$1 !~ /^d/ {size=$5; for (i = 1; i <= 7; i++) sub(/^ *[^ ]+ +/, ""); print size, $0}' |
+  while read -r size key; do
+  target="%[3]s${key#%[2]s}"
+  if [ "$(ozone fs -stat %%b "$target" 2>/dev/null)" = "$size" ]; then
+    continue
+  fi
+  ozone fs %[1]s -cp -f "$key" "$target"
+%[4]s
+done
+`
+
+// reconcileRestore drives the one-time restore Job of a cluster with spec.restore set. The Job
+// waits for the OM; a failed Job is deleted and recreated, and because the script skips keys that
+// already arrived, the retry continues where the previous attempt stopped.
+func (r *OzoneClusterReconciler) reconcileRestore(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Restore == nil || (cluster.Status.Restore != nil && cluster.Status.Restore.Succeeded) {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, restoreComponent)}
+	if err := r.Get(ctx, key, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		job := restoreJob(cluster)
+		log.Info("starting restore from backup", "job", job.Name, "source", cluster.Spec.Restore.Source)
+		return ctrl.Result{RequeueAfter: restorePollInterval}, r.apply(ctx, cluster, job)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			now := metav1.Now()
+			cluster.Status.Restore = &ozonev1alpha1.RestoreStatus{Succeeded: true, CompletionTime: &now}
+			log.Info("restore finished", "job", job.Name, "source", cluster.Spec.Restore.Source)
+			r.notify(ctx, cluster, "RestoreComplete",
+				fmt.Sprintf("the restore from %s finished", cluster.Spec.Restore.Source))
+			return ctrl.Result{}, nil
+		}
+		if condition.Type == batchv1.JobFailed {
+			r.notify(ctx, cluster, "RestoreFailed",
+				fmt.Sprintf("the restore job %s failed (%s); it is recreated and resumes from the keys "+
+					"already copied", job.Name, condition.Reason))
+			propagation := metav1.DeletePropagationBackground
+			if err := r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil &&
+				!errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+		}
+	}
+	return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+}
+
+// restoreJob builds the Job that copies the backup into the cluster namespace over the s3a
+// connector, the reverse direction of finalBackupJob.
+func restoreJob(cluster *ozonev1alpha1.OzoneCluster) *batchv1.Job {
+	restore := cluster.Spec.Restore
+	source := "s3a://" + strings.TrimPrefix(restore.Source, "s3://")
+	if !strings.HasSuffix(source, "/") {
+		source += "/"
+	}
+	fsOptions := ""
+	if restore.S3 != nil && restore.S3.Endpoint != "" {
+		fsOptions = "-D fs.s3a.endpoint=" + restore.S3.Endpoint
+	}
+	throttle := ""
+	if restore.BandwidthLimitMBps != nil && *restore.BandwidthLimitMBps > 0 {
+		throttle = fmt.Sprintf("  sleep $((size / %d + 1))", int64(*restore.BandwidthLimitMBps)*1024*1024)
+	}
+	script := fmt.Sprintf(restoreScript, fsOptions, source,
+		"ofs://"+podFQDN(cluster, omComponent, 0)+"/", throttle)
+
+	var env []corev1.EnvVar
+	if restore.S3 != nil {
+		env = []corev1.EnvVar{
+			{Name: "AWS_ACCESS_KEY_ID", ValueFrom: secretKeyRef(restore.S3.CredentialsSecret, "accessKey")},
+			{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef(restore.S3.CredentialsSecret, "secretKey")},
+		}
+	}
+	labels := componentLabels(cluster, restoreComponent)
+	backoffLimit := int32(2)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentName(cluster, restoreComponent),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:  corev1.RestartPolicyNever,
+					InitContainers: waitForInitContainers(cluster, omComponent, omRPCPort, omAddresses(cluster)...),
+					Containers: []corev1.Container{{
+						Name:    restoreComponent,
+						Image:   clusterImage(cluster),
+						Args:    []string{"bash", "-c", script},
+						Env:     env,
+						EnvFrom: configEnvFrom(cluster),
+					}},
+				},
+			},
+		},
+	}
+}
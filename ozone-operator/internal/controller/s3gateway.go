@@ -0,0 +1,391 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// s3gZoneLabel marks per-zone gateway pods and services with their availability zone.
+const s3gZoneLabel = "ozone.apache.org/zone"
+
+// s3gAccessLogDir and s3gAccessLogFile locate the gateway access log on the shared log emptyDir.
+const (
+	s3gAccessLogDir  = "/var/log/ozone"
+	s3gAccessLogFile = "s3g-access.log"
+)
+
+// s3gRateLimitPort is where the rate-limiting sidecar listens; with rate limiting enabled the
+// gateway Services send S3 traffic here instead of the unprotected REST port.
+const s3gRateLimitPort = int32(9879)
+
+// defaultRateLimitImage is the sidecar image used when spec.s3g.limits.rateLimit.image is empty.
+const defaultRateLimitImage = "docker.io/library/nginx:1.25.4-alpine"
+
+// s3gScalingRequeue is how often gateway scaling windows are re-evaluated.
+const s3gScalingRequeue = time.Minute
+
+// applyS3GatewayScalingWindows overrides the gateway replica count in memory while a scaling
+// window is active, so the generated Deployments scale up and down on the configured daily
+// schedule. The spec value is never persisted and applies again outside every window.
+func applyS3GatewayScalingWindows(cluster *ozonev1alpha1.OzoneCluster, now time.Time) (ctrl.Result, error) {
+	s3g := cluster.Spec.S3Gateway
+	if s3g == nil || len(s3g.ScalingWindows) == 0 {
+		return ctrl.Result{}, nil
+	}
+	for i := range s3g.ScalingWindows {
+		window := &s3g.ScalingWindows[i]
+		inWindow, err := inDailyWindow(window.Start, window.End, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if inWindow {
+			replicas := window.Replicas
+			s3g.Replicas = &replicas
+			break
+		}
+	}
+	return ctrl.Result{RequeueAfter: s3gScalingRequeue}, nil
+}
+
+// applyS3GatewayServiceSpec applies the routing knobs of spec.s3g.service onto a gateway Service.
+func applyS3GatewayServiceSpec(service *corev1.Service, spec *ozonev1alpha1.S3GatewayServiceSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.Type != "" {
+		service.Spec.Type = spec.Type
+		if spec.Type != corev1.ServiceTypeClusterIP {
+			// The base Service is headless; NodePort and LoadBalancer services need an allocated
+			// cluster IP.
+			service.Spec.ClusterIP = ""
+		}
+	}
+	if spec.SessionAffinity != "" {
+		service.Spec.SessionAffinity = spec.SessionAffinity
+	}
+	if spec.ExternalTrafficPolicy != "" &&
+		(service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer) {
+		service.Spec.ExternalTrafficPolicy = spec.ExternalTrafficPolicy
+	}
+	if spec.TopologyAwareRouting {
+		if service.Annotations == nil {
+			service.Annotations = map[string]string{}
+		}
+		service.Annotations["service.kubernetes.io/topology-mode"] = "Auto"
+	}
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		service.Spec.LoadBalancerIP = spec.LoadBalancerIP
+		service.Spec.LoadBalancerClass = spec.LoadBalancerClass
+		service.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+	}
+	if len(spec.Annotations) > 0 || spec.Hostname != "" {
+		if service.Annotations == nil {
+			service.Annotations = map[string]string{}
+		}
+		for key, value := range spec.Annotations {
+			service.Annotations[key] = value
+		}
+		if spec.Hostname != "" {
+			service.Annotations["external-dns.alpha.kubernetes.io/hostname"] = spec.Hostname
+		}
+	}
+}
+
+// s3gService generates the headless service of the S3 Gateway. With per-zone services configured it
+// still selects every gateway pod across zones.
+func s3gService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	service := headlessService(cluster, s3gComponent, []corev1.ServicePort{
+		{Name: "rest", Port: s3gHTTPPort},
+	})
+	applyS3GatewayServiceSpec(service, cluster.Spec.S3Gateway.Service)
+	if s3gRateLimit(cluster) != nil {
+		for i := range service.Spec.Ports {
+			if service.Spec.Ports[i].Name == "rest" {
+				service.Spec.Ports[i].TargetPort = intstr.FromInt32(s3gRateLimitPort)
+			}
+		}
+	}
+	return service
+}
+
+// s3gZones returns the configured per-zone service zones, empty for the single-Deployment layout.
+func s3gZones(cluster *ozonev1alpha1.OzoneCluster) []string {
+	if cluster.Spec.S3Gateway == nil || cluster.Spec.S3Gateway.Service == nil {
+		return nil
+	}
+	return cluster.Spec.S3Gateway.Service.PerZoneServices
+}
+
+// s3gZoneService generates the Service routing only to the gateway pods of one zone.
+func s3gZoneService(cluster *ozonev1alpha1.OzoneCluster, zone string) *corev1.Service {
+	service := s3gService(cluster)
+	service.Name = service.Name + "-" + zone
+	service.Labels[s3gZoneLabel] = zone
+	service.Spec.Selector[s3gZoneLabel] = zone
+	return service
+}
+
+// s3gZoneDeployment pins one gateway Deployment to a zone so the matching per-zone Service always
+// routes inside that zone. The zone requirement is merged into the node affinity the base
+// Deployment may already carry (the arch restriction of applyArchAffinity), not written over it:
+// appending the expression to every existing term keeps each OR branch restricted to the zone.
+func s3gZoneDeployment(cluster *ozonev1alpha1.OzoneCluster, zone string) *appsv1.Deployment {
+	deployment := s3gDeployment(cluster)
+	deployment.Name = deployment.Name + "-" + zone
+	deployment.Labels[s3gZoneLabel] = zone
+	deployment.Spec.Selector.MatchLabels[s3gZoneLabel] = zone
+	deployment.Spec.Template.Labels[s3gZoneLabel] = zone
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key:      "topology.kubernetes.io/zone",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{zone},
+	}
+	pod := &deployment.Spec.Template.Spec
+	if pod.Affinity == nil {
+		pod.Affinity = &corev1.Affinity{}
+	}
+	if pod.Affinity.NodeAffinity == nil {
+		pod.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	selector := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if selector == nil || len(selector.NodeSelectorTerms) == 0 {
+		pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement},
+			}},
+		}
+	} else {
+		for i := range selector.NodeSelectorTerms {
+			selector.NodeSelectorTerms[i].MatchExpressions =
+				append(selector.NodeSelectorTerms[i].MatchExpressions, zoneRequirement)
+		}
+	}
+	return deployment
+}
+
+// applyS3GatewayAccessLog routes the Hadoop request log of the gateway into a rolling file on a
+// shared emptyDir and adds a sidecar tailing the file to its own stdout. The log4j entries ride
+// on the container environment, which the image entrypoint merges with the shared ConfigMap.
+func applyS3GatewayAccessLog(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	accessLog := cluster.Spec.S3Gateway.AccessLog
+	if accessLog == nil {
+		return
+	}
+	format := accessLog.Format
+	if format == "" {
+		format = "%m%n"
+	}
+	logFile := s3gAccessLogDir + "/" + s3gAccessLogFile
+	logMount := corev1.VolumeMount{Name: "access-log", MountPath: s3gAccessLogDir}
+	pod.Containers[0].Env = append(pod.Containers[0].Env,
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.logger.http.requests.s3gateway", Value: "INFO,s3gaccess"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.additivity.http.requests.s3gateway", Value: "false"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess", Value: "org.apache.log4j.RollingFileAppender"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess.File", Value: logFile},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess.MaxFileSize", Value: "100MB"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess.MaxBackupIndex", Value: "2"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess.layout", Value: "org.apache.log4j.PatternLayout"},
+		corev1.EnvVar{Name: "LOG4J.PROPERTIES_log4j.appender.s3gaccess.layout.ConversionPattern", Value: format},
+	)
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, logMount)
+
+	image := accessLog.SidecarImage
+	if image == "" {
+		image = componentImage(cluster, &cluster.Spec.S3Gateway.ComponentSpec)
+	}
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:            "access-log",
+		Image:           image,
+		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+		// tail from the first line so records logged before the sidecar came up are not lost.
+		Args:         []string{"tail", "-n+1", "-F", logFile},
+		VolumeMounts: []corev1.VolumeMount{logMount},
+	})
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name:         "access-log",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+}
+
+// s3gRateLimit returns the gateway rate-limit settings, nil when rate limiting is disabled.
+func s3gRateLimit(cluster *ozonev1alpha1.OzoneCluster) *ozonev1alpha1.S3RateLimitSpec {
+	if cluster.Spec.S3Gateway == nil || cluster.Spec.S3Gateway.Limits == nil {
+		return nil
+	}
+	return cluster.Spec.S3Gateway.Limits.RateLimit
+}
+
+// s3gRateLimitConfigMapName names the ConfigMap holding the nginx configuration of the sidecar.
+func s3gRateLimitConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return componentName(cluster, s3gComponent) + "-ratelimit"
+}
+
+// s3gRateLimitConfigMap renders the nginx configuration of the rate-limiting sidecar. The shared
+// zone is keyed on the client IP, or on the access key parsed out of the AWS Authorization header
+// with PerAccessKey, and requests above the limit are rejected with 429 Too Many Requests.
+func s3gRateLimitConfigMap(cluster *ozonev1alpha1.OzoneCluster) *corev1.ConfigMap {
+	rateLimit := s3gRateLimit(cluster)
+	key := "$binary_remote_addr"
+	keyMap := ""
+	if rateLimit.PerAccessKey {
+		key = "$s3_client"
+		keyMap = "  map $http_authorization $s3_client {\n" +
+			"    default $binary_remote_addr;\n" +
+			"    \"~Credential=(?<key>[^/]+)/\" $key;\n" +
+			"  }\n"
+	}
+	limitReq := "limit_req zone=s3;"
+	if rateLimit.Burst > 0 {
+		limitReq = fmt.Sprintf("limit_req zone=s3 burst=%d nodelay;", rateLimit.Burst)
+	}
+	config := fmt.Sprintf(`worker_processes 1;
+events {
+  worker_connections 4096;
+}
+http {
+%s  limit_req_zone %s zone=s3:32m rate=%dr/s;
+  server {
+    listen %d;
+    location / {
+      %s
+      limit_req_status 429;
+      client_max_body_size 0;
+      proxy_request_buffering off;
+      proxy_http_version 1.1;
+      proxy_set_header Host $host;
+      proxy_set_header X-Forwarded-For $remote_addr;
+      proxy_pass http://127.0.0.1:%d;
+    }
+  }
+}
+`, keyMap, key, rateLimit.RequestsPerSecond, s3gRateLimitPort, limitReq, s3gHTTPPort)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s3gRateLimitConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, s3gComponent),
+		},
+		Data: map[string]string{"nginx.conf": config},
+	}
+}
+
+// applyS3GatewayLimits tunes the gateway HTTP server through container-local ozone-site entries
+// and appends the rate-limiting sidecar when configured. The capacity knobs ride on the container
+// environment so they apply to the gateway only, not to the other components sharing the
+// configuration ConfigMap.
+func applyS3GatewayLimits(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	limits := cluster.Spec.S3Gateway.Limits
+	if limits == nil {
+		return
+	}
+	if limits.HandlerThreads != nil {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, corev1.EnvVar{
+			Name: "OZONE-SITE.XML_hadoop.http.max.threads", Value: strconv.Itoa(int(*limits.HandlerThreads)),
+		})
+	}
+	if limits.MaxConnections != nil {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, corev1.EnvVar{
+			Name: "OZONE-SITE.XML_hadoop.http.socket.backlog.size", Value: strconv.Itoa(int(*limits.MaxConnections)),
+		})
+	}
+	if limits.IdleTimeout != nil {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, corev1.EnvVar{
+			Name:  "OZONE-SITE.XML_hadoop.http.idle_timeout.ms",
+			Value: strconv.FormatInt(limits.IdleTimeout.Duration.Milliseconds(), 10),
+		})
+	}
+
+	rateLimit := limits.RateLimit
+	if rateLimit == nil {
+		return
+	}
+	image := rateLimit.Image
+	if image == "" {
+		image = defaultRateLimitImage
+	}
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:            "rate-limit",
+		Image:           image,
+		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+		Args:            []string{"nginx", "-c", "/etc/nginx-ratelimit/nginx.conf", "-g", "daemon off;"},
+		Ports:           []corev1.ContainerPort{{Name: "rate-limit", ContainerPort: s3gRateLimitPort}},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "ratelimit-config", MountPath: "/etc/nginx-ratelimit"},
+		},
+	})
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "ratelimit-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: s3gRateLimitConfigMapName(cluster)},
+		}},
+	})
+}
+
+// s3gDeployment generates the S3 Gateway Deployment. The gateway is stateless, so it rolls with a
+// surge update instead of the serial pod replacement of a StatefulSet, and /data is an emptyDir.
+func s3gDeployment(cluster *ozonev1alpha1.OzoneCluster) *appsv1.Deployment {
+	dataMount := corev1.VolumeMount{Name: "data", MountPath: dataMountPath}
+	pod := corev1.PodSpec{
+		InitContainers: waitForInitContainers(cluster, omComponent, omRPCPort, omAddresses(cluster)...),
+		Containers: []corev1.Container{{
+			Name:            s3gComponent,
+			Image:           componentImage(cluster, &cluster.Spec.S3Gateway.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "s3g"},
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    []corev1.VolumeMount{dataMount},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt32(s3gHTTPPort)},
+			}, startupWindow(&cluster.Spec.S3Gateway.ComponentSpec, gatewayStartupWindow)),
+			LivenessProbe: livenessProbe(corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt32(s3gHTTPPort)},
+			}),
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}},
+	}
+	applyComponentSpec(&pod, &cluster.Spec.S3Gateway.ComponentSpec)
+	applyS3GatewayAccessLog(cluster, &pod)
+	applyS3GatewayLimits(cluster, &pod)
+
+	deployment := baseDeployment(cluster, s3gComponent,
+		suspendedReplicas(cluster.Spec.S3Gateway.Suspended, replicasOrDefault(cluster.Spec.S3Gateway.Replicas, 1)),
+		pod, nil)
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	if rollingUpdate := cluster.Spec.S3Gateway.RollingUpdate; rollingUpdate != nil {
+		deployment.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       rollingUpdate.MaxSurge,
+			MaxUnavailable: rollingUpdate.MaxUnavailable,
+		}
+	}
+	return deployment
+}
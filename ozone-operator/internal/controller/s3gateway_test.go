@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestApplyS3GatewayScalingWindows(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	baseReplicas := int32(2)
+	windows := []ozonev1alpha1.ScalingWindow{
+		{Start: "09:00", End: "21:00", Replicas: 8},
+	}
+	cases := []struct {
+		name     string
+		now      time.Time
+		expected int32
+	}{
+		{"inside window", at(12, 0), 8},
+		{"before window", at(8, 59), 2},
+		{"after window", at(21, 0), 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			replicas := baseReplicas
+			cluster := &ozonev1alpha1.OzoneCluster{Spec: ozonev1alpha1.OzoneClusterSpec{
+				S3Gateway: &ozonev1alpha1.S3GatewaySpec{
+					ComponentSpec:  ozonev1alpha1.ComponentSpec{Replicas: &replicas},
+					ScalingWindows: windows,
+				},
+			}}
+			result, err := applyS3GatewayScalingWindows(cluster, tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter != s3gScalingRequeue {
+				t.Errorf("expected requeue after %v, got %v", s3gScalingRequeue, result.RequeueAfter)
+			}
+			if got := *cluster.Spec.S3Gateway.Replicas; got != tc.expected {
+				t.Errorf("expected %d replicas, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if result, err := applyS3GatewayScalingWindows(cluster, at(12, 0)); err != nil || result.RequeueAfter != 0 {
+		t.Errorf("expected no-op without a gateway, got result %v, err %v", result, err)
+	}
+}
+
+func TestS3GatewayAccessLog(t *testing.T) {
+	cluster := &ozonev1alpha1.OzoneCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: ozonev1alpha1.OzoneClusterSpec{
+			Image:     "apache/ozone:1.4.0",
+			S3Gateway: &ozonev1alpha1.S3GatewaySpec{},
+		},
+	}
+	deployment := s3gDeployment(cluster)
+	if len(deployment.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("expected no sidecar without accessLog, got %d containers", len(deployment.Spec.Template.Spec.Containers))
+	}
+
+	cluster.Spec.S3Gateway.AccessLog = &ozonev1alpha1.S3AccessLogSpec{}
+	deployment = s3gDeployment(cluster)
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[1].Name != "access-log" {
+		t.Fatalf("expected a tailing access-log sidecar, got %v", containers)
+	}
+	if containers[1].Image != "apache/ozone:1.4.0" {
+		t.Errorf("the sidecar should default to the gateway image, got %s", containers[1].Image)
+	}
+	var appenderFile string
+	for _, env := range containers[0].Env {
+		if env.Name == "LOG4J.PROPERTIES_log4j.appender.s3gaccess.File" {
+			appenderFile = env.Value
+		}
+	}
+	if appenderFile != s3gAccessLogDir+"/"+s3gAccessLogFile {
+		t.Errorf("the request log appender must write the shared log file, got %q", appenderFile)
+	}
+}
+
+func TestS3GatewayRateLimit(t *testing.T) {
+	cluster := &ozonev1alpha1.OzoneCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: ozonev1alpha1.OzoneClusterSpec{
+			Image: "apache/ozone:1.4.0",
+			S3Gateway: &ozonev1alpha1.S3GatewaySpec{
+				Limits: &ozonev1alpha1.S3GatewayLimitsSpec{
+					RateLimit: &ozonev1alpha1.S3RateLimitSpec{RequestsPerSecond: 100, Burst: 50},
+				},
+			},
+		},
+	}
+
+	containers := s3gDeployment(cluster).Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[1].Name != "rate-limit" {
+		t.Fatalf("expected the rate-limit sidecar, got %v", containers)
+	}
+	if containers[1].Image != defaultRateLimitImage {
+		t.Errorf("expected the default nginx image, got %s", containers[1].Image)
+	}
+
+	service := s3gService(cluster)
+	if got := service.Spec.Ports[0].TargetPort.IntValue(); got != int(s3gRateLimitPort) {
+		t.Errorf("the rest port must target the sidecar, got %d", got)
+	}
+
+	config := s3gRateLimitConfigMap(cluster).Data["nginx.conf"]
+	if !strings.Contains(config, "limit_req_zone $binary_remote_addr zone=s3:32m rate=100r/s;") {
+		t.Errorf("expected a per-IP limit zone, got:\n%s", config)
+	}
+	if !strings.Contains(config, "limit_req zone=s3 burst=50 nodelay;") {
+		t.Errorf("expected the configured burst, got:\n%s", config)
+	}
+
+	cluster.Spec.S3Gateway.Limits.RateLimit.PerAccessKey = true
+	config = s3gRateLimitConfigMap(cluster).Data["nginx.conf"]
+	if !strings.Contains(config, "limit_req_zone $s3_client zone=s3:32m rate=100r/s;") ||
+		!strings.Contains(config, "map $http_authorization $s3_client {") {
+		t.Errorf("expected a per-access-key limit zone, got:\n%s", config)
+	}
+}
+
+func TestApplyS3GatewayLimits(t *testing.T) {
+	threads := int32(128)
+	connections := int32(2048)
+	cluster := &ozonev1alpha1.OzoneCluster{Spec: ozonev1alpha1.OzoneClusterSpec{
+		S3Gateway: &ozonev1alpha1.S3GatewaySpec{Limits: &ozonev1alpha1.S3GatewayLimitsSpec{
+			HandlerThreads: &threads,
+			MaxConnections: &connections,
+			IdleTimeout:    &metav1.Duration{Duration: 30 * time.Second},
+		}},
+	}}
+	pod := corev1.PodSpec{Containers: []corev1.Container{{}}}
+	applyS3GatewayLimits(cluster, &pod)
+	if len(pod.Containers) != 1 {
+		t.Fatalf("expected no sidecar without rateLimit, got %d containers", len(pod.Containers))
+	}
+	expected := map[string]string{
+		"OZONE-SITE.XML_hadoop.http.max.threads":         "128",
+		"OZONE-SITE.XML_hadoop.http.socket.backlog.size": "2048",
+		"OZONE-SITE.XML_hadoop.http.idle_timeout.ms":     "30000",
+	}
+	for _, env := range pod.Containers[0].Env {
+		if want, ok := expected[env.Name]; ok {
+			if env.Value != want {
+				t.Errorf("%s: expected %s, got %s", env.Name, want, env.Value)
+			}
+			delete(expected, env.Name)
+		}
+	}
+	for name := range expected {
+		t.Errorf("missing gateway capacity property %s", name)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// defaultSafemodeGracePeriod is how long SCM may stay in safemode before it is prolonged.
+	defaultSafemodeGracePeriod = 10 * time.Minute
+
+	// defaultSafemodeMinDatanodePercentage of requested datanodes must be ready before a forced exit.
+	defaultSafemodeMinDatanodePercentage = int32(90)
+
+	// safemodeRequeue is how often the safemode state is polled while SCM is in safemode.
+	safemodeRequeue = 30 * time.Second
+)
+
+// reconcileSafemode watches the SCM safemode state after cold starts, reports prolonged safemode as
+// a condition, and forces an exit once enough datanodes re-registered when the spec allows it.
+func (r *OzoneClusterReconciler) reconcileSafemode(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	safemode := cluster.Spec.SCM.Safemode
+	if safemode == nil || (r.Executor == nil && r.AdminClient == nil) {
+		return ctrl.Result{}, nil
+	}
+
+	admin := r.adminClient(cluster)
+	inSafemode, err := admin.SafemodeStatus(ctx)
+	if err != nil {
+		// The SCM may simply not answer yet during a cold start; poll again instead of failing.
+		return ctrl.Result{RequeueAfter: safemodeRequeue}, nil
+	}
+
+	now := metav1.Now()
+	if !inSafemode {
+		cluster.Status.Safemode = nil
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               ConditionSafemodeProlonged,
+			Status:             metav1.ConditionFalse,
+			Reason:             "OutOfSafemode",
+			Message:            "SCM is out of safemode",
+			ObservedGeneration: cluster.Generation,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.Status.Safemode == nil || !cluster.Status.Safemode.InSafemode {
+		cluster.Status.Safemode = &ozonev1alpha1.SafemodeStatus{InSafemode: true, Since: &now}
+	}
+	grace := defaultSafemodeGracePeriod
+	if safemode.After != nil {
+		grace = safemode.After.Duration
+	}
+	elapsed := now.Sub(cluster.Status.Safemode.Since.Time)
+	if elapsed < grace {
+		return ctrl.Result{RequeueAfter: safemodeRequeue}, nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               ConditionSafemodeProlonged,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SafemodeProlonged",
+		Message:            fmt.Sprintf("SCM has been in safemode for %s", elapsed.Round(time.Second)),
+		ObservedGeneration: cluster.Generation,
+	})
+	if !safemode.ForceExit {
+		return ctrl.Result{RequeueAfter: safemodeRequeue}, nil
+	}
+
+	datanodes := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, datanodeComponent)}
+	if err := r.Get(ctx, key, datanodes); err != nil {
+		return ctrl.Result{}, err
+	}
+	requested := *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3)
+	minPercentage := defaultSafemodeMinDatanodePercentage
+	if safemode.MinDatanodePercentage != nil {
+		minPercentage = *safemode.MinDatanodePercentage
+	}
+	if requested == 0 || datanodes.Status.ReadyReplicas*100 < requested*minPercentage {
+		return ctrl.Result{RequeueAfter: safemodeRequeue}, nil
+	}
+
+	if err := admin.ExitSafemode(ctx); err != nil {
+		// The safemode status answered above, so a failing exit usually means the SCM Ratis
+		// quorum cannot commit the transition yet.
+		return ctrl.Result{}, quorumLostError(fmt.Errorf("forcing safemode exit: %w", err))
+	}
+	cluster.Status.Safemode.LastForcedExit = &now
+	return ctrl.Result{RequeueAfter: safemodeRequeue}, nil
+}
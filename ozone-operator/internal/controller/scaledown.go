@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionScaleDownBlocked is True while a requested replica reduction is held back because it
+// would risk data availability or OM quorum.
+const ConditionScaleDownBlocked = "ScaleDownBlocked"
+
+// minDatanodesForReplication is the smallest datanode count that can still host fully replicated
+// RATIS/THREE containers.
+const minDatanodesForReplication = int32(3)
+
+// applyScaleDownGuard checks requested replica reductions of the datanodes and OM against cluster
+// health before they reach the StatefulSets. An unsafe reduction is clamped to the current replica
+// count in memory, so the StatefulSet keeps its size, and the ScaleDownBlocked condition records
+// why. The spec itself is never modified.
+func (r *OzoneClusterReconciler) applyScaleDownGuard(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	blockedReason := ""
+	blockedMessage := ""
+
+	current, err := r.currentReplicas(ctx, cluster, datanodeComponent)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		desired := *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3)
+		if desired < *current {
+			if reason, message := r.datanodeScaleDownBlocked(ctx, cluster, desired); reason != "" {
+				cluster.Spec.Datanode.Replicas = current
+				blockedReason, blockedMessage = reason, message
+			}
+		}
+	}
+
+	current, err = r.currentReplicas(ctx, cluster, omComponent)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		desired := *replicasOrDefault(cluster.Spec.OM.Replicas, 1)
+		// Shrinking an OM HA ring below the majority of its current size loses the Ratis quorum.
+		if majority := *current/2 + 1; desired < *current && desired < majority {
+			cluster.Spec.OM.Replicas = current
+			blockedReason = "QuorumLoss"
+			blockedMessage = fmt.Sprintf("reducing OM replicas from %d to %d would break the Ratis quorum (majority %d)",
+				*current, desired, majority)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionScaleDownBlocked,
+		Status:             metav1.ConditionFalse,
+		Reason:             "ScaleDownSafe",
+		Message:            "no unsafe replica reduction is pending",
+		ObservedGeneration: cluster.Generation,
+	}
+	if blockedReason != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = blockedReason
+		condition.Message = blockedMessage
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return nil
+}
+
+// currentReplicas returns the replica count of a component StatefulSet, or nil when it does not
+// exist yet.
+func (r *OzoneClusterReconciler) currentReplicas(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, component string) (*int32, error) {
+	sts := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: componentName(cluster, component)}
+	if err := r.Get(ctx, key, sts); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sts.Spec.Replicas, nil
+}
+
+// datanodeScaleDownBlocked checks whether a datanode reduction is safe and returns the blocking
+// reason and message when it is not. Container health is taken from Recon when it is deployed.
+func (r *OzoneClusterReconciler) datanodeScaleDownBlocked(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, desired int32) (string, string) {
+	if desired < minDatanodesForReplication {
+		return "BelowReplicationFactor", fmt.Sprintf(
+			"%d datanodes cannot host fully replicated containers; at least %d are required",
+			desired, minDatanodesForReplication)
+	}
+	if cluster.Spec.Recon == nil {
+		return "", ""
+	}
+	var containers reconUnhealthyContainersResponse
+	if err := r.getReconJSON(ctx, reconAPIBaseURL(cluster)+"/api/v1/containers/unhealthy",
+		&containers); err != nil {
+		return "ReconUnreachable", "container health could not be verified against Recon before scaling down"
+	}
+	unhealthy := containers.MissingCount + containers.UnderReplicatedCount +
+		containers.OverReplicatedCount + containers.MisReplicatedCount
+	if unhealthy > 0 {
+		return "UnhealthyContainers", fmt.Sprintf(
+			"Recon reports %d unhealthy containers; scale down once the cluster is fully replicated", unhealthy)
+	}
+	return "", ""
+}
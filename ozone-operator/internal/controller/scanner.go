@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// dataScanIterationsMetric is the datanode gauge counting completed full data scanner passes, as
+// exported on /prom.
+const dataScanIterationsMetric = "container_data_scanner_metrics_num_scan_iterations"
+
+// scannerStatusRequeue is how often the datanode scan progress is sampled.
+const scannerStatusRequeue = 10 * time.Minute
+
+// scannerProperties maps spec.datanode.scanner onto the hdds.container.scrub properties. Returns
+// nil when the section is unset, keeping the scanner defaults of the image.
+func scannerProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	scanner := cluster.Spec.Datanode.Scanner
+	if scanner == nil {
+		return nil
+	}
+	props := map[string]string{}
+	if scanner.Enabled != nil {
+		props["hdds.container.scrub.enabled"] = fmt.Sprintf("%t", *scanner.Enabled)
+	}
+	if scanner.MetadataScanInterval != nil {
+		props["hdds.container.scrub.metadata.scan.interval"] =
+			fmt.Sprintf("%dms", scanner.MetadataScanInterval.Duration.Milliseconds())
+	}
+	if scanner.DataScanInterval != nil {
+		props["hdds.container.scrub.data.scan.interval"] =
+			fmt.Sprintf("%dms", scanner.DataScanInterval.Duration.Milliseconds())
+	}
+	if scanner.Bandwidth != nil {
+		props["hdds.container.scrub.volume.bytes.per.second"] = fmt.Sprintf("%d", scanner.Bandwidth.Value())
+	}
+	return props
+}
+
+// scanStatusEntry merges a freshly sampled iteration count with the previous record of the pod.
+// LastCompleted advances only when the pod completed a new pass since the last sample; the first
+// sample of a pod only records the count, since the completion time of earlier passes is unknown.
+func scanStatusEntry(previous map[string]ozonev1alpha1.DatanodeScanStatus, pod string, iterations int64,
+	now metav1.Time) ozonev1alpha1.DatanodeScanStatus {
+	entry := ozonev1alpha1.DatanodeScanStatus{Pod: pod, CompletedIterations: iterations}
+	if before, ok := previous[pod]; ok {
+		entry.LastCompleted = before.LastCompleted
+		if iterations > before.CompletedIterations {
+			entry.LastCompleted = &now
+		}
+	}
+	return entry
+}
+
+// reconcileScannerStatus samples the data scanner iteration count of every datanode pod into
+// status.dataScans, so the last scan completion per datanode is visible on the CR.
+func (r *OzoneClusterReconciler) reconcileScannerStatus(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if cluster.Spec.Datanode.Scanner == nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+	authHeader, err := r.metricsAuthHeader(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previous := map[string]ozonev1alpha1.DatanodeScanStatus{}
+	for _, scan := range cluster.Status.DataScans {
+		previous[scan.Pod] = scan
+	}
+	now := metav1.Now()
+	var scans []ozonev1alpha1.DatanodeScanStatus
+	for i := int32(0); i < *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3); i++ {
+		pod := fmt.Sprintf("%s-%d", componentName(cluster, datanodeComponent), i)
+		url := fmt.Sprintf("%s://%s:%d/prom",
+			metricsScheme(cluster), podFQDN(cluster, datanodeComponent, i), datanodeHTTPPort)
+		metrics, err := r.getPromMetrics(ctx, url, authHeader)
+		if err != nil {
+			// Keep the last observation of an unreachable pod instead of dropping its history.
+			if scan, ok := previous[pod]; ok {
+				scans = append(scans, scan)
+			}
+			log.V(1).Info("skipping unreachable metrics endpoint", "url", url, "error", err.Error())
+			continue
+		}
+		scans = append(scans, scanStatusEntry(previous, pod, int64(metrics[dataScanIterationsMetric]), now))
+	}
+	cluster.Status.DataScans = scans
+	return ctrl.Result{RequeueAfter: scannerStatusRequeue}, nil
+}
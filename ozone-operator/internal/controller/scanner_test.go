@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestScannerProperties(t *testing.T) {
+	cluster := &ozonev1alpha1.OzoneCluster{}
+	if props := scannerProperties(cluster); props != nil {
+		t.Fatalf("expected no properties without spec.datanode.scanner, got %v", props)
+	}
+
+	enabled := true
+	bandwidth := resource.MustParse("5Mi")
+	cluster.Spec.Datanode.Scanner = &ozonev1alpha1.ScannerSpec{
+		Enabled:              &enabled,
+		MetadataScanInterval: &metav1.Duration{Duration: 3 * time.Hour},
+		DataScanInterval:     &metav1.Duration{Duration: 7 * 24 * time.Hour},
+		Bandwidth:            &bandwidth,
+	}
+	expected := map[string]string{
+		"hdds.container.scrub.enabled":                 "true",
+		"hdds.container.scrub.metadata.scan.interval":  "10800000ms",
+		"hdds.container.scrub.data.scan.interval":      "604800000ms",
+		"hdds.container.scrub.volume.bytes.per.second": "5242880",
+	}
+	props := scannerProperties(cluster)
+	for key, want := range expected {
+		if props[key] != want {
+			t.Errorf("%s: expected %s, got %s", key, want, props[key])
+		}
+	}
+}
+
+func TestScanStatusEntry(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	previous := map[string]ozonev1alpha1.DatanodeScanStatus{
+		"prod-datanode-0": {Pod: "prod-datanode-0", CompletedIterations: 4, LastCompleted: &earlier},
+	}
+
+	entry := scanStatusEntry(previous, "prod-datanode-0", 5, now)
+	if entry.CompletedIterations != 5 || entry.LastCompleted == nil || !entry.LastCompleted.Equal(&now) {
+		t.Errorf("a new pass must advance LastCompleted, got %+v", entry)
+	}
+
+	entry = scanStatusEntry(previous, "prod-datanode-0", 4, now)
+	if entry.LastCompleted == nil || !entry.LastCompleted.Equal(&earlier) {
+		t.Errorf("an unchanged count must keep the earlier LastCompleted, got %+v", entry)
+	}
+
+	entry = scanStatusEntry(previous, "prod-datanode-1", 9, now)
+	if entry.LastCompleted != nil {
+		t.Errorf("the first sample of a pod must not claim a completion time, got %+v", entry)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// scmServicePorts returns the Service ports of the SCM, shared by the headless and the per-pod
+// Services.
+func scmServicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{Name: "client", Port: scmClientPort},
+		{Name: "datanode", Port: scmDatanodePort},
+		{Name: "block", Port: scmBlockPort},
+		{Name: "ui", Port: scmHTTPPort},
+	}
+}
+
+// scmService generates the headless service of the SCM.
+func scmService(cluster *ozonev1alpha1.OzoneCluster) *corev1.Service {
+	service := headlessService(cluster, scmComponent, scmServicePorts())
+	// SCM Ratis peers must resolve each other to form a ring before any replica reports ready.
+	service.Spec.PublishNotReadyAddresses = true
+	return redirectUIPort(cluster, scmComponent, service)
+}
+
+// scmStatefulSet generates the SCM StatefulSet. With persistent storage the scm-init Job formats
+// the cluster before this StatefulSet is created, so pods only bootstrap extra replicas into the
+// Ratis ring. On ephemeral storage an init container runs `ozone scm --init` on every fresh
+// volume instead; the command is a no-op on already-initialized volumes.
+func scmStatefulSet(cluster *ozonev1alpha1.OzoneCluster) *appsv1.StatefulSet {
+	dataMount := corev1.VolumeMount{Name: "data", MountPath: dataMountPath}
+	initContainer := corev1.Container{
+		Name:         "init",
+		Image:        componentImage(cluster, &cluster.Spec.SCM.ComponentSpec),
+		Args:         []string{"ozone", "scm", "--init"},
+		EnvFrom:      configEnvFrom(cluster),
+		VolumeMounts: []corev1.VolumeMount{dataMount},
+	}
+	if *replicasOrDefault(cluster.Spec.SCM.Replicas, 1) > 1 {
+		initContainer.Args = nil
+		initContainer.Command = []string{"sh", "-c",
+			`if [ "${HOSTNAME##*-}" = "0" ]; then exec ozone scm --init; else exec ozone scm --bootstrap; fi`}
+	}
+	initContainers := []corev1.Container{initContainer}
+	if initJobsEnabled(cluster) {
+		if *replicasOrDefault(cluster.Spec.SCM.Replicas, 1) > 1 {
+			initContainer.Command = []string{"sh", "-c",
+				`if [ "${HOSTNAME##*-}" != "0" ]; then exec ozone scm --bootstrap; fi`}
+			initContainers = []corev1.Container{initContainer}
+		} else {
+			initContainers = nil
+		}
+	}
+	pod := corev1.PodSpec{
+		InitContainers: initContainers,
+		Containers: []corev1.Container{{
+			Name:            scmComponent,
+			Image:           componentImage(cluster, &cluster.Spec.SCM.ComponentSpec),
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Args:            []string{"ozone", "scm"},
+			EnvFrom:         configEnvFrom(cluster),
+			VolumeMounts:    []corev1.VolumeMount{dataMount},
+			StartupProbe: startupProbe(corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(scmDatanodePort)},
+			}, startupWindow(&cluster.Spec.SCM.ComponentSpec, metadataStartupWindow)),
+			LivenessProbe: adminCheckProbe(scmComponent),
+		}},
+	}
+	if *replicasOrDefault(cluster.Spec.SCM.Replicas, 1) > 1 {
+		// Hand off SCM Ratis leadership before shutdown so rolling restarts stay client-transparent.
+		pod.Containers[0].Lifecycle = leadershipTransferPreStop(scmComponent)
+	}
+	pod.Affinity = componentAntiAffinity(cluster, scmComponent, cluster.Spec.SCM.AntiAffinity)
+	pod.TopologySpreadConstraints = zoneSpreadConstraints(cluster, scmComponent)
+	if cluster.Spec.TopologyAware {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "topology",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: topologyConfigMapName(cluster)},
+				},
+			},
+		})
+		pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "topology",
+			MountPath: topologyMountPath,
+		})
+	}
+	applyComponentSpec(&pod, &cluster.Spec.SCM.ComponentSpec)
+	applyUIAuth(cluster, scmComponent, &pod, scmHTTPPort)
+
+	sts := baseStatefulSet(cluster, scmComponent, replicasOrDefault(cluster.Spec.SCM.Replicas, 1),
+		pod, prometheusAnnotations(cluster, scmHTTPPort))
+	applyComponentStorage(sts, &cluster.Spec.SCM.Storage)
+	applyUpdateStrategy(sts, &cluster.Spec.SCM.ComponentSpec)
+	return sts
+}
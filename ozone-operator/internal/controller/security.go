@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// metricsTLSMountPath is where the metrics TLS certificate Secret is mounted in every component.
+const metricsTLSMountPath = "/opt/hadoop/etc/tls"
+
+// metricsSecurity returns the metrics protection settings, or nil when unset.
+func metricsSecurity(cluster *ozonev1alpha1.OzoneCluster) *ozonev1alpha1.MetricsSecuritySpec {
+	if cluster.Spec.Security == nil {
+		return nil
+	}
+	return cluster.Spec.Security.Metrics
+}
+
+// securityProperties maps spec.security onto its ozone-site properties. The metrics credentials
+// are not rendered here; they reach the components as env entries sourced from the referenced
+// Secret, so they never land in the shared ConfigMap.
+func securityProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	props := map[string]string{}
+	if metrics := metricsSecurity(cluster); metrics != nil && metrics.TLSSecret != "" {
+		props["ozone.http.policy"] = "HTTPS_ONLY"
+		props["hdds.http.ssl.certificate.file"] = metricsTLSMountPath + "/tls.crt"
+		props["hdds.http.ssl.key.file"] = metricsTLSMountPath + "/tls.key"
+	}
+	if fipsEnabled(cluster) {
+		// Pin the approved protocol versions and a FIPS-certified keystore type; anything weaker
+		// is rejected by the admission webhook rather than silently overridden here.
+		props["hadoop.ssl.enabled.protocols"] = "TLSv1.2,TLSv1.3"
+		props["ssl.server.keystore.type"] = "BCFKS"
+		props["ssl.client.keystore.type"] = "BCFKS"
+	}
+	if uri := keyProviderURI(cluster); uri != "" {
+		props["hadoop.security.key.provider.path"] = uri
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
+
+// clusterEncryption returns the TDE settings of the cluster, or nil when encryption is not
+// configured.
+func clusterEncryption(cluster *ozonev1alpha1.OzoneCluster) *ozonev1alpha1.EncryptionSpec {
+	if cluster.Spec.Security == nil {
+		return nil
+	}
+	return cluster.Spec.Security.Encryption
+}
+
+// keyProviderURI returns the Hadoop key provider URI of the cluster: the external KMS from
+// spec.security.encryption when configured, otherwise the embedded KMS, otherwise empty.
+func keyProviderURI(cluster *ozonev1alpha1.OzoneCluster) string {
+	if encryption := clusterEncryption(cluster); encryption != nil {
+		return encryption.KMSURI
+	}
+	if cluster.Spec.KMS != nil {
+		return embeddedKMSURI(cluster)
+	}
+	return ""
+}
+
+// fipsEnabled reports whether the cluster runs in restricted-crypto mode.
+func fipsEnabled(cluster *ozonev1alpha1.OzoneCluster) bool {
+	return cluster.Spec.Security != nil && cluster.Spec.Security.FIPS
+}
+
+// clusterImage returns the image every component runs, switching to the FIPS-ready build in FIPS
+// mode.
+func clusterImage(cluster *ozonev1alpha1.OzoneCluster) string {
+	if !fipsEnabled(cluster) {
+		return cluster.Spec.Image
+	}
+	if cluster.Spec.Security.FIPSImage != "" {
+		return cluster.Spec.Security.FIPSImage
+	}
+	return cluster.Spec.Image + "-fips"
+}
+
+// componentImage returns the image of one component: its own override when set, otherwise the
+// cluster-wide (possibly FIPS) image.
+func componentImage(cluster *ozonev1alpha1.OzoneCluster, spec *ozonev1alpha1.ComponentSpec) string {
+	if spec.Image != "" {
+		return spec.Image
+	}
+	return clusterImage(cluster)
+}
+
+// applyMetricsSecurity wires the metrics TLS certificate and the auth credentials of
+// spec.security.metrics into a component pod: the TLS Secret is mounted for the HTTPS endpoints,
+// and the credentials become ozone-site entries through the image's env prefix without passing
+// through the ConfigMap.
+func applyMetricsSecurity(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	metrics := metricsSecurity(cluster)
+	if metrics == nil {
+		return
+	}
+	if metrics.TLSSecret != "" {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "metrics-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: metrics.TLSSecret},
+			},
+		})
+		for i := range pod.Containers {
+			pod.Containers[i].VolumeMounts = append(pod.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      "metrics-tls",
+				MountPath: metricsTLSMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+	for _, env := range metricsAuthEnv(metrics) {
+		for i := range pod.Containers {
+			pod.Containers[i].Env = append(pod.Containers[i].Env, env)
+		}
+	}
+}
+
+// metricsAuthEnv returns the env entries feeding the metrics credentials into ozone-site.
+func metricsAuthEnv(metrics *ozonev1alpha1.MetricsSecuritySpec) []corev1.EnvVar {
+	secretEnv := func(property, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: ozoneSitePrefix + property,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: metrics.CredentialsSecret},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	switch metrics.Auth {
+	case ozonev1alpha1.MetricsAuthBasic:
+		return []corev1.EnvVar{
+			secretEnv("hdds.prometheus.endpoint.basic.username", "username"),
+			secretEnv("hdds.prometheus.endpoint.basic.password", "password"),
+		}
+	case ozonev1alpha1.MetricsAuthBearer:
+		return []corev1.EnvVar{secretEnv("hdds.prometheus.endpoint.token", "token")}
+	}
+	return nil
+}
+
+// writableRuntimeDirs are the paths Ozone writes outside its data volume; with a read-only root
+// filesystem each becomes an emptyDir mount. Kept in a fixed order so repeated reconciles
+// generate identical pod templates.
+var writableRuntimeDirs = []struct {
+	name string
+	path string
+}{
+	{name: "tmp", path: "/tmp"},
+	{name: "logs", path: "/opt/hadoop/logs"},
+	{name: "run", path: "/var/run/ozone"},
+}
+
+// applyHardening sets readOnlyRootFilesystem on every container of a component pod and backs the
+// runtime directories with emptyDirs, so the workloads pass admission policies that require an
+// immutable root filesystem.
+func applyHardening(cluster *ozonev1alpha1.OzoneCluster, pod *corev1.PodSpec) {
+	if cluster.Spec.Security == nil || !cluster.Spec.Security.ReadOnlyRootFilesystem {
+		return
+	}
+	readOnly := true
+	mounts := make([]corev1.VolumeMount, 0, len(writableRuntimeDirs))
+	for _, dir := range writableRuntimeDirs {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name:         dir.name,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: dir.name, MountPath: dir.path})
+	}
+	containers := make([]*corev1.Container, 0, len(pod.Containers)+len(pod.InitContainers))
+	for i := range pod.Containers {
+		containers = append(containers, &pod.Containers[i])
+	}
+	for i := range pod.InitContainers {
+		containers = append(containers, &pod.InitContainers[i])
+	}
+	for _, container := range containers {
+		if container.SecurityContext == nil {
+			container.SecurityContext = &corev1.SecurityContext{}
+		}
+		container.SecurityContext.ReadOnlyRootFilesystem = &readOnly
+		container.VolumeMounts = append(container.VolumeMounts, mounts...)
+	}
+}
+
+// metricsScheme returns the URL scheme of the component web endpoints.
+func metricsScheme(cluster *ozonev1alpha1.OzoneCluster) string {
+	if metrics := metricsSecurity(cluster); metrics != nil && metrics.TLSSecret != "" {
+		return "https"
+	}
+	return "http"
+}
+
+// metricsAuthHeader builds the Authorization header value the operator presents when it samples
+// the protected /prom endpoints; empty when no auth is configured.
+func (r *OzoneClusterReconciler) metricsAuthHeader(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (string, error) {
+	metrics := metricsSecurity(cluster)
+	if metrics == nil || metrics.Auth == "" {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: metrics.CredentialsSecret}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return "", fmt.Errorf("reading metrics credentials secret %s: %w", metrics.CredentialsSecret, err)
+	}
+	switch metrics.Auth {
+	case ozonev1alpha1.MetricsAuthBasic:
+		credentials := string(secret.Data["username"]) + ":" + string(secret.Data["password"])
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+	case ozonev1alpha1.MetricsAuthBearer:
+		return "Bearer " + string(secret.Data["token"]), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// exportTargetLabel records which snapshot an export Job copies, so a finished Job of an
+	// older snapshot can be told apart from the Job of the current one.
+	exportTargetLabel = "ozone.apache.org/target-snapshot"
+
+	// exportPollInterval is how often a running export Job is rechecked.
+	exportPollInterval = 30 * time.Second
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// reconcileSnapshotExport drives the export Job of the newest snapshot and returns how soon the
+// object needs another look (zero when no export is pending). A failed Job is removed and reported
+// as an error so the export is retried.
+func (r *OzoneSnapshotReconciler) reconcileSnapshotExport(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, snapshot *ozonev1alpha1.OzoneSnapshot) (time.Duration, error) {
+	if snapshot.Spec.Export == nil || len(snapshot.Status.Snapshots) == 0 {
+		return 0, nil
+	}
+	newest := snapshot.Status.Snapshots[len(snapshot.Status.Snapshots)-1].Name
+	if newest == snapshot.Status.LastExportedSnapshot {
+		return 0, nil
+	}
+	log := logf.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: snapshot.Namespace, Name: snapshot.Name + "-export"}
+	if err := r.Get(ctx, key, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, err
+		}
+		job := snapshotExportJob(cluster, snapshot, snapshot.Status.LastExportedSnapshot, newest)
+		if err := controllerutil.SetControllerReference(snapshot, job, r.Scheme); err != nil {
+			return 0, err
+		}
+		log.Info("starting snapshot export", "job", job.Name, "snapshot", newest,
+			"since", snapshot.Status.LastExportedSnapshot)
+		return exportPollInterval, r.Create(ctx, job)
+	}
+
+	if job.Labels[exportTargetLabel] != newest {
+		// A leftover Job of an earlier snapshot; remove it so the current export can start.
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!errors.IsNotFound(err) {
+			return 0, err
+		}
+		return exportPollInterval, nil
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			snapshot.Status.LastExportedSnapshot = newest
+			return 0, nil
+		}
+		if condition.Type == batchv1.JobFailed {
+			if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+				!errors.IsNotFound(err) {
+				return 0, err
+			}
+			return 0, fmt.Errorf("the export job of snapshot %s failed (%s)", newest, condition.Reason)
+		}
+	}
+	return exportPollInterval, nil
+}
+
+// snapshotExportJob builds the Job exporting one snapshot to the S3 destination. The first export
+// (from is empty) copies the full snapshot directory; later exports copy only the keys that
+// `ozone sh snapshot diff` reports as created or modified since the previously exported snapshot.
+func snapshotExportJob(cluster *ozonev1alpha1.OzoneCluster,
+	snapshot *ozonev1alpha1.OzoneSnapshot, from, to string) *batchv1.Job {
+	export := snapshot.Spec.Export
+	bucketPath := "/" + snapshot.Spec.Volume + "/" + snapshot.Spec.Bucket
+	bucketRoot := "ofs://" + podFQDN(cluster, omComponent, 0) + bucketPath
+	destination := "s3a://" + strings.TrimPrefix(export.Destination, "s3://")
+	if !strings.HasSuffix(destination, "/") {
+		destination += "/"
+	}
+	fsOptions := ""
+	if export.S3 != nil && export.S3.Endpoint != "" {
+		fsOptions = "-D fs.s3a.endpoint=" + export.S3.Endpoint
+	}
+
+	var script string
+	if from == "" {
+		script = fmt.Sprintf("set -e\nozone fs %s -cp -f %s/.snapshot/%s/ %s\n",
+			fsOptions, bucketRoot, to, destination)
+	} else {
+		// The awk strips only the leading diff marker instead of printing $2, so keys containing
+		// spaces are exported whole rather than truncated at the first space.
+		script = fmt.Sprintf(`set -e
+ozone sh snapshot diff %s %s %s | awk '$1 == "+" || $1 == "M" {sub(/^ *[^ ]+ +/, ""); print}' | while read -r key; do
+  ozone fs %s -cp -f "%s/.snapshot/%s/$key" "%s$key"
+done
+`, bucketPath, from, to, fsOptions, bucketRoot, to, destination)
+	}
+
+	var env []corev1.EnvVar
+	if export.S3 != nil {
+		env = []corev1.EnvVar{
+			{Name: "AWS_ACCESS_KEY_ID", ValueFrom: secretKeyRef(export.S3.CredentialsSecret, "accessKey")},
+			{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef(export.S3.CredentialsSecret, "secretKey")},
+		}
+	}
+	labels := componentLabels(cluster, "snapshot-export")
+	labels[exportTargetLabel] = to
+	backoffLimit := int32(2)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshot.Name + "-export",
+			Namespace: snapshot.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "export",
+						Image:   clusterImage(cluster),
+						Args:    []string{"bash", "-c", script},
+						Env:     env,
+						EnvFrom: configEnvFrom(cluster),
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestSnapshotExportJob(t *testing.T) {
+	cluster := &ozonev1alpha1.OzoneCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec:       ozonev1alpha1.OzoneClusterSpec{Image: "apache/ozone:1.4.0"},
+	}
+	snapshot := &ozonev1alpha1.OzoneSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+		Spec: ozonev1alpha1.OzoneSnapshotSpec{
+			Cluster: "prod",
+			Volume:  "vol1",
+			Bucket:  "bucket1",
+			Export: &ozonev1alpha1.SnapshotExportSpec{
+				Destination: "s3://exports/prod",
+				S3:          &ozonev1alpha1.BackupS3Spec{Endpoint: "https://minio:9000", CredentialsSecret: "export-creds"},
+			},
+		},
+	}
+
+	full := snapshotExportJob(cluster, snapshot, "", "hourly-1")
+	if full.Name != "hourly-export" || full.Labels[exportTargetLabel] != "hourly-1" {
+		t.Fatalf("unexpected job identity: %s %v", full.Name, full.Labels)
+	}
+	script := full.Spec.Template.Spec.Containers[0].Args[2]
+	if strings.Contains(script, "snapshot diff") {
+		t.Errorf("the first export must copy the full snapshot, got:\n%s", script)
+	}
+	if !strings.Contains(script, "ofs://prod-om-0.prod-om/vol1/bucket1/.snapshot/hourly-1/") ||
+		!strings.Contains(script, "s3a://exports/prod/") {
+		t.Errorf("full export script misses source or destination:\n%s", script)
+	}
+
+	incremental := snapshotExportJob(cluster, snapshot, "hourly-1", "hourly-2")
+	script = incremental.Spec.Template.Spec.Containers[0].Args[2]
+	if !strings.Contains(script, "ozone sh snapshot diff /vol1/bucket1 hourly-1 hourly-2") {
+		t.Errorf("incremental export must diff against the previously exported snapshot:\n%s", script)
+	}
+	if !strings.Contains(script, "-D fs.s3a.endpoint=https://minio:9000") {
+		t.Errorf("incremental export script misses the endpoint option:\n%s", script)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// spotTaintKeys are the taints the managed Kubernetes offerings put on spot/preemptible nodes.
+// Spot-tolerant datanode pods tolerate these so they can be scheduled there at all.
+var spotTaintKeys = []string{
+	"kubernetes.azure.com/scalesetpriority",
+	"cloud.google.com/gke-spot",
+	"cloud.google.com/gke-preemptible",
+}
+
+// terminationNoticeTaintKeys are the taints node termination handlers and autoscalers put on a
+// node that is about to go away: a spot interruption or scheduled maintenance notice, a cluster
+// autoscaler scale-down candidate, or a Karpenter disruption.
+var terminationNoticeTaintKeys = []string{
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/scheduled-maintenance",
+	"ToBeDeletedByClusterAutoscaler",
+	"DeletionCandidateOfClusterAutoscaler",
+	"karpenter.sh/disruption",
+	"karpenter.sh/disrupted",
+}
+
+// spotTolerations returns the tolerations of spot-tolerant datanode pods: the spot taints so the
+// pods schedule onto spot nodes, and the termination notice taints so the pods are not evicted by
+// a NoExecute notice before the datanode entered Ozone maintenance mode.
+func spotTolerations() []corev1.Toleration {
+	keys := make([]string, 0, len(spotTaintKeys)+len(terminationNoticeTaintKeys))
+	keys = append(keys, spotTaintKeys...)
+	keys = append(keys, terminationNoticeTaintKeys...)
+	tolerations := make([]corev1.Toleration, 0, len(keys))
+	for _, key := range keys {
+		tolerations = append(tolerations, corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists})
+	}
+	return tolerations
+}
+
+// nodeHasTerminationNotice reports whether a node carries a taint announcing its upcoming
+// preemption or removal.
+func nodeHasTerminationNotice(node *corev1.Node) bool {
+	for i := range node.Spec.Taints {
+		for _, key := range terminationNoticeTaintKeys {
+			if node.Spec.Taints[i].Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rebalanceAfterRecommission triggers one Container Balancer run after spot capacity returned, so
+// the containers re-replicated while nodes were gone spread back over the recovered datanodes.
+// The balancer stops on its own when the cluster is balanced; when spec.balancer is managed and
+// enabled, reconcileBalancer keeps owning the run state.
+func (r *OzoneClusterReconciler) rebalanceAfterRecommission(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) {
+	if cluster.Status.Balancer != nil && cluster.Status.Balancer.Running {
+		return
+	}
+	command := []string{"ozone", "admin", "containerbalancer", "start"}
+	if cluster.Spec.Balancer != nil {
+		command = balancerStartCommand(cluster.Spec.Balancer)
+	}
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	if _, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent, command...); err != nil {
+		// Best effort: the data is already fully replicated, the balancer only evens out placement.
+		logf.FromContext(ctx).Error(err, "failed to start the balancer after spot capacity returned")
+		return
+	}
+	now := metav1.Now()
+	cluster.Status.Balancer = &ozonev1alpha1.BalancerStatus{Running: true, LastTransitionTime: &now}
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestNodeHasTerminationNotice(t *testing.T) {
+	node := func(taintKeys ...string) *corev1.Node {
+		taints := make([]corev1.Taint, 0, len(taintKeys))
+		for _, key := range taintKeys {
+			taints = append(taints, corev1.Taint{Key: key, Effect: corev1.TaintEffectNoSchedule})
+		}
+		return &corev1.Node{Spec: corev1.NodeSpec{Taints: taints}}
+	}
+	if nodeHasTerminationNotice(node()) {
+		t.Error("a node without taints has no termination notice")
+	}
+	if nodeHasTerminationNotice(node("kubernetes.azure.com/scalesetpriority")) {
+		t.Error("a plain spot taint is not a termination notice")
+	}
+	if !nodeHasTerminationNotice(node("node.kubernetes.io/unreachable", "aws-node-termination-handler/spot-itn")) {
+		t.Error("a spot interruption taint is a termination notice")
+	}
+	if !nodeHasTerminationNotice(node("ToBeDeletedByClusterAutoscaler")) {
+		t.Error("a cluster autoscaler scale-down taint is a termination notice")
+	}
+}
+
+func TestDatanodeSpotTolerations(t *testing.T) {
+	cluster := &ozonev1alpha1.OzoneCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "spot", Namespace: "default"},
+		Spec:       ozonev1alpha1.OzoneClusterSpec{Image: "apache/ozone:1.4.0"},
+	}
+	sts := datanodeStatefulSet(cluster)
+	if len(sts.Spec.Template.Spec.Tolerations) != 0 {
+		t.Fatalf("expected no tolerations without spotTolerant, got %v", sts.Spec.Template.Spec.Tolerations)
+	}
+
+	cluster.Spec.Datanode.SpotTolerant = true
+	sts = datanodeStatefulSet(cluster)
+	tolerated := map[string]bool{}
+	for _, toleration := range sts.Spec.Template.Spec.Tolerations {
+		tolerated[toleration.Key] = true
+	}
+	for _, key := range []string{"cloud.google.com/gke-spot", "aws-node-termination-handler/spot-itn"} {
+		if !tolerated[key] {
+			t.Errorf("spot-tolerant datanodes should tolerate %s, got %v", key, tolerated)
+		}
+	}
+}
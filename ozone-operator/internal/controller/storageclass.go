@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	storagev1 "k8s.io/api/storage/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// defaultStorageClassAnnotation marks the cluster-default StorageClass.
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// pinStorageClass sets an unset storageClass of persistent storage to the resolved default.
+func pinStorageClass(storageClass **string, ephemeral bool, defaultClass string) bool {
+	if ephemeral || *storageClass != nil {
+		return false
+	}
+	pinned := defaultClass
+	*storageClass = &pinned
+	return true
+}
+
+// pinDefaultStorageClass resolves the cluster-default StorageClass and persists it into every
+// unset storageClass of the spec. StatefulSet volumeClaimTemplates are immutable, so without the
+// pin a later change of the cluster default would make the generated templates diverge from the
+// existing StatefulSets and fail their update.
+func (r *OzoneClusterReconciler) pinDefaultStorageClass(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	unpinned := !cluster.Spec.SCM.Storage.Ephemeral && cluster.Spec.SCM.Storage.StorageClass == nil ||
+		!cluster.Spec.OM.Storage.Ephemeral && cluster.Spec.OM.Storage.StorageClass == nil
+	if cluster.Spec.Recon != nil {
+		unpinned = unpinned ||
+			!cluster.Spec.Recon.Storage.Ephemeral && cluster.Spec.Recon.Storage.StorageClass == nil
+	}
+	for i := range cluster.Spec.Datanode.Volumes {
+		volume := &cluster.Spec.Datanode.Volumes[i]
+		unpinned = unpinned || !volume.Ephemeral && volume.StorageClass == nil
+	}
+	if !unpinned {
+		return nil
+	}
+
+	classes := &storagev1.StorageClassList{}
+	if err := r.List(ctx, classes); err != nil {
+		return storageError(fmt.Errorf("listing storage classes: %w", err))
+	}
+	defaultClass := ""
+	for i := range classes.Items {
+		if classes.Items[i].Annotations[defaultStorageClassAnnotation] == "true" {
+			defaultClass = classes.Items[i].Name
+			break
+		}
+	}
+	if defaultClass == "" {
+		logf.FromContext(ctx).Info("no default StorageClass found, leaving unset storageClass fields unpinned")
+		return nil
+	}
+
+	changed := pinStorageClass(&cluster.Spec.SCM.Storage.StorageClass, cluster.Spec.SCM.Storage.Ephemeral, defaultClass)
+	changed = pinStorageClass(&cluster.Spec.OM.Storage.StorageClass, cluster.Spec.OM.Storage.Ephemeral, defaultClass) || changed
+	if cluster.Spec.Recon != nil {
+		changed = pinStorageClass(&cluster.Spec.Recon.Storage.StorageClass,
+			cluster.Spec.Recon.Storage.Ephemeral, defaultClass) || changed
+	}
+	for i := range cluster.Spec.Datanode.Volumes {
+		volume := &cluster.Spec.Datanode.Volumes[i]
+		changed = pinStorageClass(&volume.StorageClass, volume.Ephemeral, defaultClass) || changed
+	}
+	if !changed {
+		return nil
+	}
+	return r.Update(ctx, cluster)
+}
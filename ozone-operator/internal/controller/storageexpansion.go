@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// storageExpandRequeue is how often metadata volume usage is sampled while autoExpand is set.
+const storageExpandRequeue = 5 * time.Minute
+
+// defaultExpandUsagePercent triggers an expansion when autoExpand.usagePercent is unset.
+const defaultExpandUsagePercent = int32(80)
+
+// defaultExpandStepPercent is the growth per expansion when autoExpand.stepPercent is unset.
+const defaultExpandStepPercent = int32(20)
+
+// reconcileStorageExpansion samples the usage of SCM, OM and Recon metadata volumes and grows
+// their PVCs before RocksDB runs into "no space left". Expansion patches the live claims; the
+// StatefulSet volumeClaimTemplates stay at spec.storage.size since they are immutable, so new
+// replicas start at the configured size and grow on their own once they fill up.
+func (r *OzoneClusterReconciler) reconcileStorageExpansion(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if r.Executor == nil {
+		return ctrl.Result{}, nil
+	}
+	targets := []struct {
+		component string
+		storage   *ozonev1alpha1.StorageSpec
+		replicas  int32
+	}{
+		{scmComponent, &cluster.Spec.SCM.Storage, *replicasOrDefault(cluster.Spec.SCM.Replicas, 1)},
+		{omComponent, &cluster.Spec.OM.Storage, *replicasOrDefault(cluster.Spec.OM.Replicas, 1)},
+	}
+	if cluster.Spec.Recon != nil {
+		targets = append(targets, struct {
+			component string
+			storage   *ozonev1alpha1.StorageSpec
+			replicas  int32
+		}{reconComponent, &cluster.Spec.Recon.Storage, *replicasOrDefault(cluster.Spec.Recon.Replicas, 1)})
+	}
+
+	sampled := false
+	for _, target := range targets {
+		if target.storage.AutoExpand == nil || target.storage.Ephemeral {
+			continue
+		}
+		sampled = true
+		for ordinal := int32(0); ordinal < target.replicas; ordinal++ {
+			if err := r.expandVolumeIfNeeded(ctx, cluster, target.component, ordinal,
+				target.storage.AutoExpand); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	if sampled {
+		return ctrl.Result{RequeueAfter: storageExpandRequeue}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// expandVolumeIfNeeded samples one replica's data volume and grows its PVC by the configured step
+// when usage crossed the threshold, capped at autoExpand.maxSize.
+func (r *OzoneClusterReconciler) expandVolumeIfNeeded(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, component string, ordinal int32,
+	autoExpand *ozonev1alpha1.StorageAutoExpandSpec) error {
+	log := logf.FromContext(ctx)
+	podName := fmt.Sprintf("%s-%d", componentName(cluster, component), ordinal)
+	out, err := r.Executor.Exec(ctx, cluster.Namespace, podName, component, "df", "-P", "-k", dataMountPath)
+	if err != nil {
+		// The pod may be starting or restarting; the next sample will catch up.
+		return nil
+	}
+	usage, err := parseDfUsage(out)
+	if err != nil {
+		log.Error(err, "failed to parse volume usage", "pod", podName)
+		return nil
+	}
+	threshold := defaultExpandUsagePercent
+	if autoExpand.UsagePercent != nil {
+		threshold = *autoExpand.UsagePercent
+	}
+	if usage < threshold {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: "data-" + podName}
+	if err := r.Get(ctx, key, pvc); err != nil {
+		return err
+	}
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	step := defaultExpandStepPercent
+	if autoExpand.StepPercent != nil {
+		step = *autoExpand.StepPercent
+	}
+	grown := current.Value() * int64(100+step) / 100
+	if max := autoExpand.MaxSize.Value(); grown > max {
+		grown = max
+	}
+	if grown <= current.Value() {
+		if r.Recorder != nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "StorageExpansionCapped",
+				"volume of %s is %d%% full but its claim already reached autoExpand.maxSize %s",
+				podName, usage, autoExpand.MaxSize.String())
+		}
+		return nil
+	}
+
+	newSize := *resource.NewQuantity(grown, resource.BinarySI)
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = newSize
+	if err := r.Update(ctx, pvc); err != nil {
+		return storageError(fmt.Errorf("expanding PVC %s: %w", pvc.Name, err))
+	}
+	log.Info("expanded metadata volume", "pvc", pvc.Name, "usage", usage,
+		"from", current.String(), "to", newSize.String())
+	if r.Recorder != nil {
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "StorageExpanded",
+			"expanded PVC %s from %s to %s at %d%% usage", pvc.Name, current.String(), newSize.String(), usage)
+	}
+	return nil
+}
+
+// parseDfUsage extracts the usage percentage from POSIX `df -P` output.
+func parseDfUsage(out string) (int32, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output %q", out)
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 5 {
+		return 0, fmt.Errorf("unexpected df line %q", lines[len(lines)-1])
+	}
+	usage, err := strconv.Atoi(strings.TrimSuffix(fields[4], "%"))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected df usage column %q: %v", fields[4], err)
+	}
+	return int32(usage), nil
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import "testing"
+
+func TestParseDfUsage(t *testing.T) {
+	out := `Filesystem     1024-blocks     Used Available Capacity Mounted on
+/dev/sdb1         20961280 17788544   3172736      85% /data
+`
+	usage, err := parseDfUsage(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage != 85 {
+		t.Errorf("parseDfUsage = %d, want 85", usage)
+	}
+
+	for _, broken := range []string{"", "Filesystem only header", "one\ntoo few fields"} {
+		if _, err := parseDfUsage(broken); err == nil {
+			t.Errorf("expected an error for %q", broken)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionDegraded is True while SCM reports under-replicated containers or no open pipeline,
+// i.e. data is readable but redundancy or write availability is reduced.
+const ConditionDegraded = "Degraded"
+
+// storageHealthRequeue is how often the SCM pipeline and container statistics are collected.
+const storageHealthRequeue = 5 * time.Minute
+
+// reconcileStorageHealth collects pipeline and container replication statistics from SCM into
+// status.storageHealth and derives the Degraded condition from them.
+func (r *OzoneClusterReconciler) reconcileStorageHealth(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	if (r.Executor == nil && r.AdminClient == nil) || cluster.Spec.ExternalControlPlane != nil {
+		return ctrl.Result{}, nil
+	}
+
+	report, err := r.adminClient(cluster).StorageReport(ctx)
+	if err != nil {
+		// SCM may be restarting or still in safemode; keep the last report and poll again.
+		return ctrl.Result{RequeueAfter: storageHealthRequeue}, nil
+	}
+	now := metav1.Now()
+	cluster.Status.StorageHealth = &ozonev1alpha1.StorageHealthStatus{
+		OpenPipelines:             report.OpenPipelines,
+		ClosedPipelines:           report.ClosedPipelines,
+		TotalContainers:           report.TotalContainers,
+		UnderReplicatedContainers: report.UnderReplicated,
+		OverReplicatedContainers:  report.OverReplicated,
+		LastProbeTime:             &now,
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "StorageHealthy",
+		Message:            fmt.Sprintf("%d open pipelines, %d containers", report.OpenPipelines, report.TotalContainers),
+		ObservedGeneration: cluster.Generation,
+	}
+	switch {
+	case report.UnderReplicated > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UnderReplicatedContainers"
+		condition.Message = fmt.Sprintf("%d of %d containers are under-replicated",
+			report.UnderReplicated, report.TotalContainers)
+	case report.OpenPipelines == 0 && report.TotalContainers > 0:
+		// A cluster that already stores data but has no open pipeline cannot take writes.
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "NoOpenPipelines"
+		condition.Message = "no pipeline is open for new writes"
+	}
+	previous := meta.FindStatusCondition(cluster.Status.Conditions, ConditionDegraded)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	// Notify only when the condition flips, not on every probe; a cluster that starts out healthy
+	// stays silent.
+	if (previous == nil && condition.Status == metav1.ConditionTrue) ||
+		(previous != nil && previous.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return ctrl.Result{RequeueAfter: storageHealthRequeue}, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+const (
+	// topologyZoneLabel is the well-known node label carrying the availability zone.
+	topologyZoneLabel = "topology.kubernetes.io/zone"
+
+	// topologyMountPath is where the topology table ConfigMap is mounted into the SCM pod.
+	topologyMountPath = "/etc/ozone/topology"
+
+	// topologyTableFile is the TableMapping file name inside the topology ConfigMap.
+	topologyTableFile = "topology.table"
+)
+
+// topologyConfigMapName returns the name of the network topology table ConfigMap.
+func topologyConfigMapName(cluster *ozonev1alpha1.OzoneCluster) string {
+	return cluster.Name + "-topology"
+}
+
+// zoneSpreadConstraints spreads the pods of a component across zones when spec.topologyAware is
+// set; nil otherwise.
+func zoneSpreadConstraints(cluster *ozonev1alpha1.OzoneCluster,
+	component string) []corev1.TopologySpreadConstraint {
+	if !cluster.Spec.TopologyAware {
+		return nil
+	}
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       topologyZoneLabel,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: componentLabels(cluster, component)},
+	}}
+}
+
+// reconcileTopology maintains the network topology table mapping each datanode host name onto the
+// zone of its node, consumed by SCM through Hadoop's TableMapping. Hosts without a zone label land
+// in the default rack.
+func (r *OzoneClusterReconciler) reconcileTopology(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) error {
+	if !cluster.Spec.TopologyAware {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(componentLabels(cluster, datanodeComponent))); err != nil {
+		return err
+	}
+	lines := make([]string, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		zone := "default"
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err == nil {
+			if label := node.Labels[topologyZoneLabel]; label != "" {
+				zone = label
+			}
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("%s.%s /%s",
+			pod.Name, componentName(cluster, datanodeComponent), zone))
+	}
+	sort.Strings(lines)
+
+	topology := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      topologyConfigMapName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    componentLabels(cluster, "topology"),
+		},
+		Data: map[string]string{
+			topologyTableFile: strings.Join(lines, "\n") + "\n",
+		},
+	}
+	return r.apply(ctx, cluster, topology)
+}
+
+// topologyProperties returns the ozone-site.xml entries enabling zone-aware replica placement;
+// empty unless spec.topologyAware is set.
+func topologyProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	if !cluster.Spec.TopologyAware {
+		return nil
+	}
+	return map[string]string{
+		"ozone.network.topology.aware.read":     "true",
+		"net.topology.node.switch.mapping.impl": "org.apache.hadoop.net.TableMapping",
+		"net.topology.table.file.name":          topologyMountPath + "/" + topologyTableFile,
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// tuningProperties maps spec.tuning onto its ozone-site properties. Sizes are rendered as byte
+// counts so the quantities stay unit-exact. Returns nil when spec.tuning is unset.
+func tuningProperties(cluster *ozonev1alpha1.OzoneCluster) map[string]string {
+	tuning := cluster.Spec.Tuning
+	if tuning == nil {
+		return nil
+	}
+	props := map[string]string{}
+	if tuning.RatisSegmentSize != nil {
+		size := fmt.Sprintf("%d", tuning.RatisSegmentSize.Value())
+		props["ozone.om.ratis.segment.size"] = size
+		props["ozone.scm.ha.ratis.segment.size"] = size
+		props["hdds.container.ratis.segment.size"] = size
+	}
+	if tuning.RatisSnapshotThreshold != nil {
+		threshold := fmt.Sprintf("%d", *tuning.RatisSnapshotThreshold)
+		props["ozone.om.ratis.snapshot.threshold"] = threshold
+		props["ozone.scm.ha.ratis.snapshot.threshold"] = threshold
+	}
+	if tuning.RocksDBBlockCacheSize != nil {
+		size := fmt.Sprintf("%d", tuning.RocksDBBlockCacheSize.Value())
+		props["ozone.metadata.rocksdb.block.cache.size"] = size
+		props["hdds.datanode.metadata.rocksdb.cache.size"] = size
+	}
+	if tuning.RocksDBWriteBufferSize != nil {
+		props["ozone.metadata.rocksdb.write.buffer.size"] = fmt.Sprintf("%d", tuning.RocksDBWriteBufferSize.Value())
+	}
+	if tuning.CompactionThreads != nil {
+		props["ozone.metadata.rocksdb.compaction.threads"] = fmt.Sprintf("%d", *tuning.CompactionThreads)
+	}
+	if deletion := tuning.KeyDeletion; deletion != nil {
+		if deletion.ServiceInterval != nil {
+			props["ozone.key.deleting.service.interval"] =
+				fmt.Sprintf("%dms", deletion.ServiceInterval.Duration.Milliseconds())
+		}
+		if deletion.KeyLimitPerTask != nil {
+			props["ozone.key.deleting.limit.per.task"] = fmt.Sprintf("%d", *deletion.KeyLimitPerTask)
+		}
+		if deletion.BlockDeletionInterval != nil {
+			props["hdds.scm.block.deletion.service.interval"] =
+				fmt.Sprintf("%dms", deletion.BlockDeletionInterval.Duration.Milliseconds())
+		}
+		if deletion.BlockDeletionLimit != nil {
+			props["hdds.scm.block.deletion.per-interval.max"] = fmt.Sprintf("%d", *deletion.BlockDeletionLimit)
+		}
+	}
+	return props
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// oauthProxyPort is where the oauth2-proxy sidecar listens; the component Services send UI
+// traffic here instead of the unauthenticated HTTP port.
+const oauthProxyPort = int32(4180)
+
+// defaultOAuthProxyImage is the sidecar image used when spec.security.ui.image is empty.
+const defaultOAuthProxyImage = "quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"
+
+// uiAuthEnabled reports whether a component's web UI is put behind the OAuth2 sidecar.
+func uiAuthEnabled(cluster *ozonev1alpha1.OzoneCluster, component string) bool {
+	if cluster.Spec.Security == nil || cluster.Spec.Security.UI == nil {
+		return false
+	}
+	switch component {
+	case scmComponent, omComponent, reconComponent:
+	default:
+		return false
+	}
+	components := cluster.Spec.Security.UI.Components
+	if len(components) == 0 {
+		return true
+	}
+	for _, name := range components {
+		if name == component {
+			return true
+		}
+	}
+	return false
+}
+
+// applyUIAuth appends the oauth2-proxy sidecar to a UI-protected component pod. The sidecar
+// handles the OIDC login and proxies authenticated requests to the UI port on localhost; the
+// UI port itself stays off the Service, so only the login flow is reachable from outside the pod.
+func applyUIAuth(cluster *ozonev1alpha1.OzoneCluster, component string, pod *corev1.PodSpec, uiPort int32) {
+	if !uiAuthEnabled(cluster, component) {
+		return
+	}
+	auth := cluster.Spec.Security.UI
+	image := auth.Image
+	if image == "" {
+		image = defaultOAuthProxyImage
+	}
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:  "oauth2-proxy",
+		Image: image,
+		Args: []string{
+			fmt.Sprintf("--http-address=0.0.0.0:%d", oauthProxyPort),
+			fmt.Sprintf("--upstream=http://127.0.0.1:%d", uiPort),
+			"--provider=oidc",
+			"--oidc-issuer-url=" + auth.IssuerURL,
+			"--email-domain=*",
+		},
+		Env: []corev1.EnvVar{
+			{Name: "OAUTH2_PROXY_CLIENT_ID", ValueFrom: secretKeyRef(auth.ClientCredentialsSecret, "client-id")},
+			{Name: "OAUTH2_PROXY_CLIENT_SECRET", ValueFrom: secretKeyRef(auth.ClientCredentialsSecret, "client-secret")},
+			{Name: "OAUTH2_PROXY_COOKIE_SECRET", ValueFrom: secretKeyRef(auth.ClientCredentialsSecret, "cookie-secret")},
+		},
+		Ports: []corev1.ContainerPort{{Name: "oauth-proxy", ContainerPort: oauthProxyPort}},
+	})
+}
+
+// redirectUIPort points the ui port of a UI-protected component Service at the oauth2-proxy
+// sidecar, so every UI request goes through the login flow.
+func redirectUIPort(cluster *ozonev1alpha1.OzoneCluster, component string, svc *corev1.Service) *corev1.Service {
+	if !uiAuthEnabled(cluster, component) {
+		return svc
+	}
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Name == "ui" {
+			svc.Spec.Ports[i].TargetPort = intstr.FromInt32(oauthProxyPort)
+		}
+	}
+	return svc
+}
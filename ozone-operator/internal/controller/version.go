@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// clusterVersion returns the Ozone release the cluster runs: spec.version when set, otherwise
+// the tag of the configured image.
+func clusterVersion(cluster *ozonev1alpha1.OzoneCluster) string {
+	if cluster.Spec.Version != "" {
+		return cluster.Spec.Version
+	}
+	image := clusterImage(cluster)
+	if i := strings.LastIndex(image, ":"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// parseOzoneVersion extracts the major and minor release numbers from a version string such as
+// "1.4.0", "1.4.0-SNAPSHOT", or "ozone-1.4". ok is false when no release can be recognized, e.g.
+// for a "latest" tag or a digest.
+func parseOzoneVersion(version string) (major, minor int, ok bool) {
+	start := strings.IndexFunc(version, func(r rune) bool { return r >= '0' && r <= '9' })
+	if start < 0 {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(version[start:], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	digits := len(parts[1])
+	for i, r := range parts[1] {
+		if r < '0' || r > '9' {
+			digits = i
+			break
+		}
+	}
+	minor, err = strconv.Atoi(parts[1][:digits])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// applyVersionProperties adjusts the generated ozone-site properties to the deployed release:
+// some property names and defaults changed across the 1.x line, and emitting a key the deployed
+// version does not know is at best ignored and at worst rejected at startup. Unrecognized
+// versions keep the current template.
+func applyVersionProperties(cluster *ozonev1alpha1.OzoneCluster, props map[string]string) {
+	major, minor, ok := parseOzoneVersion(clusterVersion(cluster))
+	if !ok || major != 1 {
+		return
+	}
+	if minor < 4 {
+		// OM Ratis had to be enabled explicitly before 1.4 made it the only mode.
+		props["ozone.om.ratis.enable"] = "true"
+	}
+	if minor < 3 {
+		// The per-volume free-space guard only exists since 1.3.
+		delete(props, "hdds.datanode.volume.min.free.space")
+	}
+	if minor < 2 {
+		// The reserved-space key was renamed in 1.2; older releases use the short form.
+		if reserved, found := props["hdds.datanode.dir.du.reserved"]; found {
+			delete(props, "hdds.datanode.dir.du.reserved")
+			props["hdds.datanode.du.reserved"] = reserved
+		}
+	}
+}
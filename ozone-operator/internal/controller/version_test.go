@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+func TestParseOzoneVersion(t *testing.T) {
+	cases := []struct {
+		version      string
+		major, minor int
+		ok           bool
+	}{
+		{version: "1.4.0", major: 1, minor: 4, ok: true},
+		{version: "1.4", major: 1, minor: 4, ok: true},
+		{version: "1.4.0-SNAPSHOT", major: 1, minor: 4, ok: true},
+		{version: "1.5-SNAPSHOT", major: 1, minor: 5, ok: true},
+		{version: "ozone-1.3.0", major: 1, minor: 3, ok: true},
+		{version: "2.0.0", major: 2, minor: 0, ok: true},
+		{version: "latest"},
+		{version: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			major, minor, ok := parseOzoneVersion(tc.version)
+			if ok != tc.ok || major != tc.major || minor != tc.minor {
+				t.Errorf("parseOzoneVersion(%q) = %d, %d, %v; want %d, %d, %v",
+					tc.version, major, minor, ok, tc.major, tc.minor, tc.ok)
+			}
+		})
+	}
+}
+
+func TestApplyVersionProperties(t *testing.T) {
+	generated := func() map[string]string {
+		return map[string]string{
+			"hdds.datanode.volume.min.free.space": "1GB",
+			"hdds.datanode.dir.du.reserved":       "10GB",
+		}
+	}
+	cluster := func(image string) *ozonev1alpha1.OzoneCluster {
+		return &ozonev1alpha1.OzoneCluster{Spec: ozonev1alpha1.OzoneClusterSpec{Image: image}}
+	}
+
+	props := generated()
+	applyVersionProperties(cluster("apache/ozone:1.4.0"), props)
+	if _, found := props["ozone.om.ratis.enable"]; found {
+		t.Error("1.4 should not pin ozone.om.ratis.enable")
+	}
+	if props["hdds.datanode.volume.min.free.space"] != "1GB" {
+		t.Error("1.4 should keep hdds.datanode.volume.min.free.space")
+	}
+
+	props = generated()
+	applyVersionProperties(cluster("apache/ozone:1.3.0"), props)
+	if props["ozone.om.ratis.enable"] != "true" {
+		t.Error("pre-1.4 should enable OM Ratis explicitly")
+	}
+
+	props = generated()
+	applyVersionProperties(cluster("apache/ozone:1.1.0"), props)
+	if _, found := props["hdds.datanode.volume.min.free.space"]; found {
+		t.Error("pre-1.3 should drop hdds.datanode.volume.min.free.space")
+	}
+	if props["hdds.datanode.du.reserved"] != "10GB" {
+		t.Error("pre-1.2 should use the old reserved-space key")
+	}
+
+	c := cluster("apache/ozone:latest")
+	c.Spec.Version = "1.3.0"
+	props = generated()
+	applyVersionProperties(c, props)
+	if props["ozone.om.ratis.enable"] != "true" {
+		t.Error("spec.version should override an unrecognizable image tag")
+	}
+}
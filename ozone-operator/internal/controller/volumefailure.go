@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// ConditionVolumeFailure is True while a datanode carries more failed storage volumes than
+// spec.datanode.failedVolumePolicy tolerates.
+const ConditionVolumeFailure = "VolumeFailure"
+
+// failedVolumesMetric is the datanode gauge counting the volumes its volume scanner has failed,
+// as exported by the VolumeSet on /prom.
+const failedVolumesMetric = "volume_set_num_failed_volumes"
+
+// volumeFailureRequeue is how often the datanode volume failure counts are sampled.
+const volumeFailureRequeue = 5 * time.Minute
+
+// reconcileVolumeFailures samples the failed volume count of every datanode pod from its metrics
+// endpoint into status.failedVolumes and applies spec.datanode.failedVolumePolicy: datanodes over
+// the tolerated count flip the VolumeFailure condition, and with the Decommission action they are
+// decommissioned through SCM so their container replicas move to healthy nodes.
+func (r *OzoneClusterReconciler) reconcileVolumeFailures(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster) (ctrl.Result, error) {
+	policy := cluster.Spec.Datanode.FailedVolumePolicy
+	if policy == nil {
+		return ctrl.Result{}, nil
+	}
+	log := logf.FromContext(ctx)
+	authHeader, err := r.metricsAuthHeader(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previous := map[string]ozonev1alpha1.DatanodeVolumeFailure{}
+	for _, failure := range cluster.Status.FailedVolumes {
+		previous[failure.Pod] = failure
+	}
+	var failures []ozonev1alpha1.DatanodeVolumeFailure
+	for i := int32(0); i < *replicasOrDefault(cluster.Spec.Datanode.Replicas, 3); i++ {
+		url := fmt.Sprintf("%s://%s:%d/prom",
+			metricsScheme(cluster), podFQDN(cluster, datanodeComponent, i), datanodeHTTPPort)
+		metrics, err := r.getPromMetrics(ctx, url, authHeader)
+		if err != nil {
+			// An unreachable pod is covered by the readiness probes; keep any earlier finding so a
+			// decommissioned entry is not forgotten while the pod restarts.
+			pod := fmt.Sprintf("%s-%d", componentName(cluster, datanodeComponent), i)
+			if failure, ok := previous[pod]; ok {
+				failures = append(failures, failure)
+			}
+			log.V(1).Info("skipping unreachable metrics endpoint", "url", url, "error", err.Error())
+			continue
+		}
+		if failed := int32(metrics[failedVolumesMetric]); failed > 0 {
+			pod := fmt.Sprintf("%s-%d", componentName(cluster, datanodeComponent), i)
+			failures = append(failures, ozonev1alpha1.DatanodeVolumeFailure{
+				Pod:            pod,
+				FailedVolumes:  failed,
+				Decommissioned: previous[pod].Decommissioned,
+			})
+		}
+	}
+	cluster.Status.FailedVolumes = failures
+
+	var exceeders []string
+	for i := range failures {
+		if failures[i].FailedVolumes > policy.ToleratedFailures {
+			exceeders = append(exceeders, fmt.Sprintf("%s (%d)", failures[i].Pod, failures[i].FailedVolumes))
+			if policy.Action == ozonev1alpha1.FailedVolumeDecommission && !failures[i].Decommissioned {
+				if err := r.decommissionDatanode(ctx, cluster, failures[i].Pod); err != nil {
+					log.Error(err, "decommission for failed volumes failed", "pod", failures[i].Pod)
+					continue
+				}
+				failures[i].Decommissioned = true
+				r.notify(ctx, cluster, "FailedVolumeDecommission",
+					fmt.Sprintf("decommissioning %s: %d failed volumes exceed the tolerated %d",
+						failures[i].Pod, failures[i].FailedVolumes, policy.ToleratedFailures))
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               ConditionVolumeFailure,
+		Status:             metav1.ConditionFalse,
+		Reason:             "VolumesHealthy",
+		Message:            "no datanode exceeds the tolerated failed volume count",
+		ObservedGeneration: cluster.Generation,
+	}
+	if len(exceeders) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "FailedVolumesExceedPolicy"
+		condition.Message = fmt.Sprintf("failed volumes exceed the tolerated %d: %s",
+			policy.ToleratedFailures, strings.Join(exceeders, ", "))
+	}
+	previousCondition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionVolumeFailure)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	if (previousCondition == nil && condition.Status == metav1.ConditionTrue) ||
+		(previousCondition != nil && previousCondition.Status != condition.Status) {
+		r.notify(ctx, cluster, condition.Reason, condition.Message)
+	}
+	return ctrl.Result{RequeueAfter: volumeFailureRequeue}, nil
+}
+
+// decommissionDatanode issues the SCM decommission command for one datanode pod, so SCM
+// re-replicates its containers before the datanode is taken out of service.
+func (r *OzoneClusterReconciler) decommissionDatanode(ctx context.Context,
+	cluster *ozonev1alpha1.OzoneCluster, pod string) error {
+	if r.Executor == nil {
+		return fmt.Errorf("no executor configured")
+	}
+	scmPod := componentName(cluster, scmComponent) + "-0"
+	host := pod + "." + componentName(cluster, datanodeComponent)
+	_, err := r.Executor.Exec(ctx, cluster.Namespace, scmPod, scmComponent,
+		"ozone", "admin", "datanode", "decommission", host)
+	return err
+}
@@ -0,0 +1,272 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhookcert manages the serving certificate of the operator admission webhooks without
+// external tooling: it keeps a self-signed CA and leaf certificate in a Secret, writes them into
+// the webhook server's cert directory, and patches the CA bundle into the webhook configurations.
+package webhookcert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// certValidity is how long a generated certificate is good for.
+const certValidity = 365 * 24 * time.Hour
+
+// rotateBefore is the remaining lifetime below which the certificate is regenerated. The webhook
+// server watches its cert directory and reloads the files without a restart.
+const rotateBefore = 30 * 24 * time.Hour
+
+// rotationCheckInterval is how often a running operator re-checks the certificate lifetime.
+const rotationCheckInterval = 12 * time.Hour
+
+// Options identifies the webhook service and the objects the certificate is wired into.
+type Options struct {
+	// Namespace is where the operator and its webhook Service run.
+	Namespace string
+
+	// ServiceName is the webhook Service the certificate must be valid for.
+	ServiceName string
+
+	// SecretName is the Secret persisting the CA and leaf, shared across operator restarts.
+	SecretName string
+
+	// CertDir is where tls.crt and tls.key are written for the webhook server.
+	CertDir string
+
+	// MutatingWebhookConfigurationName and ValidatingWebhookConfigurationName receive the CA
+	// bundle; a missing configuration is skipped.
+	MutatingWebhookConfigurationName   string
+	ValidatingWebhookConfigurationName string
+}
+
+// Ensure generates or reuses the webhook serving certificate and wires it everywhere it is
+// needed. It is safe to call repeatedly; a still-valid certificate is only rewritten locally.
+func Ensure(ctx context.Context, cfg *rest.Config, opts Options) error {
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return err
+	}
+	return ensure(ctx, c, opts)
+}
+
+// Rotator re-runs Ensure periodically so a long-running operator renews its certificate before
+// expiry. It implements manager.Runnable.
+type Rotator struct {
+	Config  *rest.Config
+	Options Options
+}
+
+// Start implements manager.Runnable.
+func (r *Rotator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(rotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := Ensure(ctx, r.Config, r.Options); err != nil {
+				logf.FromContext(ctx).Error(err, "webhook certificate rotation failed")
+			}
+		}
+	}
+}
+
+func ensure(ctx context.Context, c client.Client, opts Options) error {
+	log := logf.FromContext(ctx)
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: opts.Namespace, Name: opts.SecretName}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !certificateUsable(secret.Data, opts) {
+		data, genErr := generate(opts)
+		if genErr != nil {
+			return genErr
+		}
+		if err != nil {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: opts.Namespace, Name: opts.SecretName},
+				Type:       corev1.SecretTypeTLS,
+				Data:       data,
+			}
+			if createErr := c.Create(ctx, secret); createErr != nil {
+				return createErr
+			}
+		} else {
+			secret.Data = data
+			if updateErr := c.Update(ctx, secret); updateErr != nil {
+				return updateErr
+			}
+		}
+		log.Info("generated webhook serving certificate", "secret", opts.SecretName)
+	}
+
+	if err := writeCertDir(opts.CertDir, secret.Data); err != nil {
+		return err
+	}
+	return patchCABundles(ctx, c, opts, secret.Data["ca.crt"])
+}
+
+// certificateUsable reports whether the persisted certificate still covers the service and will
+// not expire soon.
+func certificateUsable(data map[string][]byte, opts Options) bool {
+	block, _ := pem.Decode(data[corev1.TLSCertKey])
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	if time.Until(cert.NotAfter) < rotateBefore {
+		return false
+	}
+	return cert.VerifyHostname(serviceFQDN(opts)) == nil && len(data["ca.crt"]) > 0 &&
+		len(data[corev1.TLSPrivateKeyKey]) > 0
+}
+
+// serviceFQDN is the DNS name the API server uses to call the webhook Service.
+func serviceFQDN(opts Options) string {
+	return fmt.Sprintf("%s.%s.svc", opts.ServiceName, opts.Namespace)
+}
+
+// generate creates a fresh self-signed CA and a leaf certificate for the webhook Service.
+func generate(opts Options) (map[string][]byte, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: opts.ServiceName + "-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: serviceFQDN(opts)},
+		DNSNames: []string{
+			opts.ServiceName,
+			fmt.Sprintf("%s.%s", opts.ServiceName, opts.Namespace),
+			serviceFQDN(opts),
+			serviceFQDN(opts) + ".cluster.local",
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	leafKeyDER, err := x509.MarshalECPrivateKey(leafKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		"ca.crt":                pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: leafKeyDER}),
+	}, nil
+}
+
+// writeCertDir places the serving certificate where the webhook server expects it.
+func writeCertDir(dir string, data map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if err := os.WriteFile(filepath.Join(dir, key), data[key], 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchCABundles writes the CA into every webhook of the operator's webhook configurations, so
+// the API server trusts the self-signed serving certificate. Missing configurations are skipped;
+// they simply have not been installed.
+func patchCABundles(ctx context.Context, c client.Client, opts Options, caBundle []byte) error {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err := c.Get(ctx, types.NamespacedName{Name: opts.MutatingWebhookConfigurationName}, mutating)
+	if err == nil {
+		for i := range mutating.Webhooks {
+			mutating.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+		if err := c.Update(ctx, mutating); err != nil {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err = c.Get(ctx, types.NamespacedName{Name: opts.ValidatingWebhookConfigurationName}, validating)
+	if err == nil {
+		for i := range validating.Webhooks {
+			validating.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+		if err := c.Update(ctx, validating); err != nil {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ozoneclient abstracts the admin operations the operator performs against one Ozone
+// cluster. Controllers program against the Client interface instead of assembling `ozone admin`
+// command lines in place, so the admin surface is in one place and unit tests can substitute the
+// Mock without a running cluster.
+package ozoneclient
+
+import "context"
+
+// DatanodeState is the operational state SCM reports for a datanode.
+type DatanodeState string
+
+const (
+	// StateInService marks a datanode taking part in pipelines normally.
+	StateInService DatanodeState = "IN_SERVICE"
+
+	// StateDecommissioning marks a datanode replicating its containers away.
+	StateDecommissioning DatanodeState = "DECOMMISSIONING"
+
+	// StateInMaintenance marks a datanode in maintenance mode.
+	StateInMaintenance DatanodeState = "IN_MAINTENANCE"
+)
+
+// Datanode is one datanode as reported by SCM.
+type Datanode struct {
+	// UUID identifies the datanode in SCM.
+	UUID string
+
+	// State is the operational state of the datanode.
+	State DatanodeState
+}
+
+// StorageReport summarizes SCM pipeline and container replication statistics.
+type StorageReport struct {
+	// OpenPipelines is the number of pipelines in OPEN state.
+	OpenPipelines int32
+
+	// ClosedPipelines is the number of pipelines in CLOSED state.
+	ClosedPipelines int32
+
+	// TotalContainers is the number of containers known to SCM.
+	TotalContainers int64
+
+	// UnderReplicated is the number of containers with fewer replicas than required.
+	UnderReplicated int64
+
+	// OverReplicated is the number of containers with more replicas than required.
+	OverReplicated int64
+}
+
+// Client is the admin surface of one Ozone cluster used by the operator.
+type Client interface {
+	// SafemodeStatus reports whether SCM is in safemode.
+	SafemodeStatus(ctx context.Context) (bool, error)
+
+	// ExitSafemode forces SCM out of safemode.
+	ExitSafemode(ctx context.Context) error
+
+	// ListDatanodes returns the datanodes registered with SCM.
+	ListDatanodes(ctx context.Context) ([]Datanode, error)
+
+	// Roles returns the Ratis roles of an HA service, "scm" or "om".
+	Roles(ctx context.Context, service string) (string, error)
+
+	// StorageReport returns pipeline and container replication statistics from SCM.
+	StorageReport(ctx context.Context) (StorageReport, error)
+
+	// FinalizeUpgrade finalizes a pending SCM upgrade after all components run the new version.
+	FinalizeUpgrade(ctx context.Context) error
+
+	// CreateSnapshot creates a named snapshot of one bucket through the OM.
+	CreateSnapshot(ctx context.Context, volume, bucket, snapshot string) error
+}
@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ozoneclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExecFunc runs a command in a pod of the cluster, typically backed by the Kubernetes exec
+// subresource. The namespace is bound by the caller.
+type ExecFunc func(ctx context.Context, pod, container string, command ...string) (string, error)
+
+// execClient implements Client by running the `ozone` CLI inside the cluster's own pods. That is
+// the operator's production access path: the CLI speaks the native RPC protocol and needs no
+// extra port or credential beyond what the pods already carry.
+type execClient struct {
+	exec         ExecFunc
+	scmPod       string
+	scmContainer string
+	omPod        string
+	omContainer  string
+}
+
+// NewExecClient returns a Client backed by execing the `ozone` CLI in the given SCM and OM pods.
+func NewExecClient(exec ExecFunc, scmPod, scmContainer, omPod, omContainer string) Client {
+	return &execClient{
+		exec:         exec,
+		scmPod:       scmPod,
+		scmContainer: scmContainer,
+		omPod:        omPod,
+		omContainer:  omContainer,
+	}
+}
+
+// SafemodeStatus implements Client.
+func (c *execClient) SafemodeStatus(ctx context.Context) (bool, error) {
+	out, err := c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "safemode", "status")
+	if err != nil {
+		return false, err
+	}
+	return parseSafemodeStatus(out), nil
+}
+
+// parseSafemodeStatus interprets the `ozone admin safemode status` output.
+func parseSafemodeStatus(out string) bool {
+	return !strings.Contains(strings.ToLower(out), "out of safe mode")
+}
+
+// ExitSafemode implements Client.
+func (c *execClient) ExitSafemode(ctx context.Context) error {
+	_, err := c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "safemode", "exit")
+	return err
+}
+
+// ListDatanodes implements Client.
+func (c *execClient) ListDatanodes(ctx context.Context) ([]Datanode, error) {
+	out, err := c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "datanode", "list")
+	if err != nil {
+		return nil, err
+	}
+	return parseDatanodeList(out), nil
+}
+
+// parseDatanodeList extracts the datanode entries from the `ozone admin datanode list` output,
+// which reports one "Datanode: <uuid> ..." line per node followed by its attributes.
+func parseDatanodeList(out string) []Datanode {
+	var datanodes []Datanode
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if uuid, ok := strings.CutPrefix(line, "Datanode:"); ok {
+			fields := strings.Fields(uuid)
+			datanode := Datanode{}
+			if len(fields) > 0 {
+				datanode.UUID = fields[0]
+			}
+			datanodes = append(datanodes, datanode)
+			continue
+		}
+		if state, ok := strings.CutPrefix(line, "Operational State:"); ok && len(datanodes) > 0 {
+			datanodes[len(datanodes)-1].State = DatanodeState(strings.TrimSpace(state))
+		}
+	}
+	return datanodes
+}
+
+// StorageReport implements Client.
+func (c *execClient) StorageReport(ctx context.Context) (StorageReport, error) {
+	report := StorageReport{}
+	pipelines, err := c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "pipeline", "list")
+	if err != nil {
+		return report, err
+	}
+	report.OpenPipelines, report.ClosedPipelines = parsePipelineStates(pipelines)
+
+	containers, err := c.exec(ctx, c.scmPod, c.scmContainer,
+		"ozone", "admin", "replicationmanager", "report")
+	if err != nil {
+		return report, err
+	}
+	parseContainerReport(containers, &report)
+	return report, nil
+}
+
+// parsePipelineStates counts the OPEN and CLOSED pipelines of an `ozone admin pipeline list`
+// output, which reports one "State: <STATE>" per pipeline.
+func parsePipelineStates(out string) (open, closed int32) {
+	for _, line := range strings.Split(out, "\n") {
+		state, ok := strings.CutPrefix(strings.TrimSpace(line), "State:")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(state) {
+		case "OPEN":
+			open++
+		case "CLOSED":
+			closed++
+		}
+	}
+	return open, closed
+}
+
+// parseContainerReport fills the container counters from an `ozone admin replicationmanager
+// report` summary, matching its "<label>: <count>" lines case-insensitively.
+func parseContainerReport(out string, report *StorageReport) {
+	for _, line := range strings.Split(out, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		count, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key = strings.ToLower(key); {
+		case strings.HasPrefix(key, "total containers"):
+			report.TotalContainers = count
+		case strings.HasPrefix(key, "under replicated"):
+			report.UnderReplicated = count
+		case strings.HasPrefix(key, "over replicated"):
+			report.OverReplicated = count
+		}
+	}
+}
+
+// Roles implements Client.
+func (c *execClient) Roles(ctx context.Context, service string) (string, error) {
+	switch service {
+	case "scm":
+		return c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "scm", "roles")
+	case "om":
+		return c.exec(ctx, c.omPod, c.omContainer, "ozone", "admin", "om", "roles")
+	}
+	return "", fmt.Errorf("unknown HA service %q, expected scm or om", service)
+}
+
+// FinalizeUpgrade implements Client.
+func (c *execClient) FinalizeUpgrade(ctx context.Context) error {
+	_, err := c.exec(ctx, c.scmPod, c.scmContainer, "ozone", "admin", "scm", "finalizeupgrade")
+	return err
+}
+
+// CreateSnapshot implements Client.
+func (c *execClient) CreateSnapshot(ctx context.Context, volume, bucket, snapshot string) error {
+	_, err := c.exec(ctx, c.omPod, c.omContainer,
+		"ozone", "sh", "snapshot", "create", "/"+volume+"/"+bucket, snapshot)
+	return err
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ozoneclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseSafemodeStatus(t *testing.T) {
+	tests := []struct {
+		out        string
+		inSafemode bool
+	}{
+		{"SCM is out of safe mode.", false},
+		{"SCM is in safe mode.", true},
+		{"", true},
+	}
+	for _, tc := range tests {
+		if got := parseSafemodeStatus(tc.out); got != tc.inSafemode {
+			t.Errorf("parseSafemodeStatus(%q) = %v, want %v", tc.out, got, tc.inSafemode)
+		}
+	}
+}
+
+func TestParseDatanodeList(t *testing.T) {
+	out := `
+Datanode: 1f10d9d1-1111-4a55-b1f1-aaaaaaaaaaaa (/default-rack/10.0.0.1/dn-0/9866)
+Operational State: IN_SERVICE
+Related pipelines:
+Datanode: 2f10d9d1-2222-4a55-b1f1-bbbbbbbbbbbb (/default-rack/10.0.0.2/dn-1/9866)
+Operational State: DECOMMISSIONING
+`
+	datanodes := parseDatanodeList(out)
+	if len(datanodes) != 2 {
+		t.Fatalf("expected 2 datanodes, got %d: %+v", len(datanodes), datanodes)
+	}
+	if datanodes[0].UUID != "1f10d9d1-1111-4a55-b1f1-aaaaaaaaaaaa" || datanodes[0].State != StateInService {
+		t.Errorf("unexpected first datanode: %+v", datanodes[0])
+	}
+	if datanodes[1].State != StateDecommissioning {
+		t.Errorf("unexpected second datanode state: %+v", datanodes[1])
+	}
+}
+
+func TestExecClientCommandRouting(t *testing.T) {
+	var pods []string
+	var commands []string
+	exec := func(ctx context.Context, pod, container string, command ...string) (string, error) {
+		pods = append(pods, pod)
+		commands = append(commands, strings.Join(command, " "))
+		return "", nil
+	}
+	client := NewExecClient(exec, "cluster-scm-0", "scm", "cluster-om-0", "om")
+	ctx := context.Background()
+
+	if _, err := client.SafemodeStatus(ctx); err != nil {
+		t.Fatalf("SafemodeStatus: %v", err)
+	}
+	if err := client.CreateSnapshot(ctx, "vol1", "bucket1", "snap1"); err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	if _, err := client.Roles(ctx, "om"); err != nil {
+		t.Fatalf("Roles: %v", err)
+	}
+	if _, err := client.Roles(ctx, "datanode"); err == nil {
+		t.Error("expected an error for an unknown HA service")
+	}
+
+	want := []string{"cluster-scm-0", "cluster-om-0", "cluster-om-0"}
+	for i := range want {
+		if pods[i] != want[i] {
+			t.Errorf("call %d ran in pod %s, want %s", i, pods[i], want[i])
+		}
+	}
+	if commands[1] != "ozone sh snapshot create /vol1/bucket1 snap1" {
+		t.Errorf("unexpected snapshot command: %q", commands[1])
+	}
+}
+
+func TestParsePipelineStates(t *testing.T) {
+	out := `
+Pipeline id: 1
+State: OPEN
+Pipeline id: 2
+State: CLOSED
+Pipeline id: 3
+State: OPEN
+`
+	open, closed := parsePipelineStates(out)
+	if open != 2 || closed != 1 {
+		t.Errorf("parsePipelineStates = (%d, %d), want (2, 1)", open, closed)
+	}
+}
+
+func TestParseContainerReport(t *testing.T) {
+	out := `
+Container Summary Report generated at 2026-08-29T10:00:00Z
+Total Containers: 20
+Under Replicated Containers: 2
+Over Replicated Containers: 1
+Missing Containers: 0
+`
+	report := StorageReport{}
+	parseContainerReport(out, &report)
+	if report.TotalContainers != 20 || report.UnderReplicated != 2 || report.OverReplicated != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
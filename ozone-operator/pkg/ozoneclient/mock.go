@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ozoneclient
+
+import "context"
+
+// Mock implements Client for unit tests. Unset fields answer with zero values and no error, so a
+// test only stubs the operations it cares about.
+type Mock struct {
+	// InSafemode is returned by SafemodeStatus.
+	InSafemode bool
+
+	// Datanodes is returned by ListDatanodes.
+	Datanodes []Datanode
+
+	// RolesOutput is returned by Roles for any service.
+	RolesOutput string
+
+	// Report is returned by StorageReport.
+	Report StorageReport
+
+	// Err is returned by every operation when set.
+	Err error
+
+	// Calls records each operation in call order, e.g. "ExitSafemode" or
+	// "CreateSnapshot vol1 bucket1 snap1".
+	Calls []string
+}
+
+var _ Client = &Mock{}
+
+// SafemodeStatus implements Client.
+func (m *Mock) SafemodeStatus(ctx context.Context) (bool, error) {
+	m.Calls = append(m.Calls, "SafemodeStatus")
+	return m.InSafemode, m.Err
+}
+
+// ExitSafemode implements Client.
+func (m *Mock) ExitSafemode(ctx context.Context) error {
+	m.Calls = append(m.Calls, "ExitSafemode")
+	return m.Err
+}
+
+// ListDatanodes implements Client.
+func (m *Mock) ListDatanodes(ctx context.Context) ([]Datanode, error) {
+	m.Calls = append(m.Calls, "ListDatanodes")
+	return m.Datanodes, m.Err
+}
+
+// Roles implements Client.
+func (m *Mock) Roles(ctx context.Context, service string) (string, error) {
+	m.Calls = append(m.Calls, "Roles "+service)
+	return m.RolesOutput, m.Err
+}
+
+// StorageReport implements Client.
+func (m *Mock) StorageReport(ctx context.Context) (StorageReport, error) {
+	m.Calls = append(m.Calls, "StorageReport")
+	return m.Report, m.Err
+}
+
+// FinalizeUpgrade implements Client.
+func (m *Mock) FinalizeUpgrade(ctx context.Context) error {
+	m.Calls = append(m.Calls, "FinalizeUpgrade")
+	return m.Err
+}
+
+// CreateSnapshot implements Client.
+func (m *Mock) CreateSnapshot(ctx context.Context, volume, bucket, snapshot string) error {
+	m.Calls = append(m.Calls, "CreateSnapshot "+volume+" "+bucket+" "+snapshot)
+	return m.Err
+}
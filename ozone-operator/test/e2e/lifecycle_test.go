@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ozonev1alpha1 "github.com/peterxcli/ozone/ozone-operator/api/v1alpha1"
+)
+
+// TestClusterLifecycle walks one OzoneCluster through create, scale, upgrade, backup and delete.
+// The scenarios build on each other and run in order against a shared control plane.
+func TestClusterLifecycle(t *testing.T) {
+	env := startSuite(t)
+	// The backup destination probe always reaches its bucket; the e2e control plane has no
+	// network, and destination failure modes are covered by the backup unit scenarios.
+	env.reconciler.HTTPClient = &http.Client{Transport: roundTripperFunc(
+		func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		})}
+	ctx := context.Background()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ozone-e2e"}}
+	if err := env.client.Create(ctx, namespace); err != nil {
+		t.Fatalf("failed to create the test namespace: %v", err)
+	}
+
+	replicas := int32(1)
+	cluster := &ozonev1alpha1.OzoneCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "lifecycle", Namespace: namespace.Name},
+		Spec: ozonev1alpha1.OzoneClusterSpec{
+			Image: ozoneImage(),
+			SCM:   ozonev1alpha1.SCMSpec{ComponentSpec: ozonev1alpha1.ComponentSpec{Replicas: &replicas}},
+			OM:    ozonev1alpha1.OMSpec{ComponentSpec: ozonev1alpha1.ComponentSpec{Replicas: &replicas}},
+			Datanode: ozonev1alpha1.DatanodeSpec{
+				ComponentSpec: ozonev1alpha1.ComponentSpec{Replicas: &replicas},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+
+	t.Run("create", func(t *testing.T) {
+		if err := env.client.Create(ctx, cluster); err != nil {
+			t.Fatalf("failed to create the cluster: %v", err)
+		}
+		for _, component := range []string{"scm", "om", "datanode"} {
+			name := cluster.Name + "-" + component
+			eventually(t, "StatefulSet "+name+" exists", func() error {
+				sts := &appsv1.StatefulSet{}
+				return env.client.Get(ctx,
+					types.NamespacedName{Namespace: cluster.Namespace, Name: name}, sts)
+			})
+			eventually(t, "headless Service "+name+" exists", func() error {
+				service := &corev1.Service{}
+				return env.client.Get(ctx,
+					types.NamespacedName{Namespace: cluster.Namespace, Name: name}, service)
+			})
+		}
+		eventually(t, "shared ConfigMap exists", func() error {
+			configMap := &corev1.ConfigMap{}
+			return env.client.Get(ctx,
+				types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name + "-config"}, configMap)
+		})
+	})
+
+	t.Run("scale", func(t *testing.T) {
+		scaled := int32(3)
+		if err := env.client.Get(ctx, key, cluster); err != nil {
+			t.Fatalf("failed to refresh the cluster: %v", err)
+		}
+		cluster.Spec.Datanode.Replicas = &scaled
+		if err := env.client.Update(ctx, cluster); err != nil {
+			t.Fatalf("failed to scale the datanodes: %v", err)
+		}
+		eventually(t, "datanode StatefulSet scaled to 3", func() error {
+			sts := &appsv1.StatefulSet{}
+			if err := env.client.Get(ctx,
+				types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name + "-datanode"}, sts); err != nil {
+				return err
+			}
+			if sts.Spec.Replicas == nil || *sts.Spec.Replicas != scaled {
+				return fmt.Errorf("expected 3 replicas, got %v", sts.Spec.Replicas)
+			}
+			return nil
+		})
+	})
+
+	t.Run("upgrade", func(t *testing.T) {
+		upgraded := ozoneImage() + "-upgraded"
+		if err := env.client.Get(ctx, key, cluster); err != nil {
+			t.Fatalf("failed to refresh the cluster: %v", err)
+		}
+		cluster.Spec.Image = upgraded
+		if err := env.client.Update(ctx, cluster); err != nil {
+			t.Fatalf("failed to update the image: %v", err)
+		}
+		eventually(t, "datanode StatefulSet runs the upgraded image", func() error {
+			sts := &appsv1.StatefulSet{}
+			if err := env.client.Get(ctx,
+				types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name + "-datanode"}, sts); err != nil {
+				return err
+			}
+			for _, container := range sts.Spec.Template.Spec.Containers {
+				if container.Name == "datanode" && container.Image == upgraded {
+					return nil
+				}
+			}
+			return fmt.Errorf("datanode container does not run %s yet", upgraded)
+		})
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		if err := env.client.Get(ctx, key, cluster); err != nil {
+			t.Fatalf("failed to refresh the cluster: %v", err)
+		}
+		cluster.Spec.Backup = &ozonev1alpha1.BackupSpec{
+			Destination: "s3://lifecycle-backups",
+			S3: &ozonev1alpha1.BackupS3Spec{
+				Endpoint:          "http://backup.example:9000",
+				CredentialsSecret: "backup-credentials",
+			},
+		}
+		if err := env.client.Update(ctx, cluster); err != nil {
+			t.Fatalf("failed to configure the backup: %v", err)
+		}
+		eventually(t, "BackupDestinationReachable condition is True", func() error {
+			if err := env.client.Get(ctx, key, cluster); err != nil {
+				return err
+			}
+			if !meta.IsStatusConditionTrue(cluster.Status.Conditions, "BackupDestinationReachable") {
+				return fmt.Errorf("conditions: %+v", cluster.Status.Conditions)
+			}
+			return nil
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := env.client.Get(ctx, key, cluster); err != nil {
+			t.Fatalf("failed to refresh the cluster: %v", err)
+		}
+		cluster.Spec.DeletionPolicy = ozonev1alpha1.DeletionPolicyDelete
+		if err := env.client.Update(ctx, cluster); err != nil {
+			t.Fatalf("failed to set the deletion policy: %v", err)
+		}
+		// A claim carrying the cluster label, standing in for the PVCs the StatefulSet
+		// controller would create; envtest runs no StatefulSet controller.
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "data-" + cluster.Name + "-om-0",
+				Namespace: cluster.Namespace,
+				Labels:    map[string]string{"ozone.apache.org/cluster": cluster.Name},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		}
+		if err := env.client.Create(ctx, pvc); err != nil {
+			t.Fatalf("failed to create the stand-in PVC: %v", err)
+		}
+		eventually(t, "deletion finalizer added", func() error {
+			if err := env.client.Get(ctx, key, cluster); err != nil {
+				return err
+			}
+			if len(cluster.Finalizers) == 0 {
+				return fmt.Errorf("no finalizer on the cluster yet")
+			}
+			return nil
+		})
+
+		if err := env.client.Delete(ctx, cluster); err != nil {
+			t.Fatalf("failed to delete the cluster: %v", err)
+		}
+		eventually(t, "cluster is gone", func() error {
+			err := env.client.Get(ctx, key, &ozonev1alpha1.OzoneCluster{})
+			if err == nil {
+				return fmt.Errorf("cluster still exists")
+			}
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			return nil
+		})
+		eventually(t, "cluster PVCs are deleted", func() error {
+			existing := &corev1.PersistentVolumeClaim{}
+			err := env.client.Get(ctx,
+				types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}, existing)
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// The pvc-protection finalizer keeps deleted claims around in envtest; the
+			// deletion timestamp shows the operator issued the delete.
+			if existing.DeletionTimestamp == nil {
+				return fmt.Errorf("PVC not deleted yet")
+			}
+			return nil
+		})
+	})
+}